@@ -0,0 +1,66 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	authdelivery "gobackend/src/auth/delivery"
+	authinterfaces "gobackend/src/auth/interfaces"
+	authrepository "gobackend/src/auth/repository"
+	authroutes "gobackend/src/auth/routes"
+	authservice "gobackend/src/auth/service"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+// RegisterAPIKeyFeature wires the personal API key endpoints of the current user into the router.
+// The router is expected to run the auth middleware.
+func RegisterAPIKeyFeature(router gin.IRouter, database *sql.DB, logService loginterfaces.Service) error {
+	if router == nil {
+		return fmt.Errorf("register api key feature: router is nil")
+	}
+
+	if database == nil {
+		return fmt.Errorf("register api key feature: database is nil")
+	}
+
+	if logService == nil {
+		return fmt.Errorf("register api key feature: log service is nil")
+	}
+
+	userRepository, err := authrepository.NewPostgresUserRepository(database)
+	if err != nil {
+		return fmt.Errorf("initialise auth repository: %w", err)
+	}
+
+	roleRepository, err := authrepository.NewPostgresRoleRepository(database)
+	if err != nil {
+		return fmt.Errorf("initialise role repository: %w", err)
+	}
+
+	service, err := newAPIKeyService(database, userRepository, roleRepository, logService)
+	if err != nil {
+		return err
+	}
+
+	handler := authdelivery.NewAPIKeyHandler(service)
+	authroutes.RegisterAPIKeys(router, handler)
+
+	return nil
+}
+
+// newAPIKeyService builds the service shared by the API key endpoints and the auth middleware.
+func newAPIKeyService(
+	database *sql.DB,
+	users authinterfaces.UserRepository,
+	roles authinterfaces.RoleRepository,
+	logService loginterfaces.Service,
+) (*authservice.APIKeyService, error) {
+	apiKeyRepository, err := authrepository.NewPostgresAPIKeyRepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise api key repository: %w", err)
+	}
+
+	return authservice.NewAPIKeyService(apiKeyRepository, users, roles, logService), nil
+}
@@ -7,71 +7,161 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"gobackend/shared/dbtx"
 	authdelivery "gobackend/src/auth/delivery"
+	authinterfaces "gobackend/src/auth/interfaces"
 	authrepository "gobackend/src/auth/repository"
 	authroutes "gobackend/src/auth/routes"
 	authservice "gobackend/src/auth/service"
-	logrepository "gobackend/src/logs/repository"
-	logservice "gobackend/src/logs/service"
+	loginterfaces "gobackend/src/logs/interfaces"
 )
 
 const (
-	googleClientIDEnv      = "GOOGLE_CLIENT_ID"
-	googleClientSecretEnv  = "GOOGLE_CLIENT_SECRET"
-	googleRedirectURIEnv   = "GOOGLE_REDIRECT_URI"
 	jwtSecretEnv           = "JWT_SECRET"
 	jwtTTLMinutesEnv       = "JWT_TOKEN_TTL_MINUTES"
+	refreshTTLHoursEnv     = "JWT_REFRESH_TTL_HOURS"
 	authSuccessRedirectEnv = "AUTH_SUCCESS_REDIRECT_URL"
 	authFailureRedirectEnv = "AUTH_FAILURE_REDIRECT_URL"
+	bootstrapAdminsEnv     = "AUTH_BOOTSTRAP_ADMIN_EMAILS"
+	authFlowSecretEnv      = "AUTH_FLOW_SECRET"
+	authTokenDeliveryEnv   = "AUTH_TOKEN_DELIVERY"
 
-	defaultJWTTokenTTL = time.Hour
+	defaultJWTTokenTTL     = time.Hour
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
 )
 
 // RegisterAuthFeature wires the auth feature (repository, service, handlers, routes) into the provided router.
-func RegisterAuthFeature(router gin.IRouter, database *sql.DB) error {
+// The auth service is returned so other features can validate access tokens.
+func RegisterAuthFeature(
+	router gin.IRouter,
+	database *sql.DB,
+	logService loginterfaces.Service,
+) (authinterfaces.AuthService, error) {
 	if router == nil {
-		return fmt.Errorf("register auth feature: router is nil")
+		return nil, fmt.Errorf("register auth feature: router is nil")
 	}
 
 	if database == nil {
-		return fmt.Errorf("register auth feature: database is nil")
+		return nil, fmt.Errorf("register auth feature: database is nil")
+	}
+
+	if logService == nil {
+		return nil, fmt.Errorf("register auth feature: log service is nil")
 	}
 
 	userRepository, err := authrepository.NewPostgresUserRepository(database)
 	if err != nil {
-		return fmt.Errorf("initialise auth repository: %w", err)
+		return nil, fmt.Errorf("initialise auth repository: %w", err)
+	}
+
+	roleRepository, err := authrepository.NewPostgresRoleRepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise role repository: %w", err)
+	}
+
+	signupRepository, err := authrepository.NewPostgresSignupPolicyRepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise signup policy repository: %w", err)
 	}
 
-	logRepo := logrepository.NewPostgresRepository(database)
-	if err := logRepo.EnsureSchema(context.Background()); err != nil {
-		return fmt.Errorf("ensure user logs schema: %w", err)
+	sessionRepository, err := authrepository.NewPostgresSessionRepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise session repository: %w", err)
 	}
-	activityLogService := logservice.NewLogService(logRepo)
 
-	authConfig := authservice.GoogleAuthConfig{
-		ClientID:     os.Getenv(googleClientIDEnv),
-		ClientSecret: os.Getenv(googleClientSecretEnv),
-		RedirectURL:  os.Getenv(googleRedirectURIEnv),
-		JWTSecret:    os.Getenv(jwtSecretEnv),
-		TokenTTL:     readJWTTTL(),
-		LogService:   activityLogService,
+	identityRepository, err := authrepository.NewPostgresIdentityRepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise identity repository: %w", err)
 	}
 
-	authService, err := authservice.NewGoogleAuthService(userRepository, authConfig)
+	keyring, err := NewSigningKeyring(database)
 	if err != nil {
-		return fmt.Errorf("initialise google auth service: %w", err)
+		return nil, err
+	}
+
+	if err := keyring.EnsureActiveKey(context.Background(), SigningAlgorithm()); err != nil {
+		return nil, fmt.Errorf("ensure signing key: %w", err)
+	}
+
+	apiKeyService, err := newAPIKeyService(database, userRepository, roleRepository, logService)
+	if err != nil {
+		return nil, err
+	}
+
+	mfaService, err := newMFAService(database, logService)
+	if err != nil {
+		return nil, err
+	}
+
+	providers, err := newIdentityProviders()
+	if err != nil {
+		return nil, fmt.Errorf("initialise identity providers: %w", err)
+	}
+
+	outbox, err := newOutboxService(database)
+	if err != nil {
+		return nil, err
+	}
+
+	authConfig := authservice.OAuthConfig{
+		Providers:            providers,
+		Keyring:              keyring,
+		TokenTTL:             readJWTTTL(),
+		RefreshTokenTTL:      readRefreshTTL(),
+		LogService:           logService,
+		RoleRepo:             roleRepository,
+		SessionRepo:          sessionRepository,
+		IdentityRepo:         identityRepository,
+		APIKeys:              apiKeyService,
+		MFA:                  mfaService,
+		SignupGate:           authservice.NewSignupPolicyService(signupRepository, logService),
+		Transactor:           dbtx.NewTransactor(database),
+		Outbox:               outbox,
+		BootstrapAdminEmails: strings.Split(os.Getenv(bootstrapAdminsEnv), ","),
+	}
+
+	authService, err := authservice.NewOAuthService(userRepository, authConfig)
+	if err != nil {
+		return nil, fmt.Errorf("initialise oauth service: %w", err)
+	}
+
+	linkService, err := newIdentityLinkService(database, providers, logService)
+	if err != nil {
+		return nil, err
+	}
+
+	flows, err := newFlowSealer()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenDelivery := os.Getenv(authTokenDeliveryEnv)
+	if tokenDelivery == "" {
+		tokenDelivery = authdelivery.TokenDeliveryCode
+	}
+	if !authdelivery.IsValidTokenDelivery(tokenDelivery) {
+		return nil, fmt.Errorf("invalid %s value %q; use code, cookie or query", authTokenDeliveryEnv, tokenDelivery)
 	}
 
 	successRedirectURL := os.Getenv(authSuccessRedirectEnv)
 	failureRedirectURL := os.Getenv(authFailureRedirectEnv)
-	handler := authdelivery.NewHandler(authService, successRedirectURL, failureRedirectURL, activityLogService)
+	handler := authdelivery.NewHandler(
+		authService,
+		linkService,
+		flows,
+		successRedirectURL,
+		failureRedirectURL,
+		tokenDelivery,
+		logService,
+	)
 	authroutes.Register(router, handler)
 
-	return nil
+	return authService, nil
 }
 
 func readJWTTTL() time.Duration {
@@ -88,3 +178,18 @@ func readJWTTTL() time.Duration {
 
 	return time.Duration(minutes) * time.Minute
 }
+
+func readRefreshTTL() time.Duration {
+	value := os.Getenv(refreshTTLHoursEnv)
+	if value == "" {
+		return defaultRefreshTokenTTL
+	}
+
+	hours, err := strconv.Atoi(value)
+	if err != nil || hours <= 0 {
+		log.Printf("invalid %s value %q, defaulting to %s", refreshTTLHoursEnv, value, defaultRefreshTokenTTL)
+		return defaultRefreshTokenTTL
+	}
+
+	return time.Duration(hours) * time.Hour
+}
@@ -1,22 +1,34 @@
 package app
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
 
 	bunpodelivery "gobackend/src/bunpo/delivery"
+	bunporepository "gobackend/src/bunpo/repository"
 	bunporoutes "gobackend/src/bunpo/routes"
 	bunposervice "gobackend/src/bunpo/service"
 )
 
 // RegisterBunpoFeature wires the bunpo feature into the router.
-func RegisterBunpoFeature(router gin.IRouter) error {
+func RegisterBunpoFeature(router gin.IRouter, database *sql.DB) error {
 	if router == nil {
 		return fmt.Errorf("register bunpo feature: router is nil")
 	}
 
-	service := bunposervice.NewService()
+	if database == nil {
+		return fmt.Errorf("register bunpo feature: database is nil")
+	}
+
+	repo := bunporepository.NewPostgresRepository(database)
+	if err := repo.EnsureSchema(context.Background()); err != nil {
+		return fmt.Errorf("ensure bunpo schema: %w", err)
+	}
+
+	service := bunposervice.NewService(repo)
 	handler := bunpodelivery.NewHandler(service)
 	bunporoutes.Register(router, handler)
 
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/securecookie"
+	authdelivery "gobackend/src/auth/delivery"
+	"gobackend/src/auth/provider"
+	authrepository "gobackend/src/auth/repository"
+	authroutes "gobackend/src/auth/routes"
+	authservice "gobackend/src/auth/service"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+// RegisterIdentityFeature wires the linked identity endpoints of the current user into the router.
+// The router is expected to run the auth middleware.
+func RegisterIdentityFeature(router gin.IRouter, database *sql.DB, logService loginterfaces.Service) error {
+	if router == nil {
+		return fmt.Errorf("register identity feature: router is nil")
+	}
+
+	if database == nil {
+		return fmt.Errorf("register identity feature: database is nil")
+	}
+
+	if logService == nil {
+		return fmt.Errorf("register identity feature: log service is nil")
+	}
+
+	providers, err := newIdentityProviders()
+	if err != nil {
+		return fmt.Errorf("initialise identity providers: %w", err)
+	}
+
+	service, err := newIdentityLinkService(database, providers, logService)
+	if err != nil {
+		return err
+	}
+
+	flows, err := newFlowSealer()
+	if err != nil {
+		return err
+	}
+
+	handler := authdelivery.NewIdentityHandler(service, flows)
+	authroutes.RegisterIdentities(router, handler)
+
+	return nil
+}
+
+// newIdentityLinkService builds the service shared by the link endpoints and the provider callback.
+func newIdentityLinkService(
+	database *sql.DB,
+	providers *provider.Registry,
+	logService loginterfaces.Service,
+) (*authservice.IdentityLinkService, error) {
+	identityRepository, err := authrepository.NewPostgresIdentityRepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise identity repository: %w", err)
+	}
+
+	service, err := authservice.NewIdentityLinkService(providers, identityRepository, logService)
+	if err != nil {
+		return nil, fmt.Errorf("initialise identity link service: %w", err)
+	}
+
+	return service, nil
+}
+
+// newFlowSealer builds the sealer for the login flow cookie. The login and link handlers must share its secret.
+func newFlowSealer() (*securecookie.Sealer, error) {
+	secret := os.Getenv(authFlowSecretEnv)
+	if secret == "" {
+		secret = os.Getenv(jwtSecretEnv)
+	}
+
+	flows, err := securecookie.NewSealer(secret)
+	if err != nil {
+		return nil, fmt.Errorf("initialise login flow sealer: %w", err)
+	}
+
+	return flows, nil
+}
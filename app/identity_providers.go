@@ -0,0 +1,84 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/provider"
+)
+
+const (
+	googleClientIDEnv     = "GOOGLE_CLIENT_ID"
+	googleClientSecretEnv = "GOOGLE_CLIENT_SECRET"
+	googleRedirectURIEnv  = "GOOGLE_REDIRECT_URI"
+
+	githubClientIDEnv     = "GITHUB_CLIENT_ID"
+	githubClientSecretEnv = "GITHUB_CLIENT_SECRET"
+	githubRedirectURIEnv  = "GITHUB_REDIRECT_URI"
+
+	oidcNameEnv         = "OIDC_PROVIDER_NAME"
+	oidcIssuerURLEnv    = "OIDC_ISSUER_URL"
+	oidcClientIDEnv     = "OIDC_CLIENT_ID"
+	oidcClientSecretEnv = "OIDC_CLIENT_SECRET"
+	oidcRedirectURIEnv  = "OIDC_REDIRECT_URI"
+	oidcScopesEnv       = "OIDC_SCOPES"
+
+	defaultOIDCName = "oidc"
+)
+
+// newIdentityProviders registers every identity provider whose client ID is configured.
+func newIdentityProviders() (*provider.Registry, error) {
+	var providers []authinterfaces.IdentityProvider
+
+	if os.Getenv(googleClientIDEnv) != "" {
+		google, err := provider.NewGoogle(provider.GoogleConfig{
+			ClientID:     os.Getenv(googleClientIDEnv),
+			ClientSecret: os.Getenv(googleClientSecretEnv),
+			RedirectURL:  os.Getenv(googleRedirectURIEnv),
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, google)
+	}
+
+	if os.Getenv(githubClientIDEnv) != "" {
+		github, err := provider.NewGitHub(provider.GitHubConfig{
+			ClientID:     os.Getenv(githubClientIDEnv),
+			ClientSecret: os.Getenv(githubClientSecretEnv),
+			RedirectURL:  os.Getenv(githubRedirectURIEnv),
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, github)
+	}
+
+	if os.Getenv(oidcIssuerURLEnv) != "" {
+		name := os.Getenv(oidcNameEnv)
+		if name == "" {
+			name = defaultOIDCName
+		}
+
+		oidc, err := provider.NewOIDC(provider.OIDCConfig{
+			Name:         name,
+			IssuerURL:    os.Getenv(oidcIssuerURLEnv),
+			ClientID:     os.Getenv(oidcClientIDEnv),
+			ClientSecret: os.Getenv(oidcClientSecretEnv),
+			RedirectURL:  os.Getenv(oidcRedirectURIEnv),
+			Scopes:       strings.Fields(strings.ReplaceAll(os.Getenv(oidcScopesEnv), ",", " ")),
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, oidc)
+	}
+
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no identity provider configured; set %s, %s or %s", googleClientIDEnv, githubClientIDEnv, oidcIssuerURLEnv)
+	}
+
+	return provider.NewRegistry(providers...)
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	kanjidelivery "gobackend/src/kanji/delivery"
+	kanjirepository "gobackend/src/kanji/repository"
+	kanjiroutes "gobackend/src/kanji/routes"
+	kanjiservice "gobackend/src/kanji/service"
+)
+
+// RegisterKanjiFeature wires the kanji catalog endpoints into the router.
+func RegisterKanjiFeature(router gin.IRouter, database *sql.DB) error {
+	if router == nil {
+		return fmt.Errorf("register kanji feature: router is nil")
+	}
+
+	if database == nil {
+		return fmt.Errorf("register kanji feature: database is nil")
+	}
+
+	repo := kanjirepository.NewPostgresRepository(database)
+	if err := repo.EnsureSchema(context.Background()); err != nil {
+		return fmt.Errorf("ensure kanji schema: %w", err)
+	}
+
+	service := kanjiservice.NewKanjiService(repo)
+	handler := kanjidelivery.NewHandler(service)
+	kanjiroutes.Register(router, handler)
+
+	return nil
+}
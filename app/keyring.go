@@ -0,0 +1,80 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"gobackend/shared/securecookie"
+	authrepository "gobackend/src/auth/repository"
+	authservice "gobackend/src/auth/service"
+)
+
+const (
+	keyringSecretEnv     = "JWT_KEYRING_SECRET"
+	signingAlgorithmEnv  = "JWT_SIGNING_ALGORITHM"
+	keyRetentionHoursEnv = "JWT_KEY_RETENTION_HOURS"
+
+	defaultKeyRetention = 24 * time.Hour
+)
+
+// NewSigningKeyring builds the access token keyring shared by the auth feature and the rotate-signing-key command.
+// Private keys are sealed with JWT_KEYRING_SECRET, which is required.
+func NewSigningKeyring(database *sql.DB) (*authservice.Keyring, error) {
+	repository, err := authrepository.NewPostgresSigningKeyRepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise signing key repository: %w", err)
+	}
+
+	secret := os.Getenv(keyringSecretEnv)
+	if secret == "" {
+		return nil, fmt.Errorf("%s is required", keyringSecretEnv)
+	}
+
+	sealer, err := securecookie.NewSealer(secret)
+	if err != nil {
+		return nil, fmt.Errorf("initialise signing key sealer: %w", err)
+	}
+
+	keyring, err := authservice.NewKeyring(authservice.KeyringConfig{
+		Repo:      repository,
+		Sealer:    sealer,
+		Retention: readKeyRetention(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initialise signing keyring: %w", err)
+	}
+
+	return keyring, nil
+}
+
+// SigningAlgorithm returns the algorithm of newly generated keys: RS256 unless JWT_SIGNING_ALGORITHM says otherwise.
+func SigningAlgorithm() string {
+	if algorithm := os.Getenv(signingAlgorithmEnv); algorithm != "" {
+		return algorithm
+	}
+
+	return authservice.AlgorithmRS256
+}
+
+// readKeyRetention returns how long retired keys stay valid; never shorter than the access token TTL.
+func readKeyRetention() time.Duration {
+	retention := defaultKeyRetention
+	if value := os.Getenv(keyRetentionHoursEnv); value != "" {
+		hours, err := strconv.Atoi(value)
+		if err != nil || hours <= 0 {
+			log.Printf("invalid %s value %q, defaulting to %s", keyRetentionHoursEnv, value, defaultKeyRetention)
+		} else {
+			retention = time.Duration(hours) * time.Hour
+		}
+	}
+
+	if tokenTTL := readJWTTTL(); retention < tokenTTL {
+		return tokenTTL
+	}
+
+	return retention
+}
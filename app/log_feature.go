@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"gobackend/infra/mq"
+	"gobackend/src/logs/ingest"
+	logrepository "gobackend/src/logs/repository"
+	logservice "gobackend/src/logs/service"
+)
+
+const (
+	userLogsBatchSizeEnv     = "USER_LOGS_BATCH_SIZE"
+	userLogsFlushIntervalEnv = "USER_LOGS_FLUSH_INTERVAL_MS"
+)
+
+// LogIngestion holds the activity log service shared by every feature and the worker that stores queued entries.
+type LogIngestion struct {
+	Service *logservice.LogService
+	Worker  *ingest.Consumer
+
+	publisher *mq.Publisher
+}
+
+// NewLogIngestion declares the ingestion topology and builds the activity log service. Recorded entries are
+// published to RabbitMQ and written to user_logs by Worker, which the caller must run.
+func NewLogIngestion(database *sql.DB, conn *mq.Connection) (*LogIngestion, error) {
+	if database == nil {
+		return nil, fmt.Errorf("initialise log ingestion: database is nil")
+	}
+
+	if conn == nil {
+		return nil, fmt.Errorf("initialise log ingestion: rabbitmq connection is nil")
+	}
+
+	logRepo := logrepository.NewPostgresRepository(database)
+	if err := logRepo.EnsureSchema(context.Background()); err != nil {
+		return nil, fmt.Errorf("ensure user logs schema: %w", err)
+	}
+
+	if err := conn.Declare(ingest.Topology); err != nil {
+		return nil, fmt.Errorf("declare log ingestion topology: %w", err)
+	}
+
+	publisher, err := mq.NewPublisher(conn, mq.PublisherConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("initialise rabbitmq publisher: %w", err)
+	}
+
+	logPublisher, err := ingest.NewPublisher(publisher)
+	if err != nil {
+		return nil, fmt.Errorf("initialise log publisher: %w", err)
+	}
+
+	worker, err := ingest.NewConsumer(conn, logRepo, ingest.ConsumerConfig{
+		BatchSize:     readPositiveInt(userLogsBatchSizeEnv),
+		FlushInterval: time.Duration(readPositiveInt(userLogsFlushIntervalEnv)) * time.Millisecond,
+	})
+	if err != nil {
+		publisher.Close()
+		return nil, fmt.Errorf("initialise log ingestion worker: %w", err)
+	}
+
+	return &LogIngestion{
+		Service:   logservice.NewLogService(logRepo, logPublisher),
+		Worker:    worker,
+		publisher: publisher,
+	}, nil
+}
+
+// Close waits for entries being published and releases the publisher channels. Entries recorded afterwards
+// are written to user_logs directly.
+func (l *LogIngestion) Close() error {
+	return l.publisher.Close()
+}
+
+// readPositiveInt returns the integer in the environment variable name, or 0 when it is unset or invalid.
+func readPositiveInt(name string) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("invalid %s value %q, using the default", name, value)
+		return 0
+	}
+
+	return parsed
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/securecookie"
+	authdelivery "gobackend/src/auth/delivery"
+	authrepository "gobackend/src/auth/repository"
+	authroutes "gobackend/src/auth/routes"
+	authservice "gobackend/src/auth/service"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+const (
+	authMFASecretEnv = "AUTH_MFA_SECRET"
+	authMFAIssuerEnv = "AUTH_MFA_ISSUER"
+
+	defaultMFAIssuer = "gobackend"
+)
+
+// RegisterMFAFeature wires the second factor endpoints of the current user into the router.
+// The router is expected to run the auth middleware.
+func RegisterMFAFeature(router gin.IRouter, database *sql.DB, logService loginterfaces.Service) error {
+	if router == nil {
+		return fmt.Errorf("register mfa feature: router is nil")
+	}
+
+	if database == nil {
+		return fmt.Errorf("register mfa feature: database is nil")
+	}
+
+	if logService == nil {
+		return fmt.Errorf("register mfa feature: log service is nil")
+	}
+
+	service, err := newMFAService(database, logService)
+	if err != nil {
+		return err
+	}
+
+	handler := authdelivery.NewMFAHandler(service)
+	authroutes.RegisterMFA(router, handler)
+
+	return nil
+}
+
+// newMFAService builds the service shared by the MFA endpoints and the login flow.
+func newMFAService(database *sql.DB, logService loginterfaces.Service) (*authservice.MFAService, error) {
+	mfaRepository, err := authrepository.NewPostgresMFARepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise mfa repository: %w", err)
+	}
+
+	secret := os.Getenv(authMFASecretEnv)
+	if secret == "" {
+		secret = os.Getenv(jwtSecretEnv)
+	}
+
+	sealer, err := securecookie.NewSealer(secret)
+	if err != nil {
+		return nil, fmt.Errorf("initialise mfa secret sealer: %w", err)
+	}
+
+	issuer := os.Getenv(authMFAIssuerEnv)
+	if issuer == "" {
+		issuer = defaultMFAIssuer
+	}
+
+	return authservice.NewMFAService(mfaRepository, sealer, logService, issuer), nil
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gobackend/infra/mq"
+	outboxrelay "gobackend/src/outbox/relay"
+	outboxrepository "gobackend/src/outbox/repository"
+	outboxservice "gobackend/src/outbox/service"
+)
+
+const (
+	outboxBatchSizeEnv      = "OUTBOX_BATCH_SIZE"
+	outboxPollIntervalEnv   = "OUTBOX_POLL_INTERVAL_MS"
+	outboxRetentionHoursEnv = "OUTBOX_RETENTION_HOURS"
+)
+
+// OutboxRelay holds the worker that publishes outbox messages to RabbitMQ.
+type OutboxRelay struct {
+	Worker *outboxrelay.Relay
+
+	publisher *mq.Publisher
+}
+
+// NewOutboxRelay declares the domain events exchange and builds the relay worker, which the caller must run.
+func NewOutboxRelay(database *sql.DB, conn *mq.Connection) (*OutboxRelay, error) {
+	if database == nil {
+		return nil, fmt.Errorf("initialise outbox relay: database is nil")
+	}
+
+	if conn == nil {
+		return nil, fmt.Errorf("initialise outbox relay: rabbitmq connection is nil")
+	}
+
+	repo := outboxrepository.NewPostgresRepository(database)
+	if err := repo.EnsureSchema(context.Background()); err != nil {
+		return nil, fmt.Errorf("ensure outbox schema: %w", err)
+	}
+
+	if err := conn.Declare(outboxrelay.Topology); err != nil {
+		return nil, fmt.Errorf("declare domain events topology: %w", err)
+	}
+
+	publisher, err := mq.NewPublisher(conn, mq.PublisherConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("initialise rabbitmq publisher: %w", err)
+	}
+
+	worker, err := outboxrelay.NewRelay(repo, publisher, outboxrelay.Config{
+		BatchSize:    readPositiveInt(outboxBatchSizeEnv),
+		PollInterval: time.Duration(readPositiveInt(outboxPollIntervalEnv)) * time.Millisecond,
+		Retention:    time.Duration(readPositiveInt(outboxRetentionHoursEnv)) * time.Hour,
+	})
+	if err != nil {
+		publisher.Close()
+		return nil, fmt.Errorf("initialise outbox relay: %w", err)
+	}
+
+	return &OutboxRelay{Worker: worker, publisher: publisher}, nil
+}
+
+// Close waits for messages being published and releases the publisher channels.
+func (r *OutboxRelay) Close() error {
+	return r.publisher.Close()
+}
+
+// newOutboxService builds the outbox that features record domain events in.
+func newOutboxService(database *sql.DB) (*outboxservice.OutboxService, error) {
+	repo := outboxrepository.NewPostgresRepository(database)
+	if err := repo.EnsureSchema(context.Background()); err != nil {
+		return nil, fmt.Errorf("ensure outbox schema: %w", err)
+	}
+
+	return outboxservice.NewOutboxService(repo), nil
+}
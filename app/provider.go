@@ -1,2 +1,21 @@
 package app
 
+import (
+	"fmt"
+	"os"
+
+	"gobackend/shared/identity"
+)
+
+const userReferenceSaltEnv = "USER_REFERENCE_SALT"
+
+// newUserReferenceEncoder builds the hashid encoder shared by every feature that exposes user references.
+// USER_REFERENCE_SALT is required: references must not be derivable from the token signing secret.
+func newUserReferenceEncoder() (*identity.UserReferenceEncoder, error) {
+	referenceSalt := os.Getenv(userReferenceSaltEnv)
+	if referenceSalt == "" {
+		return nil, fmt.Errorf("%s is required", userReferenceSaltEnv)
+	}
+
+	return identity.NewUserReferenceEncoder(referenceSalt)
+}
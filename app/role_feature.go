@@ -0,0 +1,51 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	authdelivery "gobackend/src/auth/delivery"
+	authrepository "gobackend/src/auth/repository"
+	authroutes "gobackend/src/auth/routes"
+	authservice "gobackend/src/auth/service"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+// RegisterRoleFeature wires the role administration endpoints into the router.
+// The router is expected to run the auth and authorization middleware.
+func RegisterRoleFeature(router gin.IRouter, database *sql.DB, logService loginterfaces.Service) error {
+	if router == nil {
+		return fmt.Errorf("register role feature: router is nil")
+	}
+
+	if database == nil {
+		return fmt.Errorf("register role feature: database is nil")
+	}
+
+	if logService == nil {
+		return fmt.Errorf("register role feature: log service is nil")
+	}
+
+	userRepository, err := authrepository.NewPostgresUserRepository(database)
+	if err != nil {
+		return fmt.Errorf("initialise auth repository: %w", err)
+	}
+
+	roleRepository, err := authrepository.NewPostgresRoleRepository(database)
+	if err != nil {
+		return fmt.Errorf("initialise role repository: %w", err)
+	}
+
+	refEncoder, err := newUserReferenceEncoder()
+	if err != nil {
+		return fmt.Errorf("initialise user reference encoder: %w", err)
+	}
+
+	service := authservice.NewRoleService(userRepository, roleRepository, logService)
+	handler := authdelivery.NewRoleHandler(service, refEncoder)
+	authroutes.RegisterRoles(router, handler)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	authdelivery "gobackend/src/auth/delivery"
+	authrepository "gobackend/src/auth/repository"
+	authroutes "gobackend/src/auth/routes"
+	authservice "gobackend/src/auth/service"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+// RegisterSessionFeature wires the session administration endpoints into the router.
+// The router is expected to run the auth and authorization middleware.
+func RegisterSessionFeature(router gin.IRouter, database *sql.DB, logService loginterfaces.Service) error {
+	if router == nil {
+		return fmt.Errorf("register session feature: router is nil")
+	}
+
+	if database == nil {
+		return fmt.Errorf("register session feature: database is nil")
+	}
+
+	if logService == nil {
+		return fmt.Errorf("register session feature: log service is nil")
+	}
+
+	service, err := newSessionService(database, logService)
+	if err != nil {
+		return err
+	}
+
+	refEncoder, err := newUserReferenceEncoder()
+	if err != nil {
+		return fmt.Errorf("initialise user reference encoder: %w", err)
+	}
+
+	handler := authdelivery.NewSessionHandler(service, refEncoder)
+	authroutes.RegisterSessions(router, handler)
+
+	return nil
+}
+
+// newSessionService builds the service shared by the session endpoints and user deactivation.
+func newSessionService(database *sql.DB, logService loginterfaces.Service) (*authservice.SessionService, error) {
+	userRepository, err := authrepository.NewPostgresUserRepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise auth repository: %w", err)
+	}
+
+	sessionRepository, err := authrepository.NewPostgresSessionRepository(database)
+	if err != nil {
+		return nil, fmt.Errorf("initialise session repository: %w", err)
+	}
+
+	return authservice.NewSessionService(userRepository, sessionRepository, logService), nil
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	authdelivery "gobackend/src/auth/delivery"
+	authrepository "gobackend/src/auth/repository"
+	authroutes "gobackend/src/auth/routes"
+	authservice "gobackend/src/auth/service"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+// RegisterSignupPolicyFeature wires the sign-up allowlist and invitation administration endpoints into the router.
+// The router is expected to run the auth and authorization middleware.
+func RegisterSignupPolicyFeature(router gin.IRouter, database *sql.DB, logService loginterfaces.Service) error {
+	if router == nil {
+		return fmt.Errorf("register signup policy feature: router is nil")
+	}
+
+	if database == nil {
+		return fmt.Errorf("register signup policy feature: database is nil")
+	}
+
+	if logService == nil {
+		return fmt.Errorf("register signup policy feature: log service is nil")
+	}
+
+	signupRepository, err := authrepository.NewPostgresSignupPolicyRepository(database)
+	if err != nil {
+		return fmt.Errorf("initialise signup policy repository: %w", err)
+	}
+
+	service := authservice.NewSignupPolicyService(signupRepository, logService)
+	handler := authdelivery.NewSignupPolicyHandler(service)
+	authroutes.RegisterSignupPolicy(router, handler)
+
+	return nil
+}
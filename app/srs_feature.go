@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/dbtx"
+	loginterfaces "gobackend/src/logs/interfaces"
+	srsdelivery "gobackend/src/srs/delivery"
+	srsrepository "gobackend/src/srs/repository"
+	srsroutes "gobackend/src/srs/routes"
+	srsscheduler "gobackend/src/srs/scheduler"
+	srsservice "gobackend/src/srs/service"
+)
+
+const (
+	srsSchedulerEnv        = "SRS_SCHEDULER"
+	srsDesiredRetentionEnv = "SRS_DESIRED_RETENTION"
+
+	defaultSRSScheduler = srsscheduler.SM2Name
+)
+
+// RegisterSRSFeature wires the spaced-repetition review endpoints into the router.
+// The router is expected to run the auth middleware so handlers can read the current user.
+func RegisterSRSFeature(router gin.IRouter, database *sql.DB, logService loginterfaces.Service) error {
+	if router == nil {
+		return fmt.Errorf("register srs feature: router is nil")
+	}
+
+	if database == nil {
+		return fmt.Errorf("register srs feature: database is nil")
+	}
+
+	if logService == nil {
+		return fmt.Errorf("register srs feature: log service is nil")
+	}
+
+	repo := srsrepository.NewPostgresRepository(database)
+	if err := repo.EnsureSchema(context.Background()); err != nil {
+		return fmt.Errorf("ensure review states schema: %w", err)
+	}
+
+	outbox, err := newOutboxService(database)
+	if err != nil {
+		return err
+	}
+
+	service, err := srsservice.NewReviewService(
+		repo,
+		logService,
+		dbtx.NewTransactor(database),
+		outbox,
+		readSRSScheduler(),
+		srsscheduler.NewSM2(),
+		srsscheduler.NewFSRS(readDesiredRetention()),
+	)
+	if err != nil {
+		return fmt.Errorf("initialise review service: %w", err)
+	}
+
+	handler := srsdelivery.NewHandler(service)
+	srsroutes.Register(router, handler)
+
+	return nil
+}
+
+func readSRSScheduler() string {
+	if value := os.Getenv(srsSchedulerEnv); value != "" {
+		return value
+	}
+
+	return defaultSRSScheduler
+}
+
+func readDesiredRetention() float64 {
+	value := os.Getenv(srsDesiredRetentionEnv)
+	if value == "" {
+		return 0
+	}
+
+	retention, err := strconv.ParseFloat(value, 64)
+	if err != nil || retention <= 0 || retention >= 1 {
+		log.Printf("invalid %s value %q, using the scheduler default", srsDesiredRetentionEnv, value)
+		return 0
+	}
+
+	return retention
+}
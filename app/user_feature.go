@@ -4,15 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"os"
 
 	"github.com/gin-gonic/gin"
 
-	"gobackend/shared/identity"
+	"gobackend/shared/dbtx"
 	logdelivery "gobackend/src/logs/delivery"
-	logrepository "gobackend/src/logs/repository"
+	loginterfaces "gobackend/src/logs/interfaces"
 	logroutes "gobackend/src/logs/routes"
-	logservice "gobackend/src/logs/service"
 	userdelivery "gobackend/src/users/delivery"
 	userrepository "gobackend/src/users/repository"
 	userroutes "gobackend/src/users/routes"
@@ -20,7 +18,7 @@ import (
 )
 
 // RegisterUserFeature wires the user endpoints into the router.
-func RegisterUserFeature(router gin.IRouter, database *sql.DB) error {
+func RegisterUserFeature(router gin.IRouter, database *sql.DB, logService loginterfaces.Service) error {
 	if router == nil {
 		return fmt.Errorf("register user feature: router is nil")
 	}
@@ -29,30 +27,35 @@ func RegisterUserFeature(router gin.IRouter, database *sql.DB) error {
 		return fmt.Errorf("register user feature: database is nil")
 	}
 
-	referenceSalt := os.Getenv("USER_REFERENCE_SALT")
-	if referenceSalt == "" {
-		referenceSalt = os.Getenv("JWT_SECRET")
-	}
-	if referenceSalt == "" {
-		referenceSalt = "default-user-reference-salt"
+	if logService == nil {
+		return fmt.Errorf("register user feature: log service is nil")
 	}
 
-	refEncoder, err := identity.NewUserReferenceEncoder(referenceSalt)
+	refEncoder, err := newUserReferenceEncoder()
 	if err != nil {
 		return fmt.Errorf("initialise user reference encoder: %w", err)
 	}
 
 	repo := userrepository.NewPostgresUserRepository(database)
-	service := userservice.NewUserService(repo, refEncoder)
-	handler := userdelivery.NewHandler(service)
+	if err := repo.EnsureSchema(context.Background()); err != nil {
+		return fmt.Errorf("ensure user preferences schema: %w", err)
+	}
 
-	userroutes.Register(router, handler)
+	mfaService, err := newMFAService(database, logService)
+	if err != nil {
+		return err
+	}
 
-	logRepo := logrepository.NewPostgresRepository(database)
-	if err := logRepo.EnsureSchema(context.Background()); err != nil {
-		return fmt.Errorf("ensure user logs schema: %w", err)
+	sessionService, err := newSessionService(database, logService)
+	if err != nil {
+		return err
 	}
-	logService := logservice.NewLogService(logRepo)
+
+	service := userservice.NewUserService(repo, refEncoder, logService, dbtx.NewTransactor(database), mfaService, sessionService)
+	handler := userdelivery.NewHandler(service, refEncoder)
+
+	userroutes.Register(router, handler)
+
 	logHandler := logdelivery.NewHandler(logService, refEncoder)
 	logroutes.Register(router, logHandler)
 
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	vocabularydelivery "gobackend/src/vocabulary/delivery"
+	vocabularyrepository "gobackend/src/vocabulary/repository"
+	vocabularyroutes "gobackend/src/vocabulary/routes"
+	vocabularyservice "gobackend/src/vocabulary/service"
+)
+
+// RegisterVocabularyFeature wires the vocabulary dictionary endpoints into the router.
+func RegisterVocabularyFeature(router gin.IRouter, database *sql.DB) error {
+	if router == nil {
+		return fmt.Errorf("register vocabulary feature: router is nil")
+	}
+
+	if database == nil {
+		return fmt.Errorf("register vocabulary feature: database is nil")
+	}
+
+	repo := vocabularyrepository.NewPostgresRepository(database)
+	if err := repo.EnsureSchema(context.Background()); err != nil {
+		return fmt.Errorf("ensure vocabulary schema: %w", err)
+	}
+
+	service := vocabularyservice.NewVocabularyService(repo)
+	handler := vocabularydelivery.NewHandler(service)
+	vocabularyroutes.Register(router, handler)
+
+	return nil
+}
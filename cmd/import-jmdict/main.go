@@ -0,0 +1,89 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+
+	"gobackend/infra/db"
+	vocabularyrepository "gobackend/src/vocabulary/repository"
+	vocabularyservice "gobackend/src/vocabulary/service"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	var (
+		filePath  = flag.String("file", "", "path to a local JMdict XML file, optionally gzipped (required)")
+		batchSize = flag.Int("batch-size", 500, "number of entries upserted per transaction")
+		dryRun    = flag.Bool("dry-run", false, "report counts without committing any change")
+	)
+	flag.Parse()
+
+	if *filePath == "" {
+		flag.Usage()
+		return errors.New("-file is required")
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("warning: .env file not loaded, falling back to environment variables")
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("open jmdict file: %w", err)
+	}
+	defer file.Close()
+
+	var source io.Reader = file
+	if strings.HasSuffix(*filePath, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("open gzipped jmdict file: %w", err)
+		}
+		defer gzipReader.Close()
+		source = gzipReader
+	}
+
+	database, err := db.OpenConnection()
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+
+	repo := vocabularyrepository.NewPostgresRepository(database)
+	if err := repo.EnsureSchema(ctx); err != nil {
+		return fmt.Errorf("ensure vocabulary schema: %w", err)
+	}
+
+	service := vocabularyservice.NewImportService(repo)
+	result, err := service.ImportJMdict(ctx, source, vocabularyservice.ImportOptions{
+		BatchSize: *batchSize,
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("import jmdict: %w", err)
+	}
+
+	mode := "committed"
+	if *dryRun {
+		mode = "dry run, nothing committed"
+	}
+	log.Printf("jmdict import finished (%s): inserted=%d updated=%d skipped=%d", mode, result.Inserted, result.Updated, result.Skipped)
+
+	return nil
+}
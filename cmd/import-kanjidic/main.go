@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"gobackend/infra/db"
+	kanjirepository "gobackend/src/kanji/repository"
+	kanjiservice "gobackend/src/kanji/service"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	var (
+		filePath  = flag.String("file", "", "path to a local KANJIDIC2 XML file (required)")
+		batchSize = flag.Int("batch-size", 500, "number of characters upserted per transaction")
+		dryRun    = flag.Bool("dry-run", false, "report counts without committing any change")
+	)
+	flag.Parse()
+
+	if *filePath == "" {
+		flag.Usage()
+		return errors.New("-file is required")
+	}
+
+	if *batchSize < 1 || *batchSize > kanjiservice.MaxImportBatchSize {
+		return fmt.Errorf("-batch-size must be between 1 and %d", kanjiservice.MaxImportBatchSize)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("warning: .env file not loaded, falling back to environment variables")
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("open kanjidic file: %w", err)
+	}
+	defer file.Close()
+
+	database, err := db.OpenConnection()
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+
+	repo := kanjirepository.NewPostgresRepository(database)
+	if err := repo.EnsureSchema(ctx); err != nil {
+		return fmt.Errorf("ensure kanji schema: %w", err)
+	}
+
+	service := kanjiservice.NewImportService(repo)
+	result, err := service.ImportKanjidic(ctx, file, kanjiservice.ImportOptions{
+		BatchSize: *batchSize,
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("import kanjidic: %w", err)
+	}
+
+	mode := "committed"
+	if *dryRun {
+		mode = "dry run, nothing committed"
+	}
+	log.Printf("kanjidic import finished (%s): inserted=%d updated=%d skipped=%d", mode, result.Inserted, result.Updated, result.Skipped)
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+
+	"gobackend/app"
+	"gobackend/infra/db"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	var (
+		algorithm = flag.String("algorithm", "", "algorithm of the new key: RS256 or EdDSA (defaults to JWT_SIGNING_ALGORITHM, then RS256)")
+		prune     = flag.Bool("prune", true, "delete keys retired longer than JWT_KEY_RETENTION_HOURS ago")
+	)
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("warning: .env file not loaded, falling back to environment variables")
+	}
+
+	if *algorithm == "" {
+		*algorithm = app.SigningAlgorithm()
+	}
+
+	database, err := db.OpenConnection()
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+
+	keyring, err := app.NewSigningKeyring(database)
+	if err != nil {
+		return err
+	}
+
+	keyID, err := keyring.Rotate(ctx, *algorithm)
+	if err != nil {
+		return fmt.Errorf("rotate signing key: %w", err)
+	}
+	log.Printf("signing key rotated: kid=%s algorithm=%s", keyID, *algorithm)
+
+	if *prune {
+		removed, err := keyring.Prune(ctx)
+		if err != nil {
+			return fmt.Errorf("prune signing keys: %w", err)
+		}
+		log.Printf("retired signing keys pruned: removed=%d", removed)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package mq
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// Backoff computes exponentially growing delays between retries. Zero values use the defaults.
+type Backoff struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Delay returns the wait before retry number attempt (starting at 0): Min doubled per attempt, capped at Max,
+// with up to half of it randomised so reconnecting clients do not retry in lockstep.
+func (b Backoff) Delay(attempt int) time.Duration {
+	minDelay, maxDelay := b.Min, b.Max
+	if minDelay <= 0 {
+		minDelay = defaultMinBackoff
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxBackoff
+	}
+	if maxDelay < minDelay {
+		maxDelay = minDelay
+	}
+
+	delay := minDelay
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// Wait sleeps for Delay(attempt) or until ctx is done.
+func (b Backoff) Wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(b.Delay(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,34 @@
+package mq
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		attempt int
+		want    time.Duration
+	}{
+		{name: "first attempt", backoff: Backoff{Min: time.Second, Max: time.Minute}, attempt: 0, want: time.Second},
+		{name: "doubles per attempt", backoff: Backoff{Min: time.Second, Max: time.Minute}, attempt: 3, want: 8 * time.Second},
+		{name: "capped at max", backoff: Backoff{Min: time.Second, Max: 10 * time.Second}, attempt: 5, want: 10 * time.Second},
+		{name: "large attempt stays capped", backoff: Backoff{Min: time.Second, Max: 10 * time.Second}, attempt: 1000, want: 10 * time.Second},
+		{name: "zero values use defaults", backoff: Backoff{}, attempt: 0, want: defaultMinBackoff},
+		{name: "default max", backoff: Backoff{}, attempt: 100, want: defaultMaxBackoff},
+		{name: "max below min", backoff: Backoff{Min: time.Second, Max: time.Millisecond}, attempt: 4, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := tt.backoff.Delay(tt.attempt)
+				if got < tt.want/2 || got > tt.want {
+					t.Fatalf("Delay(%d) = %s, want between %s and %s", tt.attempt, got, tt.want/2, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+// errDeliveriesClosed reports that the broker closed the consumer channel, usually with the connection.
+var errDeliveriesClosed = errors.New("deliveries channel closed")
+
+// Handler processes one delivery. Returning nil acknowledges it. Errors wrapped with Requeue return it to the
+// queue once; any other error, a second failure or a panic rejects it, so it is dead-lettered when the queue
+// has a dead-letter exchange and dropped otherwise.
+type Handler func(ctx context.Context, delivery amqp.Delivery) error
+
+type requeueError struct {
+	err error
+}
+
+func (e requeueError) Error() string { return e.err.Error() }
+
+func (e requeueError) Unwrap() error { return e.err }
+
+// Requeue marks err as temporary so the delivery is returned to the queue instead of rejected.
+func Requeue(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return requeueError{err: err}
+}
+
+// ConsumerConfig configures a Consumer. Zero values use the defaults.
+type ConsumerConfig struct {
+	Queue string
+	// Tag identifies the consumer in the broker; the broker picks one when empty.
+	Tag string
+	// Concurrency is the most deliveries handled at once; it defaults to 1.
+	Concurrency int
+	// Prefetch is the most unacknowledged deliveries sent by the broker; it defaults to Concurrency.
+	Prefetch int
+	// DrainTimeout bounds how long Run waits for in-flight handlers on shutdown before cancelling their
+	// context; it defaults to 30 seconds.
+	DrainTimeout time.Duration
+	// Backoff spaces out restarts after the channel fails.
+	Backoff Backoff
+}
+
+// Consumer runs a Handler for every delivery of a queue.
+type Consumer struct {
+	conn    *Connection
+	handler Handler
+	cfg     ConsumerConfig
+}
+
+// NewConsumer constructs a Consumer of cfg.Queue on conn.
+func NewConsumer(conn *Connection, cfg ConsumerConfig, handler Handler) (*Consumer, error) {
+	if conn == nil {
+		return nil, fmt.Errorf("rabbitmq connection is nil")
+	}
+
+	if handler == nil {
+		return nil, fmt.Errorf("consumer handler is nil")
+	}
+
+	if cfg.Queue == "" {
+		return nil, fmt.Errorf("consumer queue is required")
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.Prefetch < cfg.Concurrency {
+		cfg.Prefetch = cfg.Concurrency
+	}
+	if cfg.DrainTimeout <= 0 {
+		cfg.DrainTimeout = defaultDrainTimeout
+	}
+
+	return &Consumer{conn: conn, handler: handler, cfg: cfg}, nil
+}
+
+// Run consumes until ctx is done, reopening the channel with backoff whenever it fails. On shutdown it stops
+// receiving, waits for in-flight handlers up to DrainTimeout and returns nil; unhandled prefetched deliveries
+// go back to the queue. It returns ErrClosed if the connection is closed first.
+func (c *Consumer) Run(ctx context.Context) error {
+	for attempt := 0; ; attempt++ {
+		if err := c.conn.WaitReady(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		received, err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, ErrClosed) {
+			return err
+		}
+		if received {
+			attempt = 0
+		}
+
+		log.Printf("consumer of %s interrupted: %v; restarting", c.cfg.Queue, err)
+		if err := c.cfg.Backoff.Wait(ctx, attempt); err != nil {
+			return nil
+		}
+	}
+}
+
+// consume handles deliveries on one channel until it fails or ctx is done. received reports whether any
+// delivery arrived, so Run can reset its backoff.
+func (c *Consumer) consume(ctx context.Context) (received bool, err error) {
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return false, err
+	}
+	defer channel.Close()
+
+	if err := channel.Qos(c.cfg.Prefetch, 0, false); err != nil {
+		return false, fmt.Errorf("set prefetch: %w", err)
+	}
+
+	deliveries, err := channel.Consume(c.cfg.Queue, c.cfg.Tag, false, false, false, false, nil)
+	if err != nil {
+		return false, fmt.Errorf("consume %s: %w", c.cfg.Queue, err)
+	}
+
+	// Handlers keep running through shutdown until they finish or the drain timeout passes.
+	handlerCtx, cancelHandlers := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandlers()
+
+	var (
+		inFlight sync.WaitGroup
+		slots    = make(chan struct{}, c.cfg.Concurrency)
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.drain(&inFlight, cancelHandlers)
+			return received, nil
+		case delivery, ok := <-deliveries:
+			if !ok {
+				inFlight.Wait()
+				return received, errDeliveriesClosed
+			}
+			received = true
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				c.drain(&inFlight, cancelHandlers)
+				return received, nil
+			}
+
+			inFlight.Add(1)
+			go func() {
+				defer inFlight.Done()
+				defer func() { <-slots }()
+				c.handle(handlerCtx, delivery)
+			}()
+		}
+	}
+}
+
+func (c *Consumer) drain(inFlight *sync.WaitGroup, cancelHandlers context.CancelFunc) {
+	drained := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(c.cfg.DrainTimeout):
+		log.Printf("consumer of %s: handlers still running after %s; cancelling them", c.cfg.Queue, c.cfg.DrainTimeout)
+		cancelHandlers()
+		<-drained
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, delivery amqp.Delivery) {
+	err := c.run(ctx, delivery)
+
+	var requeue requeueError
+	switch {
+	case err == nil:
+		if ackErr := delivery.Ack(false); ackErr != nil {
+			log.Printf("ack message %s from %s: %v", delivery.MessageId, c.cfg.Queue, ackErr)
+		}
+	case errors.As(err, &requeue) && !delivery.Redelivered:
+		if nackErr := delivery.Nack(false, true); nackErr != nil {
+			log.Printf("requeue message %s from %s: %v", delivery.MessageId, c.cfg.Queue, nackErr)
+		}
+	default:
+		log.Printf("reject message %s from %s: %v", delivery.MessageId, c.cfg.Queue, err)
+		if nackErr := delivery.Nack(false, false); nackErr != nil {
+			log.Printf("reject message %s from %s: %v", delivery.MessageId, c.cfg.Queue, nackErr)
+		}
+	}
+}
+
+func (c *Consumer) run(ctx context.Context, delivery amqp.Delivery) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panic: %v", recovered)
+		}
+	}()
+
+	return c.handler(ctx, delivery)
+}
@@ -0,0 +1,98 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// fakeAcknowledger records how a delivery was settled.
+type fakeAcknowledger struct {
+	acked   bool
+	nacked  bool
+	requeue bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.nacked = true
+	a.requeue = requeue
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func TestConsumerHandle(t *testing.T) {
+	failure := errors.New("boom")
+
+	tests := []struct {
+		name        string
+		handler     Handler
+		redelivered bool
+		wantAck     bool
+		wantRequeue bool
+	}{
+		{
+			name:    "success acks",
+			handler: func(context.Context, amqp.Delivery) error { return nil },
+			wantAck: true,
+		},
+		{
+			name:        "requeue on first delivery",
+			handler:     func(context.Context, amqp.Delivery) error { return Requeue(failure) },
+			wantRequeue: true,
+		},
+		{
+			name:        "wrapped requeue on first delivery",
+			handler:     func(context.Context, amqp.Delivery) error { return fmt.Errorf("store: %w", Requeue(failure)) },
+			wantRequeue: true,
+		},
+		{
+			name:        "requeue on redelivery rejects",
+			handler:     func(context.Context, amqp.Delivery) error { return Requeue(failure) },
+			redelivered: true,
+		},
+		{
+			name:    "other error rejects",
+			handler: func(context.Context, amqp.Delivery) error { return failure },
+		},
+		{
+			name:    "panic rejects",
+			handler: func(context.Context, amqp.Delivery) error { panic("handler bug") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := &Consumer{handler: tt.handler, cfg: ConsumerConfig{Queue: "test"}}
+			ack := &fakeAcknowledger{}
+
+			consumer.handle(context.Background(), amqp.Delivery{Acknowledger: ack, Redelivered: tt.redelivered})
+
+			if ack.acked != tt.wantAck {
+				t.Errorf("acked = %v, want %v", ack.acked, tt.wantAck)
+			}
+			if ack.nacked == tt.wantAck {
+				t.Errorf("nacked = %v, want %v", ack.nacked, !tt.wantAck)
+			}
+			if ack.requeue != tt.wantRequeue {
+				t.Errorf("requeue = %v, want %v", ack.requeue, tt.wantRequeue)
+			}
+		})
+	}
+}
+
+func TestRequeueNil(t *testing.T) {
+	if err := Requeue(nil); err != nil {
+		t.Errorf("Requeue(nil) = %v, want nil", err)
+	}
+}
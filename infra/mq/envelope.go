@@ -0,0 +1,69 @@
+package mq
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const jsonContentType = "application/json"
+
+// Envelope wraps a JSON payload with the metadata every message carries. Type names the payload schema so
+// consumers can reject messages they do not understand.
+type Envelope[T any] struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Payload    T         `json:"payload"`
+}
+
+// NewEnvelope wraps payload in an Envelope with a random ID and the current time.
+func NewEnvelope[T any](messageType string, payload T) (Envelope[T], error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return Envelope[T]{}, fmt.Errorf("generate message id: %w", err)
+	}
+
+	return Envelope[T]{
+		ID:         hex.EncodeToString(id),
+		Type:       messageType,
+		OccurredAt: time.Now().UTC(),
+		Payload:    payload,
+	}, nil
+}
+
+// Publishing encodes the envelope as a persistent JSON message.
+func (e Envelope[T]) Publishing() (amqp.Publishing, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return amqp.Publishing{}, fmt.Errorf("encode %s message: %w", e.Type, err)
+	}
+
+	return amqp.Publishing{
+		ContentType:  jsonContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    e.ID,
+		Type:         e.Type,
+		Timestamp:    e.OccurredAt,
+		Body:         body,
+	}, nil
+}
+
+// DecodeEnvelope decodes a delivery published from an Envelope. When messageType is not empty, envelopes of
+// another type are refused.
+func DecodeEnvelope[T any](delivery amqp.Delivery, messageType string) (Envelope[T], error) {
+	var envelope Envelope[T]
+	if err := json.Unmarshal(delivery.Body, &envelope); err != nil {
+		return Envelope[T]{}, fmt.Errorf("decode message %s: %w", delivery.MessageId, err)
+	}
+
+	if messageType != "" && envelope.Type != messageType {
+		return Envelope[T]{}, fmt.Errorf("unexpected message type %q, want %q", envelope.Type, messageType)
+	}
+
+	return envelope, nil
+}
@@ -0,0 +1,196 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// testConnection connects to the broker named by RABBITMQ_TEST_URI and skips the test when it is not set.
+func testConnection(t *testing.T) *Connection {
+	t.Helper()
+
+	uri := os.Getenv("RABBITMQ_TEST_URI")
+	if uri == "" {
+		t.Skip("RABBITMQ_TEST_URI not set")
+	}
+
+	conn, err := Connect(uri, Backoff{Min: 50 * time.Millisecond, Max: time.Second})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// testTopology declares an exchange routing to a work queue that dead-letters into a second queue, all
+// named after the test, and deletes them when the test ends.
+func testTopology(t *testing.T, conn *Connection) (exchange, queue, deadLetters string) {
+	t.Helper()
+
+	suffix := fmt.Sprintf("%s.%d", t.Name(), time.Now().UnixNano())
+	exchange, queue = "test.exchange."+suffix, "test.queue."+suffix
+	deadExchange, deadLetters := "test.dlx."+suffix, "test.dlq."+suffix
+
+	topology := Topology{
+		Exchanges: []Exchange{{Name: exchange}, {Name: deadExchange, Kind: amqp.ExchangeFanout}},
+		Queues:    []Queue{{Name: queue, DeadLetterExchange: deadExchange}, {Name: deadLetters}},
+		Bindings:  []Binding{{Queue: queue, Exchange: exchange, Key: "work"}, {Queue: deadLetters, Exchange: deadExchange}},
+	}
+	if err := conn.Declare(topology); err != nil {
+		t.Fatalf("declare topology: %v", err)
+	}
+
+	t.Cleanup(func() {
+		channel, err := conn.Channel()
+		if err != nil {
+			return
+		}
+		defer channel.Close()
+
+		for _, name := range []string{queue, deadLetters} {
+			channel.QueueDelete(name, false, false, false)
+		}
+		for _, name := range []string{exchange, deadExchange} {
+			channel.ExchangeDelete(name, false, false)
+		}
+	})
+
+	return exchange, queue, deadLetters
+}
+
+func publishTest(t *testing.T, conn *Connection, exchange string, body string) {
+	t.Helper()
+
+	publisher, err := NewPublisher(conn, PublisherConfig{Channels: 1})
+	if err != nil {
+		t.Fatalf("new publisher: %v", err)
+	}
+	defer publisher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := publisher.Publish(ctx, exchange, "work", amqp.Publishing{Body: []byte(body)}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+}
+
+// runConsumer runs a consumer of queue until stop is closed or the test times out.
+func runConsumer(t *testing.T, conn *Connection, queue string, handler Handler) (stop func()) {
+	t.Helper()
+
+	consumer, err := NewConsumer(conn, ConsumerConfig{Queue: queue, DrainTimeout: time.Second}, handler)
+	if err != nil {
+		t.Fatalf("new consumer: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	done := make(chan error, 1)
+	go func() { done <- consumer.Run(ctx) }()
+
+	return func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("consumer run: %v", err)
+		}
+	}
+}
+
+func waitForMessage(t *testing.T, conn *Connection, queue string) amqp.Delivery {
+	t.Helper()
+
+	channel, err := conn.Channel()
+	if err != nil {
+		t.Fatalf("open channel: %v", err)
+	}
+	defer channel.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		delivery, ok, err := channel.Get(queue, true)
+		if err != nil {
+			t.Fatalf("get from %s: %v", queue, err)
+		}
+		if ok {
+			return delivery
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatalf("no message arrived in %s", queue)
+	return amqp.Delivery{}
+}
+
+func TestIntegrationConsumerAcks(t *testing.T) {
+	conn := testConnection(t)
+	exchange, queue, _ := testTopology(t, conn)
+
+	received := make(chan string, 1)
+	stop := runConsumer(t, conn, queue, func(ctx context.Context, delivery amqp.Delivery) error {
+		received <- string(delivery.Body)
+		return nil
+	})
+	defer stop()
+
+	publishTest(t, conn, exchange, "hello")
+
+	select {
+	case body := <-received:
+		if body != "hello" {
+			t.Errorf("body = %q, want %q", body, "hello")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not consumed")
+	}
+}
+
+func TestIntegrationConsumerRequeuesOnceThenDeadLetters(t *testing.T) {
+	conn := testConnection(t)
+	exchange, queue, deadLetters := testTopology(t, conn)
+
+	var attempts atomic.Int32
+	stop := runConsumer(t, conn, queue, func(ctx context.Context, delivery amqp.Delivery) error {
+		attempts.Add(1)
+		return Requeue(errors.New("temporarily unavailable"))
+	})
+
+	publishTest(t, conn, exchange, "retry me")
+
+	delivery := waitForMessage(t, conn, deadLetters)
+	stop()
+
+	if string(delivery.Body) != "retry me" {
+		t.Errorf("dead-lettered body = %q, want %q", delivery.Body, "retry me")
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("handler attempts = %d, want 2", got)
+	}
+}
+
+func TestIntegrationConsumerDeadLettersFailures(t *testing.T) {
+	conn := testConnection(t)
+	exchange, queue, deadLetters := testTopology(t, conn)
+
+	var attempts atomic.Int32
+	stop := runConsumer(t, conn, queue, func(ctx context.Context, delivery amqp.Delivery) error {
+		attempts.Add(1)
+		return errors.New("malformed message")
+	})
+
+	publishTest(t, conn, exchange, "broken")
+
+	waitForMessage(t, conn, deadLetters)
+	stop()
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("handler attempts = %d, want 1", got)
+	}
+}
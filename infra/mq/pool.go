@@ -0,0 +1,135 @@
+package mq
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const defaultPoolSize = 4
+
+// ChannelPool lends out up to size channels on a Connection. Idle channels are reused, and closed ones are
+// replaced on the next Get. With confirm set, channels are put in publisher confirm mode.
+type ChannelPool struct {
+	conn    *Connection
+	confirm bool
+	slots   chan struct{}
+	idle    chan *amqp.Channel
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewChannelPool constructs a pool of at most size channels; size <= 0 uses 4.
+func NewChannelPool(conn *Connection, size int, confirm bool) (*ChannelPool, error) {
+	if conn == nil {
+		return nil, fmt.Errorf("rabbitmq connection is nil")
+	}
+
+	if size <= 0 {
+		size = defaultPoolSize
+	}
+
+	return &ChannelPool{
+		conn:    conn,
+		confirm: confirm,
+		slots:   make(chan struct{}, size),
+		idle:    make(chan *amqp.Channel, size),
+	}, nil
+}
+
+// Get returns an open channel, waiting for a free slot and, while reconnecting, for the connection.
+// Every channel obtained from Get must be returned with Put.
+func (p *ChannelPool) Get(ctx context.Context) (*amqp.Channel, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	channel, err := p.get(ctx)
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// Put returns channel to the pool. Channels that are closed, or that the caller marks as broken because an
+// operation on them failed, are closed instead of reused.
+func (p *ChannelPool) Put(channel *amqp.Channel, broken bool) {
+	defer func() { <-p.slots }()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if broken || p.closed || channel.IsClosed() {
+		channel.Close()
+		return
+	}
+
+	// Cannot block: idle holds as many channels as there are slots.
+	p.idle <- channel
+}
+
+// Close closes the idle channels. Channels still lent out are closed when they are returned.
+func (p *ChannelPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	for {
+		select {
+		case channel := <-p.idle:
+			channel.Close()
+		default:
+			return
+		}
+	}
+}
+
+func (p *ChannelPool) get(ctx context.Context) (*amqp.Channel, error) {
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+
+	if closed {
+		return nil, ErrClosed
+	}
+
+	for {
+		var channel *amqp.Channel
+		select {
+		case channel = <-p.idle:
+		default:
+		}
+
+		if channel == nil {
+			break
+		}
+		if !channel.IsClosed() {
+			return channel, nil
+		}
+	}
+
+	if err := p.conn.WaitReady(ctx); err != nil {
+		return nil, err
+	}
+
+	channel, err := p.conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.confirm {
+		if err := channel.Confirm(false); err != nil {
+			channel.Close()
+			return nil, fmt.Errorf("enable publisher confirms: %w", err)
+		}
+	}
+
+	return channel, nil
+}
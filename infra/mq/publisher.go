@@ -0,0 +1,112 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	// ErrNotConfirmed is returned when the broker nacks a published message.
+	ErrNotConfirmed = errors.New("message was not confirmed by the broker")
+	// ErrPublisherClosed is returned when publishing after Close.
+	ErrPublisherClosed = errors.New("publisher is closed")
+)
+
+// Publishable is a message that can be encoded for publishing, such as an Envelope.
+type Publishable interface {
+	Publishing() (amqp.Publishing, error)
+}
+
+// PublisherConfig tunes a Publisher. Zero values use the defaults.
+type PublisherConfig struct {
+	// Channels is the most confirm-mode channels publishing at once.
+	Channels int
+}
+
+// Publisher publishes messages on pooled confirm-mode channels and waits for the broker to confirm each one.
+// A Publisher is safe for concurrent use.
+type Publisher struct {
+	pool *ChannelPool
+
+	mu       sync.RWMutex
+	closed   bool
+	inFlight sync.WaitGroup
+}
+
+// NewPublisher constructs a Publisher on conn.
+func NewPublisher(conn *Connection, cfg PublisherConfig) (*Publisher, error) {
+	pool, err := NewChannelPool(conn, cfg.Channels, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Publisher{pool: pool}, nil
+}
+
+// Publish sends msg to exchange with routing key and blocks until the broker confirms it or ctx is done.
+// While the connection is being re-established Publish waits for it, bounded by ctx.
+func (p *Publisher) Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrPublisherClosed
+	}
+	p.inFlight.Add(1)
+	p.mu.RUnlock()
+	defer p.inFlight.Done()
+
+	channel, err := p.pool.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+	if err != nil {
+		p.pool.Put(channel, true)
+		return fmt.Errorf("publish to %s: %w", exchange, err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		// The confirm may still arrive; do not hand the channel to another publisher while it is pending.
+		p.pool.Put(channel, true)
+		return fmt.Errorf("wait for publish confirmation: %w", err)
+	}
+	p.pool.Put(channel, false)
+
+	if !acked {
+		return ErrNotConfirmed
+	}
+
+	return nil
+}
+
+// PublishMessage encodes msg and publishes it like Publish.
+func (p *Publisher) PublishMessage(ctx context.Context, exchange, key string, msg Publishable) error {
+	publishing, err := msg.Publishing()
+	if err != nil {
+		return err
+	}
+
+	return p.Publish(ctx, exchange, key, publishing)
+}
+
+// Close refuses new publishes, waits for those in flight and closes the channels. The connection is left open.
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	p.inFlight.Wait()
+	p.pool.Close()
+
+	return nil
+}
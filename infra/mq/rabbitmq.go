@@ -1,11 +1,23 @@
 package mq
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+var (
+	// ErrClosed is returned once Close has been called on a Connection.
+	ErrClosed = errors.New("rabbitmq connection is closed")
+	// ErrDisconnected is returned while a Connection is reconnecting.
+	ErrDisconnected = errors.New("rabbitmq connection is down")
+)
+
 // NewConnection establishes a RabbitMQ connection using the provided URI.
 // When uri is empty a local default is used.
 func NewConnection(uri string) (*amqp.Connection, error) {
@@ -19,3 +31,211 @@ func NewConnection(uri string) (*amqp.Connection, error) {
 
 	return amqp.DialConfig(uri, cfg)
 }
+
+// Connection is a RabbitMQ connection that redials with backoff when the broker drops it. Topologies passed to
+// Declare are declared again after every reconnect. Channels opened before a reconnect are closed with the old
+// connection, so publishers and consumers open new ones.
+type Connection struct {
+	uri     string
+	backoff Backoff
+
+	mu         sync.Mutex
+	conn       *amqp.Connection
+	ready      chan struct{}
+	topologies []Topology
+	closed     bool
+	done       chan struct{}
+}
+
+// Connect dials uri (see NewConnection) and keeps the connection up until Close is called.
+func Connect(uri string, backoff Backoff) (*Connection, error) {
+	conn, err := NewConnection(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Connection{
+		uri:     uri,
+		backoff: backoff,
+		ready:   make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	c.mu.Lock()
+	c.attach(conn)
+	c.mu.Unlock()
+
+	return c, nil
+}
+
+// Declare declares topology now and again after every reconnect.
+func (c *Connection) Declare(topology Topology) error {
+	channel, err := c.Channel()
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	if err := topology.Declare(channel); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.topologies = append(c.topologies, topology)
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Channel opens a channel on the current connection.
+func (c *Connection) Channel() (*amqp.Channel, error) {
+	c.mu.Lock()
+	conn, closed := c.conn, c.closed
+	c.mu.Unlock()
+
+	if closed {
+		return nil, ErrClosed
+	}
+	if conn == nil {
+		return nil, ErrDisconnected
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("open rabbitmq channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// WaitReady blocks until the connection is up, ctx is done or the connection is closed.
+func (c *Connection) WaitReady(ctx context.Context) error {
+	c.mu.Lock()
+	ready, closed := c.ready, c.closed
+	c.mu.Unlock()
+
+	if closed {
+		return ErrClosed
+	}
+
+	select {
+	case <-ready:
+		return nil
+	case <-c.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops reconnecting and closes the connection with every channel opened on it.
+func (c *Connection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+	close(c.done)
+
+	if c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// attach makes conn the current connection and watches it for failures. c.mu must be held.
+func (c *Connection) attach(conn *amqp.Connection) {
+	closes := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	c.conn = conn
+	close(c.ready)
+
+	go c.watch(closes)
+}
+
+func (c *Connection) watch(closes <-chan *amqp.Error) {
+	reason := <-closes
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.conn = nil
+	c.ready = make(chan struct{})
+	c.mu.Unlock()
+
+	log.Printf("rabbitmq connection lost: %v; reconnecting", reason)
+	c.reconnect()
+}
+
+func (c *Connection) reconnect() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-c.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for attempt := 0; ; attempt++ {
+		if err := c.backoff.Wait(ctx, attempt); err != nil {
+			return
+		}
+
+		conn, err := NewConnection(c.uri)
+		if err != nil {
+			log.Printf("reconnect to rabbitmq (attempt %d): %v", attempt+1, err)
+			continue
+		}
+
+		if err := c.redeclare(conn); err != nil {
+			log.Printf("redeclare rabbitmq topology: %v", err)
+			conn.Close()
+			continue
+		}
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			conn.Close()
+			return
+		}
+		c.attach(conn)
+		c.mu.Unlock()
+
+		log.Printf("rabbitmq connection restored after %d attempt(s)", attempt+1)
+		return
+	}
+}
+
+func (c *Connection) redeclare(conn *amqp.Connection) error {
+	c.mu.Lock()
+	topologies := append([]Topology(nil), c.topologies...)
+	c.mu.Unlock()
+
+	if len(topologies) == 0 {
+		return nil
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	for _, topology := range topologies {
+		if err := topology.Declare(channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package mq
+
+import (
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Exchange describes a durable exchange. Kind defaults to direct.
+type Exchange struct {
+	Name string
+	Kind string
+	Args amqp.Table
+}
+
+// Queue describes a durable queue. Rejected messages are routed to DeadLetterExchange when it is set.
+type Queue struct {
+	Name               string
+	DeadLetterExchange string
+	Args               amqp.Table
+}
+
+// Binding routes messages published to Exchange with Key into Queue.
+type Binding struct {
+	Queue    string
+	Exchange string
+	Key      string
+}
+
+// Topology is a set of exchanges, queues and bindings declared together.
+type Topology struct {
+	Exchanges []Exchange
+	Queues    []Queue
+	Bindings  []Binding
+}
+
+// Declare declares the exchanges, then the queues, then the bindings. Declaring an unchanged topology again is
+// a no-op; declaring an existing name with different settings closes channel with a precondition error.
+func (t Topology) Declare(channel *amqp.Channel) error {
+	for _, exchange := range t.Exchanges {
+		kind := exchange.Kind
+		if kind == "" {
+			kind = amqp.ExchangeDirect
+		}
+
+		if err := channel.ExchangeDeclare(exchange.Name, kind, true, false, false, false, exchange.Args); err != nil {
+			return fmt.Errorf("declare exchange %s: %w", exchange.Name, err)
+		}
+	}
+
+	for _, queue := range t.Queues {
+		args := amqp.Table{}
+		for key, value := range queue.Args {
+			args[key] = value
+		}
+		if queue.DeadLetterExchange != "" {
+			args["x-dead-letter-exchange"] = queue.DeadLetterExchange
+		}
+
+		if _, err := channel.QueueDeclare(queue.Name, true, false, false, false, args); err != nil {
+			return fmt.Errorf("declare queue %s: %w", queue.Name, err)
+		}
+	}
+
+	for _, binding := range t.Bindings {
+		if err := channel.QueueBind(binding.Queue, binding.Key, binding.Exchange, false, nil); err != nil {
+			return fmt.Errorf("bind queue %s to %s: %w", binding.Queue, binding.Exchange, err)
+		}
+	}
+
+	return nil
+}
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -16,14 +20,57 @@ import (
 	"gobackend/app"
 	"gobackend/infra/db"
 	"gobackend/infra/mq"
+	"gobackend/shared/requestinfo"
+	authmiddleware "gobackend/src/auth/middleware"
+	"gobackend/src/auth/rbac"
 )
 
 const (
 	defaultHTTPAddr   = ":8080"
 	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 15 * time.Second
 	appHTTPAddrEnv    = "APP_HTTP_ADDR"
 )
 
+// publicRoutes are reachable without a bearer token even though they are registered behind the auth middleware.
+// Entries use gin route patterns, e.g. "GET /api/kanji/:character".
+var publicRoutes = []string{
+	"GET /api/kanji",
+	"GET /api/kanji/filter",
+	"GET /api/kanji/:character",
+	"GET /api/vocabulary/search",
+	"GET /api/bunpo",
+	"GET /api/bunpo/search",
+	"GET /api/bunpo/:id",
+}
+
+// routePolicies maps gin route patterns to the permission a caller's roles must grant.
+// Routes that are not listed only require a valid access token; API keys are refused on them.
+var routePolicies = map[string]string{
+	"GET /api/users":                                 rbac.PermissionUsersRead,
+	"GET /api/users/:reference":                      rbac.PermissionUsersRead,
+	"POST /api/users/:reference/deactivate":          rbac.PermissionUsersManage,
+	"POST /api/users/:reference/reactivate":          rbac.PermissionUsersManage,
+	"DELETE /api/users/:reference":                   rbac.PermissionUsersManage,
+	"GET /api/users/logs":                            rbac.PermissionLogsRead,
+	"GET /api/users/:reference/logs":                 rbac.PermissionLogsRead,
+	"GET /api/users/:reference/roles":                rbac.PermissionRolesManage,
+	"POST /api/users/:reference/roles":               rbac.PermissionRolesManage,
+	"POST /api/users/:reference/sessions/revoke":     rbac.PermissionSessionsRevoke,
+	"DELETE /api/users/:reference/roles/:role":       rbac.PermissionRolesManage,
+	"POST /api/bunpo":                                rbac.PermissionContentWrite,
+	"PUT /api/bunpo/:id":                             rbac.PermissionContentWrite,
+	"DELETE /api/bunpo/:id":                          rbac.PermissionContentWrite,
+	"GET /api/admin/signup-policy":                   rbac.PermissionSignupManage,
+	"POST /api/admin/signup-policy/emails":           rbac.PermissionSignupManage,
+	"DELETE /api/admin/signup-policy/emails/:value":  rbac.PermissionSignupManage,
+	"POST /api/admin/signup-policy/domains":          rbac.PermissionSignupManage,
+	"DELETE /api/admin/signup-policy/domains/:value": rbac.PermissionSignupManage,
+	"GET /api/admin/invitations":                     rbac.PermissionSignupManage,
+	"POST /api/admin/invitations":                    rbac.PermissionSignupManage,
+	"DELETE /api/admin/invitations/:id":              rbac.PermissionSignupManage,
+}
+
 func main() {
 	if err := run(); err != nil {
 		log.Fatal(err)
@@ -41,25 +88,82 @@ func run() error {
 	}
 	defer database.Close()
 
-	rabbitConn, err := mq.NewConnection(os.Getenv("RABBITMQ_URI"))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	rabbitConn, err := mq.Connect(os.Getenv("RABBITMQ_URI"), mq.Backoff{})
 	if err != nil {
 		return fmt.Errorf("connect to rabbitmq: %w", err)
 	}
 	defer rabbitConn.Close()
 
+	logs, err := app.NewLogIngestion(database, rabbitConn)
+	if err != nil {
+		return fmt.Errorf("initialise log ingestion: %w", err)
+	}
+	defer logs.Close()
+
+	outbox, err := app.NewOutboxRelay(database, rabbitConn)
+	if err != nil {
+		return fmt.Errorf("initialise outbox relay: %w", err)
+	}
+	defer outbox.Close()
+
+	// Workers stop after the HTTP server so what the last requests recorded is still delivered.
+	stopWorkers := startWorkers(map[string]worker{
+		"log ingestion": logs.Worker,
+		"outbox relay":  outbox.Worker,
+	})
+	defer stopWorkers()
+
 	router := gin.New()
 	router.Use(gin.Logger(), gin.Recovery())
 	router.Use(cors.New(corsConfig()))
+	router.Use(requestinfo.Middleware())
 
-	if err := app.RegisterAuthFeature(router, database); err != nil {
+	authService, err := app.RegisterAuthFeature(router, database, logs.Service)
+	if err != nil {
 		return fmt.Errorf("register auth feature: %w", err)
 	}
-	if err := app.RegisterUserFeature(router, database); err != nil {
+
+	protected := router.Group("",
+		authmiddleware.RequireAuth(authService, publicRoutes...),
+		authmiddleware.Authorize(routePolicies),
+	)
+
+	if err := app.RegisterUserFeature(protected, database, logs.Service); err != nil {
 		return fmt.Errorf("register user feature: %w", err)
 	}
-	if err := app.RegisterBunpoFeature(router); err != nil {
+	if err := app.RegisterIdentityFeature(protected, database, logs.Service); err != nil {
+		return fmt.Errorf("register identity feature: %w", err)
+	}
+	if err := app.RegisterAPIKeyFeature(protected, database, logs.Service); err != nil {
+		return fmt.Errorf("register api key feature: %w", err)
+	}
+	if err := app.RegisterMFAFeature(protected, database, logs.Service); err != nil {
+		return fmt.Errorf("register mfa feature: %w", err)
+	}
+	if err := app.RegisterRoleFeature(protected, database, logs.Service); err != nil {
+		return fmt.Errorf("register role feature: %w", err)
+	}
+	if err := app.RegisterSessionFeature(protected, database, logs.Service); err != nil {
+		return fmt.Errorf("register session feature: %w", err)
+	}
+	if err := app.RegisterSignupPolicyFeature(protected, database, logs.Service); err != nil {
+		return fmt.Errorf("register signup policy feature: %w", err)
+	}
+	if err := app.RegisterBunpoFeature(protected, database); err != nil {
 		return fmt.Errorf("register bunpo feature: %w", err)
 	}
+	if err := app.RegisterKanjiFeature(protected, database); err != nil {
+		return fmt.Errorf("register kanji feature: %w", err)
+	}
+	if err := app.RegisterVocabularyFeature(protected, database); err != nil {
+		return fmt.Errorf("register vocabulary feature: %w", err)
+	}
+	if err := app.RegisterSRSFeature(protected, database, logs.Service); err != nil {
+		return fmt.Errorf("register srs feature: %w", err)
+	}
 
 	server := &http.Server{
 		Addr:              httpAddr(),
@@ -67,14 +171,59 @@ func run() error {
 		ReadHeaderTimeout: readHeaderTimeout,
 	}
 
-	log.Printf("HTTP server listening on %s", server.Addr)
-	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		return fmt.Errorf("http server error: %w", err)
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("HTTP server listening on %s", server.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("http server error: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shut down http server: %w", err)
 	}
 
 	return nil
 }
 
+// worker is a background loop that runs until its context is cancelled.
+type worker interface {
+	Run(ctx context.Context) error
+}
+
+// startWorkers runs each worker in its own goroutine. The returned function cancels them and waits for them
+// to finish.
+func startWorkers(workers map[string]worker) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	for name, w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.Run(ctx); err != nil {
+				log.Printf("%s worker stopped: %v", name, err)
+			}
+		}()
+	}
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
 func httpAddr() string {
 	if addr := os.Getenv(appHTTPAddrEnv); addr != "" {
 		return addr
@@ -87,8 +236,8 @@ func corsConfig() cors.Config {
 	return cors.Config{
 		AllowOrigins:     allowedOrigins(),
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", requestinfo.HeaderRequestID},
+		ExposeHeaders:    []string{"Content-Length", requestinfo.HeaderRequestID},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
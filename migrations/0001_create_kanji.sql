@@ -0,0 +1,19 @@
+-- Kanji catalog used by src/kanji.
+CREATE TABLE IF NOT EXISTS kanji (
+    id             BIGSERIAL PRIMARY KEY,
+    character      TEXT        NOT NULL,
+    onyomi         TEXT[]      NOT NULL DEFAULT '{}',
+    kunyomi        TEXT[]      NOT NULL DEFAULT '{}',
+    meanings       TEXT[]      NOT NULL DEFAULT '{}',
+    stroke_count   INTEGER     NOT NULL,
+    grade          INTEGER,
+    jlpt_level     INTEGER CHECK (jlpt_level BETWEEN 1 AND 5),
+    frequency_rank INTEGER,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS kanji_character_idx ON kanji (character);
+CREATE INDEX IF NOT EXISTS kanji_jlpt_level_idx ON kanji (jlpt_level);
+CREATE INDEX IF NOT EXISTS kanji_grade_idx ON kanji (grade);
+CREATE INDEX IF NOT EXISTS kanji_frequency_rank_idx ON kanji (frequency_rank);
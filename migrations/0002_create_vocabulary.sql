@@ -0,0 +1,52 @@
+-- JMdict vocabulary used by src/vocabulary.
+CREATE TABLE IF NOT EXISTS vocabulary_entries (
+    id           BIGSERIAL PRIMARY KEY,
+    ent_seq      BIGINT      NOT NULL,
+    is_common    BOOLEAN     NOT NULL DEFAULT FALSE,
+    content_hash TEXT        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS vocabulary_entries_ent_seq_idx ON vocabulary_entries (ent_seq);
+
+CREATE TABLE IF NOT EXISTS vocabulary_kanji_forms (
+    id         BIGSERIAL PRIMARY KEY,
+    entry_id   BIGINT  NOT NULL REFERENCES vocabulary_entries (id) ON DELETE CASCADE,
+    position   INTEGER NOT NULL,
+    text       TEXT    NOT NULL,
+    info       TEXT[]  NOT NULL DEFAULT '{}',
+    priorities TEXT[]  NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS vocabulary_kanji_forms_entry_id_idx ON vocabulary_kanji_forms (entry_id);
+CREATE INDEX IF NOT EXISTS vocabulary_kanji_forms_text_idx ON vocabulary_kanji_forms (text text_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS vocabulary_kana_forms (
+    id           BIGSERIAL PRIMARY KEY,
+    entry_id     BIGINT  NOT NULL REFERENCES vocabulary_entries (id) ON DELETE CASCADE,
+    position     INTEGER NOT NULL,
+    text         TEXT    NOT NULL,
+    romaji       TEXT    NOT NULL,
+    no_kanji     BOOLEAN NOT NULL DEFAULT FALSE,
+    restrictions TEXT[]  NOT NULL DEFAULT '{}',
+    info         TEXT[]  NOT NULL DEFAULT '{}',
+    priorities   TEXT[]  NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS vocabulary_kana_forms_entry_id_idx ON vocabulary_kana_forms (entry_id);
+CREATE INDEX IF NOT EXISTS vocabulary_kana_forms_text_idx ON vocabulary_kana_forms (text text_pattern_ops);
+CREATE INDEX IF NOT EXISTS vocabulary_kana_forms_romaji_idx ON vocabulary_kana_forms ((replace(romaji, '''', '')) text_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS vocabulary_senses (
+    id              BIGSERIAL PRIMARY KEY,
+    entry_id        BIGINT  NOT NULL REFERENCES vocabulary_entries (id) ON DELETE CASCADE,
+    position        INTEGER NOT NULL,
+    parts_of_speech TEXT[]  NOT NULL DEFAULT '{}',
+    glosses         TEXT[]  NOT NULL DEFAULT '{}',
+    misc            TEXT[]  NOT NULL DEFAULT '{}',
+    fields          TEXT[]  NOT NULL DEFAULT '{}',
+    info            TEXT[]  NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS vocabulary_senses_entry_id_idx ON vocabulary_senses (entry_id);
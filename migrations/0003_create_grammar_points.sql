@@ -0,0 +1,24 @@
+-- Grammar point catalog used by src/bunpo.
+CREATE TABLE IF NOT EXISTS grammar_points (
+    id         BIGSERIAL PRIMARY KEY,
+    pattern    TEXT        NOT NULL,
+    meaning    TEXT        NOT NULL,
+    formation  TEXT[]      NOT NULL DEFAULT '{}',
+    jlpt_level INTEGER     NOT NULL CHECK (jlpt_level BETWEEN 1 AND 5),
+    nuance     TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS grammar_points_jlpt_level_idx ON grammar_points (jlpt_level);
+
+CREATE TABLE IF NOT EXISTS grammar_examples (
+    id               BIGSERIAL PRIMARY KEY,
+    grammar_point_id BIGINT  NOT NULL REFERENCES grammar_points (id) ON DELETE CASCADE,
+    position         INTEGER NOT NULL,
+    sentence         TEXT    NOT NULL,
+    reading          TEXT    NOT NULL DEFAULT '',
+    translation      TEXT    NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS grammar_examples_grammar_point_id_idx ON grammar_examples (grammar_point_id);
@@ -0,0 +1,21 @@
+-- Spaced-repetition review state used by src/srs.
+CREATE TABLE IF NOT EXISTS review_states (
+    id               BIGSERIAL PRIMARY KEY,
+    user_id          BIGINT           NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    item_type        TEXT             NOT NULL,
+    item_key         TEXT             NOT NULL,
+    scheduler        TEXT             NOT NULL,
+    repetitions      INTEGER          NOT NULL DEFAULT 0,
+    lapses           INTEGER          NOT NULL DEFAULT 0,
+    interval_days    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ease_factor      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    stability        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    difficulty       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    due_at           TIMESTAMPTZ      NOT NULL,
+    last_reviewed_at TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ      NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ      NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS review_states_user_item_idx ON review_states (user_id, item_type, item_key);
+CREATE INDEX IF NOT EXISTS review_states_user_due_idx ON review_states (user_id, due_at);
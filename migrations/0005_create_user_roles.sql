@@ -0,0 +1,13 @@
+-- Role-based access control used by src/auth.
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role       TEXT        NOT NULL CHECK (role IN ('admin', 'editor', 'learner')),
+    granted_by BIGINT      REFERENCES users (id) ON DELETE SET NULL,
+    granted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role)
+);
+
+-- Existing accounts keep working as learners; administrators are granted through AUTH_BOOTSTRAP_ADMIN_EMAILS.
+INSERT INTO user_roles (user_id, role)
+SELECT id, 'learner' FROM users
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,21 @@
+-- Sign-up allowlist and invitations consulted by src/auth before creating a new account.
+CREATE TABLE IF NOT EXISTS signup_rules (
+    id         BIGSERIAL PRIMARY KEY,
+    kind       TEXT        NOT NULL CHECK (kind IN ('email', 'domain')),
+    value      TEXT        NOT NULL,
+    created_by BIGINT      REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, value)
+);
+
+-- Only the SHA-256 hash of an invitation code is stored.
+CREATE TABLE IF NOT EXISTS signup_invitations (
+    id         BIGSERIAL PRIMARY KEY,
+    code_hash  TEXT        NOT NULL UNIQUE,
+    email      TEXT        NOT NULL DEFAULT '',
+    created_by BIGINT      REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_by    BIGINT      REFERENCES users (id) ON DELETE SET NULL,
+    used_at    TIMESTAMPTZ
+);
@@ -0,0 +1,19 @@
+-- Provider identities linked to users by src/auth. users.provider/provider_id keep the primary identity.
+CREATE TABLE IF NOT EXISTS user_identities (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    provider       TEXT        NOT NULL,
+    provider_id    TEXT        NOT NULL,
+    email          TEXT        NOT NULL DEFAULT '',
+    email_verified BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (provider, provider_id),
+    UNIQUE (user_id, provider)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_email ON user_identities (LOWER(email));
+
+-- Existing accounts keep their identity; email_verified is refreshed on their next login.
+INSERT INTO user_identities (user_id, provider, provider_id, email, created_at)
+SELECT id, provider, provider_id, email, created_at FROM users
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,12 @@
+-- Keyring used by src/auth to sign access tokens (RS256 or EdDSA).
+-- private_key holds the PKCS#8 key sealed with JWT_KEYRING_SECRET. Exactly one key is active; retired keys
+-- only verify tokens issued before the rotation and are published in /.well-known/jwks.json until pruned.
+CREATE TABLE IF NOT EXISTS signing_keys (
+    kid         TEXT PRIMARY KEY,
+    algorithm   TEXT        NOT NULL CHECK (algorithm IN ('RS256', 'EdDSA')),
+    private_key TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    retired_at  TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_signing_keys_active ON signing_keys ((retired_at IS NULL)) WHERE retired_at IS NULL;
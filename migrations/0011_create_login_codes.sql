@@ -0,0 +1,9 @@
+-- One-time login codes used by src/auth when AUTH_TOKEN_DELIVERY=code.
+-- The callback redirects with a code instead of tokens; the client trades it once via POST /auth/token.
+CREATE TABLE IF NOT EXISTS login_codes (
+    code_hash  TEXT PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_codes_expires_at ON login_codes (expires_at);
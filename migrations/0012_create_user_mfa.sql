@@ -0,0 +1,21 @@
+-- TOTP second factor used by src/auth. secret is sealed with AUTH_MFA_SECRET; enabled_at stays NULL until the
+-- user confirms enrolment with a first code. last_used_step stops a code from being replayed.
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id         BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    secret          TEXT        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    enabled_at      TIMESTAMPTZ,
+    last_used_step  BIGINT      NOT NULL DEFAULT 0,
+    failed_attempts INT         NOT NULL DEFAULT 0,
+    locked_until    TIMESTAMPTZ
+);
+
+-- One-time recovery codes; only their SHA-256 hash is stored.
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    code_hash  TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    used_at    TIMESTAMPTZ,
+    UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,12 @@
+-- Study and display preferences edited through /api/me (src/users).
+-- A user without a row uses the defaults below. target_jlpt_level uses 1–5 for N1–N5, like kanji.jlpt_level.
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id           BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    ui_language       TEXT        NOT NULL DEFAULT 'en',
+    show_romaji       BOOLEAN     NOT NULL DEFAULT TRUE,
+    show_furigana     BOOLEAN     NOT NULL DEFAULT TRUE,
+    target_jlpt_level SMALLINT CHECK (target_jlpt_level BETWEEN 1 AND 5),
+    daily_review_goal INTEGER     NOT NULL DEFAULT 20,
+    timezone          TEXT        NOT NULL DEFAULT 'UTC',
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+-- Accounts deactivated by an administrator through /api/users/:reference/deactivate (src/users).
+-- src/auth refuses their logins, access tokens, refresh tokens and API keys until they are reactivated.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+-- Structured audit events for src/logs: JSONB metadata, the acting and affected users, and the client the
+-- request came from. Entries written before this migration keep empty values.
+ALTER TABLE user_logs
+    ADD COLUMN IF NOT EXISTS metadata   JSONB  NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS actor_id   BIGINT,
+    ADD COLUMN IF NOT EXISTS target_id  BIGINT,
+    ADD COLUMN IF NOT EXISTS ip_address TEXT,
+    ADD COLUMN IF NOT EXISTS user_agent TEXT,
+    ADD COLUMN IF NOT EXISTS request_id TEXT;
+
+CREATE INDEX IF NOT EXISTS user_logs_action_idx ON user_logs (action);
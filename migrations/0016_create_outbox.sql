@@ -0,0 +1,16 @@
+-- Transactional outbox for domain events (src/outbox). Rows are written in the same transaction as the change
+-- they describe and published to RabbitMQ by the relay worker, which retries failed rows with backoff.
+CREATE TABLE IF NOT EXISTS outbox (
+    id              BIGSERIAL PRIMARY KEY,
+    message_id      TEXT        NOT NULL UNIQUE,
+    event_type      TEXT        NOT NULL,
+    routing_key     TEXT        NOT NULL,
+    payload         JSONB       NOT NULL,
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at         TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (next_attempt_at, id) WHERE sent_at IS NULL;
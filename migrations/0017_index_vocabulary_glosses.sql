@@ -0,0 +1,10 @@
+-- Trigram index backing the English gloss substring search in src/vocabulary. The glosses of a sense are
+-- matched as one newline-separated string, so a single index lookup replaces scanning every gloss array.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE OR REPLACE FUNCTION vocabulary_gloss_text(glosses TEXT[]) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT array_to_string(glosses, E'\n') $$;
+
+CREATE INDEX IF NOT EXISTS vocabulary_senses_glosses_trgm_idx
+    ON vocabulary_senses USING GIN (vocabulary_gloss_text(glosses) gin_trgm_ops);
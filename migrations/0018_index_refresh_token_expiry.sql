@@ -0,0 +1,2 @@
+-- Expired refresh tokens are purged by src/auth whenever a login code is issued.
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens (expires_at);
@@ -0,0 +1,21 @@
+-- The audit trail outlives the accounts it mentions. user_logs must not reference users: a foreign key with
+-- ON DELETE CASCADE would take the audit entries of a deleted user with it, and one without would block the
+-- deletion. Any such constraint is dropped, and user_id is nullable so system entries such as the
+-- account_erased tombstone do not belong to a user's activity log.
+DO $$
+DECLARE
+    constraint_name TEXT;
+BEGIN
+    FOR constraint_name IN
+        SELECT c.conname
+        FROM pg_constraint c
+        WHERE c.contype = 'f'
+          AND c.conrelid = 'public.user_logs'::regclass
+          AND c.confrelid = 'public.users'::regclass
+    LOOP
+        EXECUTE format('ALTER TABLE user_logs DROP CONSTRAINT %I', constraint_name);
+    END LOOP;
+END
+$$;
+
+ALTER TABLE user_logs ALTER COLUMN user_id DROP NOT NULL;
@@ -0,0 +1,6 @@
+-- Envelope ID of log entries written by the src/logs ingestion worker. A message redelivered after its entry was
+-- inserted, for instance when the worker stops before acknowledging it, is skipped instead of inserted twice.
+-- Entries written directly have no message ID.
+ALTER TABLE user_logs ADD COLUMN IF NOT EXISTS message_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_logs_message_id_idx ON user_logs (message_id);
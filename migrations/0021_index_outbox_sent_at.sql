@@ -0,0 +1,2 @@
+-- Sent outbox rows are purged by the src/outbox relay once they are older than OUTBOX_RETENTION_HOURS.
+CREATE INDEX IF NOT EXISTS outbox_sent_at_idx ON outbox (sent_at) WHERE sent_at IS NOT NULL;
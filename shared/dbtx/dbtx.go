@@ -0,0 +1,89 @@
+// Package dbtx lets repositories join a transaction started by their caller. The transaction travels in the
+// context: RunInTx stores it there, and repositories run their statements on Conn(ctx, db) instead of db.
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Executor is implemented by *sql.DB and *sql.Tx.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Transactor runs functions in a database transaction.
+type Transactor interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// WithTx returns a copy of ctx carrying tx.
+func WithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// FromContext returns the transaction carried by ctx, if any.
+func FromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return tx, ok && tx != nil
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none.
+func Conn(ctx context.Context, db *sql.DB) Executor {
+	if tx, ok := FromContext(ctx); ok {
+		return tx
+	}
+
+	return db
+}
+
+// RunInTx calls fn with a context carrying a transaction. When ctx already carries one, fn joins it and the
+// caller decides whether it commits. Otherwise a new transaction is committed if fn succeeds and rolled back
+// if it fails.
+func RunInTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) (err error) {
+	if _, ok := FromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(WithTx(ctx, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DBTransactor is a Transactor backed by a database handle.
+type DBTransactor struct {
+	db *sql.DB
+}
+
+var _ Transactor = (*DBTransactor)(nil)
+
+// NewTransactor constructs a DBTransactor.
+func NewTransactor(db *sql.DB) *DBTransactor {
+	return &DBTransactor{db: db}
+}
+
+// RunInTx runs fn in a transaction on the database; see the package-level RunInTx.
+func (t *DBTransactor) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInTx(ctx, t.db, fn)
+}
@@ -0,0 +1,147 @@
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCacheTTL is how long a fetched key set is used before it is fetched again.
+	DefaultCacheTTL = time.Hour
+	// minRefreshInterval limits refetches triggered by unknown key ids.
+	minRefreshInterval = time.Minute
+)
+
+// ErrKeyNotFound indicates no key with the requested id is published.
+var ErrKeyNotFound = errors.New("jwks: signing key not found")
+
+// Fetcher retrieves a key set. Implementations are swapped in tests to run without network access.
+type Fetcher interface {
+	Fetch(ctx context.Context) (*Set, error)
+}
+
+// FetcherFunc adapts a function to the Fetcher interface.
+type FetcherFunc func(ctx context.Context) (*Set, error)
+
+// Fetch calls f.
+func (f FetcherFunc) Fetch(ctx context.Context) (*Set, error) {
+	return f(ctx)
+}
+
+// HTTPFetcher downloads a key set from a URL.
+type HTTPFetcher struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPFetcher builds a fetcher for url using client.
+func NewHTTPFetcher(url string, client *http.Client) *HTTPFetcher {
+	return &HTTPFetcher{url: url, client: client}
+}
+
+// Fetch downloads and decodes the key set.
+func (f *HTTPFetcher) Fetch(ctx context.Context) (*Set, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build jwks request: %w", err)
+	}
+	request.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded with status %d", f.url, resp.StatusCode)
+	}
+
+	var set Set
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	return &set, nil
+}
+
+// Cache keeps the keys of a Fetcher in memory. The set is fetched again once it is older than the TTL,
+// or when a token names a key id that is not cached yet (at most once a minute), so provider key
+// rotations are picked up without a restart.
+type Cache struct {
+	fetcher Fetcher
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewCache wraps fetcher. A non-positive ttl uses DefaultCacheTTL.
+func NewCache(fetcher Fetcher, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &Cache{fetcher: fetcher, ttl: ttl, now: time.Now}
+}
+
+// Key returns the public key with the given id. When the key set cannot be refreshed, keys that are
+// already cached keep being served.
+func (c *Cache) Key(ctx context.Context, keyID string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	key, cached := c.keys[keyID]
+	expired := now.Sub(c.fetchedAt) >= c.ttl
+	unknown := !cached && now.Sub(c.fetchedAt) >= minRefreshInterval
+
+	if c.keys == nil || expired || unknown {
+		if err := c.refresh(ctx, now); err != nil {
+			if cached {
+				return key, nil
+			}
+			return nil, err
+		}
+		key, cached = c.keys[keyID]
+	}
+
+	if !cached {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (c *Cache) refresh(ctx context.Context, now time.Time) error {
+	set, err := c.fetcher.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := jwk.PublicKey()
+		if err != nil {
+			// Skip keys of types we do not verify with rather than rejecting the whole set.
+			continue
+		}
+		keys[jwk.KeyID] = publicKey
+	}
+
+	c.keys = keys
+	c.fetchedAt = now
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package requestinfo carries the client details of an HTTP request through its context so lower layers,
+// such as the activity log, can record where an action came from.
+package requestinfo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderRequestID carries the request ID from a proxy and back to the client.
+const HeaderRequestID = "X-Request-ID"
+
+const (
+	maxRequestIDLength = 128
+	maxUserAgentLength = 512
+	requestIDBytes     = 16
+)
+
+type contextKey struct{}
+
+// Info describes the client that sent a request.
+type Info struct {
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+// Middleware stores the client IP, user agent and request ID in the request context. A well-formed incoming
+// X-Request-ID is kept so entries can be correlated with proxy logs; otherwise a new ID is generated. The ID is
+// echoed in the response header.
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(HeaderRequestID)
+		if !isValidRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		ctx.Header(HeaderRequestID, requestID)
+
+		userAgent := ctx.Request.UserAgent()
+		if len(userAgent) > maxUserAgentLength {
+			userAgent = userAgent[:maxUserAgentLength]
+		}
+
+		info := Info{
+			IP:        ctx.ClientIP(),
+			UserAgent: userAgent,
+			RequestID: requestID,
+		}
+		ctx.Request = ctx.Request.WithContext(WithInfo(ctx.Request.Context(), info))
+
+		ctx.Next()
+	}
+}
+
+// WithInfo returns a copy of ctx carrying info.
+func WithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the request details stored by Middleware; ok is false outside of an HTTP request.
+func FromContext(ctx context.Context) (Info, bool) {
+	info, ok := ctx.Value(contextKey{}).(Info)
+	return info, ok
+}
+
+func isValidRequestID(value string) bool {
+	if value == "" || len(value) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range value {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) || r == ' ' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	buf := make([]byte, requestIDBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,76 @@
+package securecookie
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidValue indicates a sealed value was tampered with, truncated or sealed with another key.
+var ErrInvalidValue = errors.New("invalid sealed value")
+
+// Sealer encrypts and authenticates small JSON payloads (AES-256-GCM) so they can be stored in cookies.
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+// NewSealer derives the encryption key from secret.
+func NewSealer(secret string) (*Sealer, error) {
+	if secret == "" {
+		return nil, errors.New("securecookie: secret is required")
+	}
+
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("securecookie: create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("securecookie: create gcm: %w", err)
+	}
+
+	return &Sealer{aead: aead}, nil
+}
+
+// Seal encodes value as JSON, encrypts it and returns a URL-safe string.
+func (s *Sealer) Seal(value interface{}) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("securecookie: encode value: %w", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("securecookie: generate nonce: %w", err)
+	}
+
+	sealed := s.aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a string produced by Seal into dest.
+func (s *Sealer) Open(sealed string, dest interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil || len(raw) < s.aead.NonceSize() {
+		return ErrInvalidValue
+	}
+
+	nonce, ciphertext := raw[:s.aead.NonceSize()], raw[s.aead.NonceSize():]
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return ErrInvalidValue
+	}
+
+	if err := json.Unmarshal(plaintext, dest); err != nil {
+		return ErrInvalidValue
+	}
+
+	return nil
+}
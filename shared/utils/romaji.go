@@ -0,0 +1,134 @@
+package utils
+
+import "strings"
+
+const (
+	katakanaStart    = 'ァ'
+	katakanaEnd      = 'ヶ'
+	kanaOffset       = 'ァ' - 'ぁ'
+	sokuon           = 'っ'
+	syllabicN        = 'ん'
+	prolongedSound   = 'ー'
+	romajiApostrophe = "'"
+)
+
+var romajiDigraphs = map[string]string{
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "しぇ": "she",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "ちぇ": "che",
+	"にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo",
+	"みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"じゃ": "ja", "じゅ": "ju", "じょ": "jo", "じぇ": "je",
+	"ぢゃ": "ja", "ぢゅ": "ju", "ぢょ": "jo",
+	"びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo",
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo",
+	"てぃ": "ti", "でぃ": "di", "とぅ": "tu", "どぅ": "du",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo",
+	"ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo",
+	"つぁ": "tsa", "つぃ": "tsi", "つぇ": "tse", "つぉ": "tso",
+}
+
+var romajiMonographs = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa",
+}
+
+// KanaToRomaji transliterates hiragana and katakana into lowercase Hepburn romaji.
+// Characters that are not kana are copied unchanged.
+func KanaToRomaji(text string) string {
+	runes := []rune(toHiragana(text))
+
+	var (
+		builder     strings.Builder
+		pendingStop bool
+	)
+
+	for i := 0; i < len(runes); i++ {
+		var syllable string
+
+		if i+1 < len(runes) {
+			if digraph, ok := romajiDigraphs[string(runes[i:i+2])]; ok {
+				syllable = digraph
+				i++
+			}
+		}
+
+		if syllable == "" {
+			switch r := runes[i]; r {
+			case sokuon:
+				pendingStop = true
+				continue
+			case syllabicN:
+				syllable = "n"
+				if i+1 < len(runes) && startsWithVowelOrY(runes[i+1]) {
+					syllable += romajiApostrophe
+				}
+			case prolongedSound:
+				syllable = lastVowel(builder.String())
+			default:
+				if mono, ok := romajiMonographs[r]; ok {
+					syllable = mono
+				} else {
+					syllable = string(r)
+				}
+			}
+		}
+
+		if pendingStop {
+			pendingStop = false
+			switch {
+			case strings.HasPrefix(syllable, "ch"):
+				builder.WriteByte('t')
+			case syllable != "" && !strings.ContainsRune("aiueon", rune(syllable[0])):
+				builder.WriteByte(syllable[0])
+			}
+		}
+
+		builder.WriteString(syllable)
+	}
+
+	return builder.String()
+}
+
+func toHiragana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= katakanaStart && r <= katakanaEnd {
+			return r - kanaOffset
+		}
+		return r
+	}, text)
+}
+
+func startsWithVowelOrY(r rune) bool {
+	mono, ok := romajiMonographs[r]
+	return ok && strings.ContainsRune("aiueoy", rune(mono[0]))
+}
+
+func lastVowel(text string) string {
+	for i := len(text) - 1; i >= 0; i-- {
+		if strings.IndexByte("aiueo", text[i]) >= 0 {
+			return string(text[i])
+		}
+	}
+	return ""
+}
@@ -1,2 +1,11 @@
 package utils
 
+import "strings"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes the LIKE wildcards in value so it matches literally in a pattern using the backslash
+// escape character.
+func EscapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
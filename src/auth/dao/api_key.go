@@ -0,0 +1,16 @@
+package dao
+
+import "time"
+
+// APIKey represents a stored personal API key. Only the hash of the key is kept.
+type APIKey struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Label      string    `json:"label"`
+	Prefix     string    `json:"prefix"`
+	KeyHash    string    `json:"key_hash"`
+	Scopes     []string  `json:"scopes"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	RevokedAt  time.Time `json:"revoked_at"`
+}
@@ -0,0 +1,14 @@
+package dao
+
+import "time"
+
+// Identity links a provider account to a user. A user holds at most one identity per provider.
+type Identity struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"user_id"`
+	Provider      string    `json:"provider"`
+	ProviderID    string    `json:"provider_id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package dao
+
+import "time"
+
+// MFA is the TOTP enrolment of a user. A zero EnabledAt marks an enrolment that was not confirmed yet.
+type MFA struct {
+	UserID         int64     `json:"user_id"`
+	Secret         string    `json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+	EnabledAt      time.Time `json:"enabled_at"`
+	LastUsedStep   int64     `json:"last_used_step"`
+	FailedAttempts int       `json:"failed_attempts"`
+	LockedUntil    time.Time `json:"locked_until"`
+	// RecoveryCodesLeft counts the unused recovery codes.
+	RecoveryCodesLeft int `json:"recovery_codes_left"`
+}
@@ -0,0 +1,16 @@
+package dao
+
+import "time"
+
+// RefreshToken represents a stored refresh token. Tokens issued by rotating one another share a FamilyID.
+type RefreshToken struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	TokenHash string    `json:"token_hash"`
+	FamilyID  string    `json:"family_id"`
+	ParentID  int64     `json:"parent_id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UsedAt    time.Time `json:"used_at"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
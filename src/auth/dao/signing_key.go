@@ -0,0 +1,12 @@
+package dao
+
+import "time"
+
+// SigningKey is a stored access token signing key. A zero RetiredAt marks the active key.
+type SigningKey struct {
+	KeyID      string    `json:"kid"`
+	Algorithm  string    `json:"algorithm"`
+	PrivateKey string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	RetiredAt  time.Time `json:"retired_at"`
+}
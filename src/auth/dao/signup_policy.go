@@ -0,0 +1,30 @@
+package dao
+
+import "time"
+
+// Signup rule kinds.
+const (
+	SignupRuleEmail  = "email"
+	SignupRuleDomain = "domain"
+)
+
+// SignupRule represents an allowlisted email address or Google Workspace domain.
+type SignupRule struct {
+	ID        int64     `json:"id"`
+	Kind      string    `json:"kind"`
+	Value     string    `json:"value"`
+	CreatedBy int64     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Invitation represents a single-use sign-up invitation. Only the hash of the code is stored.
+type Invitation struct {
+	ID        int64     `json:"id"`
+	CodeHash  string    `json:"code_hash"`
+	Email     string    `json:"email"`
+	CreatedBy int64     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UsedBy    int64     `json:"used_by"`
+	UsedAt    time.Time `json:"used_at"`
+}
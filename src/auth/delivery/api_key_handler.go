@@ -0,0 +1,150 @@
+package delivery
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/response"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/middleware"
+	authservice "gobackend/src/auth/service"
+)
+
+// APIKeyHandler exposes the personal API keys of the current user.
+type APIKeyHandler struct {
+	service authinterfaces.APIKeyService
+}
+
+// NewAPIKeyHandler builds an APIKeyHandler.
+func NewAPIKeyHandler(service authinterfaces.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: service}
+}
+
+// ListAPIKeys returns the active API keys of the current user.
+func (h *APIKeyHandler) ListAPIKeys(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, "api keys cannot manage api keys")
+	if !ok {
+		return
+	}
+
+	keys, err := h.service.ListAPIKeys(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "api keys retrieved successfully", keys)
+}
+
+// CreateAPIKey issues an API key. The key is only shown in this response.
+func (h *APIKeyHandler) CreateAPIKey(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, "api keys cannot manage api keys")
+	if !ok {
+		return
+	}
+
+	var req dto.APIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	key, err := h.service.CreateAPIKey(ctx.Request.Context(), claims.UserID, claims.Roles, req)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.Created(ctx, "api key created", key)
+}
+
+// UpdateAPIKey relabels an API key of the current user.
+func (h *APIKeyHandler) UpdateAPIKey(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, "api keys cannot manage api keys")
+	if !ok {
+		return
+	}
+
+	keyID, ok := parseAPIKeyID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.APIKeyUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	key, err := h.service.UpdateAPIKey(ctx.Request.Context(), claims.UserID, keyID, req)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "api key updated", key)
+}
+
+// RevokeAPIKey revokes an API key of the current user.
+func (h *APIKeyHandler) RevokeAPIKey(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, "api keys cannot manage api keys")
+	if !ok {
+		return
+	}
+
+	keyID, ok := parseAPIKeyID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(ctx.Request.Context(), claims.UserID, keyID); err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.NoContent(ctx)
+}
+
+// sessionClaims returns the caller's claims and refuses callers authenticated with an API key with forbidden.
+// Credentials can only be managed with an access token, so a leaked key cannot mint further keys or
+// replace the second factor.
+func sessionClaims(ctx *gin.Context, forbidden string) (*dto.Claims, bool) {
+	claims, ok := middleware.CurrentClaims(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return nil, false
+	}
+
+	if claims.APIKeyID != 0 {
+		response.Forbidden(ctx, forbidden)
+		return nil, false
+	}
+
+	return claims, true
+}
+
+func parseAPIKeyID(ctx *gin.Context) (int64, bool) {
+	keyID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || keyID <= 0 {
+		response.BadRequest(ctx, "invalid api key id", nil)
+		return 0, false
+	}
+
+	return keyID, true
+}
+
+func (h *APIKeyHandler) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authservice.ErrAPIKeyNotFound):
+		response.NotFound(ctx, err.Error())
+	case errors.Is(err, authservice.ErrInvalidAPIKeyLabel),
+		errors.Is(err, authservice.ErrInvalidAPIKeyScope),
+		errors.Is(err, authservice.ErrTooManyAPIKeys):
+		response.BadRequest(ctx, err.Error(), nil)
+	default:
+		response.InternalError(ctx, "failed to manage api keys", err.Error())
+	}
+}
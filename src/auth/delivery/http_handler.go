@@ -1,96 +1,143 @@
 package delivery
 
 import (
-	"crypto/rand"
-	"encoding/base64"
 	"errors"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"gobackend/shared/response"
+	"gobackend/shared/securecookie"
 	"gobackend/src/auth/dto"
 	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/middleware"
 	authservice "gobackend/src/auth/service"
 	"gobackend/src/auth/validation"
 	logdto "gobackend/src/logs/dto"
 	loginterfaces "gobackend/src/logs/interfaces"
 )
 
-const (
-	stateCookieName = "oauthstate"
-	stateTTL        = 10 * time.Minute
-)
-
 // Handler wires HTTP requests to the auth service layer.
 type Handler struct {
 	service            authinterfaces.AuthService
+	links              authinterfaces.IdentityLinkService
+	flows              *securecookie.Sealer
 	successRedirectURL string
 	failureRedirectURL string
+	tokenDelivery      string
 	logService         loginterfaces.Service
 }
 
-// NewHandler instantiates an auth HTTP handler.
-func NewHandler(service authinterfaces.AuthService, successRedirectURL, failureRedirectURL string, logService loginterfaces.Service) *Handler {
+// NewHandler instantiates an auth HTTP handler. tokenDelivery is one of the TokenDelivery modes.
+func NewHandler(
+	service authinterfaces.AuthService,
+	links authinterfaces.IdentityLinkService,
+	flows *securecookie.Sealer,
+	successRedirectURL, failureRedirectURL, tokenDelivery string,
+	logService loginterfaces.Service,
+) *Handler {
 	return &Handler{
 		service:            service,
+		links:              links,
+		flows:              flows,
 		successRedirectURL: successRedirectURL,
 		failureRedirectURL: failureRedirectURL,
+		tokenDelivery:      tokenDelivery,
 		logService:         logService,
 	}
 }
 
-// GoogleLogin initiates the OAuth2 login by redirecting to Google.
-func (h *Handler) GoogleLogin(ctx *gin.Context) {
-	state, err := generateState()
+// Providers lists the identity providers users can sign in with.
+func (h *Handler) Providers(ctx *gin.Context) {
+	response.OK(ctx, "identity providers retrieved successfully", gin.H{"providers": h.service.Providers()})
+}
+
+// JWKS publishes the access token verification keys. The key set is served as-is, without the response
+// envelope, so standard JWT libraries can consume it.
+func (h *Handler) JWKS(ctx *gin.Context) {
+	set, err := h.service.PublicKeys(ctx.Request.Context())
 	if err != nil {
-		response.InternalError(ctx, "failed to generate oauth state", err.Error())
+		response.InternalError(ctx, "failed to load signing keys", err.Error())
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, set)
+}
+
+// Login initiates the OAuth2 login by redirecting to the provider named in the path.
+// The state, PKCE verifier, nonce and optional invite query parameter are kept in an encrypted cookie.
+func (h *Handler) Login(ctx *gin.Context) {
+	invite := strings.TrimSpace(ctx.Query("invite"))
+	if err := validation.ValidateInvitationCode(invite); err != nil {
+		response.BadRequest(ctx, err.Error(), nil)
 		return
 	}
 
-	ctx.SetCookie(
-		stateCookieName,
-		state,
-		int(stateTTL.Seconds()),
-		"/",
-		"",
-		ctx.Request.TLS != nil,
-		true,
-	)
+	provider := ctx.Param("provider")
+	start, err := h.service.StartLogin(ctx.Request.Context(), provider)
+	if err != nil {
+		if errors.Is(err, authservice.ErrUnknownProvider) {
+			response.NotFound(ctx, err.Error())
+			return
+		}
+
+		response.InternalError(ctx, "failed to build login url", err.Error())
+		return
+	}
+
+	flow := newLoginFlow(provider, start.Params)
+	flow.InvitationCode = invite
+	if err := setFlowCookie(ctx, h.flows, flow); err != nil {
+		response.InternalError(ctx, "failed to store login session", err.Error())
+		return
+	}
 
-	loginURL := h.service.GetGoogleLoginURL(state)
-	ctx.Redirect(http.StatusTemporaryRedirect, loginURL)
+	ctx.Redirect(http.StatusTemporaryRedirect, start.URL)
 }
 
-// GoogleCallback handles Google's OAuth2 callback.
-func (h *Handler) GoogleCallback(ctx *gin.Context) {
+// Callback handles the OAuth2 callback of the provider named in the path. It only proceeds when the
+// state matches the flow cookie set by Login or IdentityHandler.StartLink.
+func (h *Handler) Callback(ctx *gin.Context) {
 	query := ctx.Request.URL.Query()
-	req := dto.GoogleCallbackRequest{
-		Code:  query.Get("code"),
-		State: query.Get("state"),
+	code, state := query.Get("code"), query.Get("state")
+
+	if err := validation.ValidateCallback(code, state); err != nil {
+		clearFlowCookie(ctx)
+		response.BadRequest(ctx, err.Error(), nil)
+		return
 	}
 
-	if err := validation.ValidateGoogleCallback(req); err != nil {
+	provider := ctx.Param("provider")
+	flow, err := readFlowCookie(ctx, h.flows, provider, state)
+	if err != nil {
 		response.BadRequest(ctx, err.Error(), nil)
 		return
 	}
 
-	if cookie, err := ctx.Request.Cookie(stateCookieName); err == nil && cookie.Value != "" {
-		if cookie.Value != req.State {
-			response.BadRequest(ctx, "state mismatch", nil)
-			return
-		}
+	req := dto.CallbackRequest{
+		Code:           code,
+		Params:         flow.params(),
+		InvitationCode: flow.InvitationCode,
+	}
 
-		ctx.SetCookie(stateCookieName, "", -1, "/", "", false, true)
+	if flow.LinkUserID != 0 {
+		h.completeLink(ctx, flow.LinkUserID, req)
+		return
 	}
 
-	result, err := h.service.HandleGoogleCallback(ctx.Request.Context(), req)
+	user, err := h.service.HandleCallback(ctx.Request.Context(), provider, req)
 	if err != nil {
 		if errors.Is(err, authservice.ErrUnauthorized) {
-			h.handleUnauthorized(ctx)
+			h.handleUnauthorized(ctx, err)
+			return
+		}
+
+		if errors.Is(err, authservice.ErrUnknownProvider) {
+			response.NotFound(ctx, err.Error())
 			return
 		}
 
@@ -98,63 +145,253 @@ func (h *Handler) GoogleCallback(ctx *gin.Context) {
 		return
 	}
 
-	if h.successRedirectURL != "" {
-		redirectURL, parseErr := url.Parse(h.successRedirectURL)
-		if parseErr != nil {
-			response.InternalError(ctx, "invalid success redirect url", parseErr.Error())
+	h.deliverLogin(ctx, user.ID)
+}
+
+// deliverLogin hands the session of a signed-in user to the client according to the token delivery mode.
+// Without a success redirect URL the tokens are returned in the response body.
+func (h *Handler) deliverLogin(ctx *gin.Context, userID int64) {
+	query := url.Values{}
+
+	if h.tokenDelivery == TokenDeliveryCode && h.successRedirectURL != "" {
+		code, err := h.service.CreateLoginCode(ctx.Request.Context(), userID)
+		if err != nil {
+			response.InternalError(ctx, "failed to complete login", err.Error())
+			return
+		}
+
+		query.Set("code", code)
+		h.redirectSuccess(ctx, query)
+		return
+	}
+
+	result, err := h.service.IssueSession(ctx.Request.Context(), userID)
+	if err != nil {
+		response.InternalError(ctx, "failed to complete login", err.Error())
+		return
+	}
+
+	switch {
+	case result.MFARequired && h.successRedirectURL != "":
+		query.Set("mfa_token", result.MFAToken)
+		h.redirectSuccess(ctx, query)
+	case result.MFARequired:
+		response.OK(ctx, "mfa required", result)
+	case h.tokenDelivery == TokenDeliveryCookie:
+		setSessionCookies(ctx, result)
+		if h.successRedirectURL != "" {
+			h.redirectSuccess(ctx, query)
 			return
 		}
 
-		query := redirectURL.Query()
+		response.OK(ctx, "login successful", gin.H{"user": result.User})
+	case h.successRedirectURL != "":
 		query.Set("token", result.Token)
+		query.Set("refresh_token", result.RefreshToken)
 		query.Set("email", result.User.Email)
 		query.Set("name", result.User.Name)
 		if result.User.PictureURL != "" {
 			query.Set("picture", result.User.PictureURL)
 		}
-		redirectURL.RawQuery = query.Encode()
+		h.redirectSuccess(ctx, query)
+	default:
+		response.OK(ctx, "login successful", result)
+	}
+}
 
-		ctx.Redirect(http.StatusTemporaryRedirect, redirectURL.String())
+// redirectSuccess redirects to the success URL with params merged into its query.
+func (h *Handler) redirectSuccess(ctx *gin.Context, params url.Values) {
+	redirectURL, err := url.Parse(h.successRedirectURL)
+	if err != nil {
+		response.InternalError(ctx, "invalid success redirect url", err.Error())
+		return
+	}
+
+	query := redirectURL.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	redirectURL.RawQuery = query.Encode()
+
+	ctx.Redirect(http.StatusTemporaryRedirect, redirectURL.String())
+}
+
+// Token exchanges the one-time code from the login redirect for an access and refresh token pair.
+func (h *Handler) Token(ctx *gin.Context) {
+	var req dto.TokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	if err := validation.ValidateTokenExchange(req); err != nil {
+		response.BadRequest(ctx, err.Error(), nil)
+		return
+	}
+
+	result, err := h.service.ExchangeLoginCode(ctx.Request.Context(), req.Code)
+	if err != nil {
+		if errors.Is(err, authservice.ErrInvalidLoginCode) || errors.Is(err, authservice.ErrUnauthorized) {
+			response.Unauthorized(ctx, err.Error())
+			return
+		}
+
+		response.InternalError(ctx, "failed to exchange login code", err.Error())
+		return
+	}
+
+	if result.MFARequired {
+		response.OK(ctx, "mfa required", result)
 		return
 	}
 
 	response.OK(ctx, "login successful", result)
 }
 
-// Logout registers a logout activity in the audit logs.
-func (h *Handler) Logout(ctx *gin.Context) {
-	var req dto.LogoutRequest
+// MFAVerify completes a login that returned an MFA token by checking a TOTP or recovery code.
+// The tokens are delivered as cookies in cookie mode and in the response body otherwise.
+func (h *Handler) MFAVerify(ctx *gin.Context) {
+	var req dto.MFAVerifyRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		response.BadRequest(ctx, "invalid payload", err.Error())
 		return
 	}
 
-	authHeader := ctx.GetHeader("Authorization")
-	if authHeader == "" {
-		response.Unauthorized(ctx, "missing authorization header")
+	if err := validation.ValidateMFAVerify(req); err != nil {
+		response.BadRequest(ctx, err.Error(), nil)
 		return
 	}
 
-	token := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer"))
-	if token == "" {
-		response.Unauthorized(ctx, "invalid authorization header")
+	result, err := h.service.CompleteMFALogin(ctx.Request.Context(), req.MFAToken, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, authservice.ErrInvalidMFAToken),
+			errors.Is(err, authservice.ErrInvalidMFACode),
+			errors.Is(err, authservice.ErrMFANotEnabled):
+			response.Unauthorized(ctx, err.Error())
+		case errors.Is(err, authservice.ErrMFALocked):
+			response.JSON(ctx, http.StatusTooManyRequests, err.Error(), nil, nil)
+		default:
+			response.InternalError(ctx, "failed to verify mfa code", err.Error())
+		}
+		return
+	}
+
+	if h.tokenDelivery == TokenDeliveryCookie {
+		setSessionCookies(ctx, result)
+		response.OK(ctx, "login successful", gin.H{"user": result.User})
 		return
 	}
 
-	userID, err := h.service.ExtractUserID(token)
+	response.OK(ctx, "login successful", result)
+}
+
+// completeLink finishes a flow started by IdentityHandler.StartLink instead of signing in.
+func (h *Handler) completeLink(ctx *gin.Context, userID int64, req dto.CallbackRequest) {
+	linked, err := h.links.CompleteLink(ctx.Request.Context(), userID, ctx.Param("provider"), req)
+	if err != nil {
+		if errors.Is(err, authservice.ErrUnauthorized) {
+			h.handleUnauthorized(ctx, err)
+			return
+		}
+
+		if errors.Is(err, authservice.ErrUnknownProvider) {
+			response.NotFound(ctx, err.Error())
+			return
+		}
+
+		response.InternalError(ctx, "failed to link identity", err.Error())
+		return
+	}
+
+	if h.successRedirectURL != "" {
+		h.redirectSuccess(ctx, url.Values{"linked": {linked.Provider}})
+		return
+	}
+
+	response.OK(ctx, "identity linked", linked)
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token pair. Without a refresh_token in the
+// payload the refresh token cookie is used, and the new pair is stored in cookies again.
+func (h *Handler) Refresh(ctx *gin.Context) {
+	var req dto.RefreshRequest
+	if err := bindOptionalJSON(ctx, &req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	fromCookie := false
+	if req.RefreshToken == "" {
+		if cookie, err := ctx.Cookie(refreshCookieName); err == nil && cookie != "" {
+			req.RefreshToken, fromCookie = cookie, true
+		}
+	}
+
+	if err := validation.ValidateRefresh(req); err != nil {
+		response.BadRequest(ctx, err.Error(), nil)
+		return
+	}
+
+	result, err := h.service.Refresh(ctx.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, authservice.ErrInvalidRefreshToken) || errors.Is(err, authservice.ErrRefreshTokenReused) {
+			response.Unauthorized(ctx, err.Error())
+			return
+		}
+
+		response.InternalError(ctx, "failed to refresh token", err.Error())
+		return
+	}
+
+	if fromCookie {
+		setSessionCookies(ctx, result)
+		response.OK(ctx, "token refreshed", gin.H{"user": result.User})
+		return
+	}
+
+	response.OK(ctx, "token refreshed", result)
+}
+
+// Logout revokes the current access token (and the refresh token, when provided) and records the logout.
+// Session cookies are used and cleared when tokens are delivered as cookies.
+func (h *Handler) Logout(ctx *gin.Context) {
+	var req dto.LogoutRequest
+	if err := bindOptionalJSON(ctx, &req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	if req.RefreshToken == "" {
+		req.RefreshToken, _ = ctx.Cookie(refreshCookieName)
+	}
+
+	token, ok := middleware.RequestToken(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "missing or invalid authorization header")
+		return
+	}
+
+	claims, err := h.service.ParseClaims(ctx.Request.Context(), token)
 	if err != nil {
 		response.Unauthorized(ctx, "invalid or expired token")
 		return
 	}
 
+	if err := h.service.RevokeSession(ctx.Request.Context(), *claims, req.RefreshToken); err != nil {
+		response.InternalError(ctx, "failed to revoke session", err.Error())
+		return
+	}
+	clearSessionCookies(ctx)
+
 	detail := req.Detail
 	if detail == "" {
 		detail = "user initiated logout"
 	}
 
 	entry := logdto.NewLog{
-		UserID: userID,
-		Action: "logout",
+		UserID: claims.UserID,
+		Action: logdto.ActionLogout,
 		Detail: detail,
 	}
 
@@ -166,28 +403,44 @@ func (h *Handler) Logout(ctx *gin.Context) {
 	response.OK(ctx, "logout recorded", gin.H{"status": "ok"})
 }
 
-func generateState() (string, error) {
-	random := make([]byte, 32)
-	if _, err := rand.Read(random); err != nil {
-		return "", err
-	}
-
-	return base64.RawURLEncoding.EncodeToString(random), nil
-}
+// handleUnauthorized redirects to the failure URL with error=unauthorize and, when the
+// sign-up policy refused the account, a reason such as not_allowlisted or invitation_expired.
+func (h *Handler) handleUnauthorized(ctx *gin.Context, cause error) {
+	clearFlowCookie(ctx)
 
-func (h *Handler) handleUnauthorized(ctx *gin.Context) {
-	ctx.SetCookie(stateCookieName, "", -1, "/", "", false, true)
+	var denied *authservice.AccessDeniedError
+	reason := ""
+	if errors.As(cause, &denied) {
+		reason = denied.Reason
+	}
 
 	if h.failureRedirectURL != "" {
 		redirectURL, err := url.Parse(h.failureRedirectURL)
 		if err == nil {
 			query := redirectURL.Query()
 			query.Set("error", authservice.ErrUnauthorized.Error())
+			if reason != "" {
+				query.Set("reason", reason)
+			}
 			redirectURL.RawQuery = query.Encode()
 			ctx.Redirect(http.StatusTemporaryRedirect, redirectURL.String())
 			return
 		}
 	}
 
+	if reason != "" {
+		response.Unauthorized(ctx, authservice.ErrUnauthorized.Error()+": "+reason)
+		return
+	}
+
 	response.Unauthorized(ctx, authservice.ErrUnauthorized.Error())
 }
+
+// bindOptionalJSON decodes the JSON payload into dest; an empty body leaves dest untouched.
+func bindOptionalJSON(ctx *gin.Context, dest interface{}) error {
+	if err := ctx.ShouldBindJSON(dest); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/response"
+	"gobackend/shared/securecookie"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/middleware"
+	authservice "gobackend/src/auth/service"
+)
+
+// identityAPIKeyForbidden refuses API keys, which could otherwise link an account of their holder's choosing.
+const identityAPIKeyForbidden = "api keys cannot manage linked identities"
+
+// IdentityHandler exposes the linked identities of the current user.
+type IdentityHandler struct {
+	service authinterfaces.IdentityLinkService
+	flows   *securecookie.Sealer
+}
+
+// NewIdentityHandler builds an IdentityHandler. flows must use the same secret as the auth Handler.
+func NewIdentityHandler(service authinterfaces.IdentityLinkService, flows *securecookie.Sealer) *IdentityHandler {
+	return &IdentityHandler{service: service, flows: flows}
+}
+
+// ListIdentities returns the provider identities linked to the current user.
+func (h *IdentityHandler) ListIdentities(ctx *gin.Context) {
+	userID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	identities, err := h.service.ListIdentities(ctx.Request.Context(), userID)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "identities retrieved successfully", identities)
+}
+
+// StartLink begins linking the provider in the path. The client must send the browser to the returned URL;
+// the provider callback then links the identity instead of signing in.
+func (h *IdentityHandler) StartLink(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, identityAPIKeyForbidden)
+	if !ok {
+		return
+	}
+
+	provider := ctx.Param("provider")
+	start, err := h.service.StartLink(ctx.Request.Context(), provider)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	flow := newLoginFlow(provider, start.Params)
+	flow.LinkUserID = claims.UserID
+	if err := setFlowCookie(ctx, h.flows, flow); err != nil {
+		response.InternalError(ctx, "failed to store login session", err.Error())
+		return
+	}
+
+	response.OK(ctx, "identity link started", dto.LinkStart{URL: start.URL})
+}
+
+// Unlink removes the identity of the provider in the path from the current user.
+func (h *IdentityHandler) Unlink(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, identityAPIKeyForbidden)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unlink(ctx.Request.Context(), claims.UserID, ctx.Param("provider")); err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.NoContent(ctx)
+}
+
+func (h *IdentityHandler) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authservice.ErrUnknownProvider), errors.Is(err, authservice.ErrIdentityNotFound):
+		response.NotFound(ctx, err.Error())
+	case errors.Is(err, authservice.ErrLastIdentity):
+		response.BadRequest(ctx, err.Error(), nil)
+	default:
+		response.InternalError(ctx, "failed to manage identities", err.Error())
+	}
+}
@@ -0,0 +1,86 @@
+package delivery
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/securecookie"
+	"gobackend/src/auth/dto"
+)
+
+const (
+	flowCookieName = "oauthflow"
+	flowTTL        = 10 * time.Minute
+)
+
+var (
+	errMissingFlow  = errors.New("login session missing or expired; please start again")
+	errStateInvalid = errors.New("state mismatch")
+)
+
+// loginFlow is kept in an encrypted cookie between the login redirect and the provider callback.
+type loginFlow struct {
+	Provider       string    `json:"provider"`
+	State          string    `json:"state"`
+	CodeVerifier   string    `json:"code_verifier"`
+	Nonce          string    `json:"nonce,omitempty"`
+	InvitationCode string    `json:"invitation_code,omitempty"`
+	LinkUserID     int64     `json:"link_user_id,omitempty"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+func newLoginFlow(provider string, params dto.AuthParams) loginFlow {
+	return loginFlow{
+		Provider:     provider,
+		State:        params.State,
+		CodeVerifier: params.CodeVerifier,
+		Nonce:        params.Nonce,
+		ExpiresAt:    time.Now().Add(flowTTL),
+	}
+}
+
+func (f loginFlow) params() dto.AuthParams {
+	return dto.AuthParams{State: f.State, CodeVerifier: f.CodeVerifier, Nonce: f.Nonce}
+}
+
+// setFlowCookie stores the sealed flow in an HttpOnly, SameSite=Lax cookie that the provider redirect still sends.
+func setFlowCookie(ctx *gin.Context, flows *securecookie.Sealer, flow loginFlow) error {
+	sealed, err := flows.Seal(flow)
+	if err != nil {
+		return err
+	}
+
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(flowCookieName, sealed, int(flowTTL.Seconds()), "/auth/", "", ctx.Request.TLS != nil, true)
+	return nil
+}
+
+func clearFlowCookie(ctx *gin.Context) {
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(flowCookieName, "", -1, "/auth/", "", ctx.Request.TLS != nil, true)
+}
+
+// readFlowCookie opens the flow cookie and checks it belongs to provider and state. The cookie is always cleared,
+// so every flow can be completed once.
+func readFlowCookie(ctx *gin.Context, flows *securecookie.Sealer, provider, state string) (loginFlow, error) {
+	cookie, err := ctx.Request.Cookie(flowCookieName)
+	if err != nil || cookie.Value == "" {
+		return loginFlow{}, errMissingFlow
+	}
+	clearFlowCookie(ctx)
+
+	var flow loginFlow
+	if err := flows.Open(cookie.Value, &flow); err != nil || time.Now().After(flow.ExpiresAt) {
+		return loginFlow{}, errMissingFlow
+	}
+
+	if flow.Provider != provider || subtle.ConstantTimeCompare([]byte(flow.State), []byte(state)) != 1 {
+		return loginFlow{}, errStateInvalid
+	}
+
+	return flow, nil
+}
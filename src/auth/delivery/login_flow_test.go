@@ -0,0 +1,112 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/securecookie"
+	"gobackend/src/auth/dto"
+)
+
+func newTestSealer(t *testing.T, secret string) *securecookie.Sealer {
+	t.Helper()
+
+	sealer, err := securecookie.NewSealer(secret)
+	if err != nil {
+		t.Fatalf("NewSealer: %v", err)
+	}
+
+	return sealer
+}
+
+func sealFlow(t *testing.T, sealer *securecookie.Sealer, flow loginFlow) string {
+	t.Helper()
+
+	sealed, err := sealer.Seal(flow)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	return sealed
+}
+
+// newCallbackContext returns a gin context for a provider callback carrying cookie as the flow cookie.
+func newCallbackContext(cookie string) (*gin.Context, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/auth/github/callback", nil)
+	if cookie != "" {
+		ctx.Request.AddCookie(&http.Cookie{Name: flowCookieName, Value: cookie})
+	}
+
+	return ctx, recorder
+}
+
+func TestReadFlowCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	sealer := newTestSealer(t, "flow-secret")
+	params := dto.AuthParams{State: "state-1", CodeVerifier: "verifier-1", Nonce: "nonce-1"}
+	valid := newLoginFlow("github", params)
+
+	expired := valid
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+
+	sealedValid := sealFlow(t, sealer, valid)
+	tampered := sealedValid[:len(sealedValid)-4] + "AAAA"
+	if tampered == sealedValid {
+		tampered = sealedValid[:len(sealedValid)-4] + "BBBB"
+	}
+
+	tests := []struct {
+		name     string
+		cookie   string
+		provider string
+		state    string
+		wantErr  error
+	}{
+		{name: "valid", cookie: sealedValid, provider: "github", state: "state-1"},
+		{name: "missing cookie", provider: "github", state: "state-1", wantErr: errMissingFlow},
+		{name: "state mismatch", cookie: sealedValid, provider: "github", state: "state-2", wantErr: errStateInvalid},
+		{name: "provider mismatch", cookie: sealedValid, provider: "google", state: "state-1", wantErr: errStateInvalid},
+		{name: "expired", cookie: sealFlow(t, sealer, expired), provider: "github", state: "state-1", wantErr: errMissingFlow},
+		{name: "tampered", cookie: tampered, provider: "github", state: "state-1", wantErr: errMissingFlow},
+		{name: "sealed with another key", cookie: sealFlow(t, newTestSealer(t, "other-secret"), valid), provider: "github", state: "state-1", wantErr: errMissingFlow},
+		{name: "not sealed", cookie: "plain-text", provider: "github", state: "state-1", wantErr: errMissingFlow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, recorder := newCallbackContext(tt.cookie)
+
+			flow, err := readFlowCookie(ctx, sealer, tt.provider, tt.state)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("readFlowCookie error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && flow.params() != params {
+				t.Errorf("params = %+v, want %+v", flow.params(), params)
+			}
+
+			if tt.cookie != "" && !clearsFlowCookie(recorder) {
+				t.Error("flow cookie was not cleared, so it could be replayed")
+			}
+		})
+	}
+}
+
+func clearsFlowCookie(recorder *httptest.ResponseRecorder) bool {
+	for _, header := range recorder.Header().Values("Set-Cookie") {
+		if strings.HasPrefix(header, flowCookieName+"=;") && strings.Contains(header, "Max-Age=0") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/response"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	authservice "gobackend/src/auth/service"
+	"gobackend/src/auth/validation"
+)
+
+const mfaAPIKeyForbidden = "api keys cannot manage mfa"
+
+// MFAHandler exposes the second factor of the current user.
+type MFAHandler struct {
+	service authinterfaces.MFAService
+}
+
+// NewMFAHandler builds an MFAHandler.
+func NewMFAHandler(service authinterfaces.MFAService) *MFAHandler {
+	return &MFAHandler{service: service}
+}
+
+// Status reports whether MFA is enabled and how many recovery codes are left.
+func (h *MFAHandler) Status(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, mfaAPIKeyForbidden)
+	if !ok {
+		return
+	}
+
+	status, err := h.service.Status(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "mfa status retrieved successfully", status)
+}
+
+// StartTOTP generates a TOTP secret; MFA is only enabled once ConfirmTOTP accepts a code for it.
+func (h *MFAHandler) StartTOTP(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, mfaAPIKeyForbidden)
+	if !ok {
+		return
+	}
+
+	enrolment, err := h.service.StartEnrolment(ctx.Request.Context(), claims.UserID, claims.Email)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.Created(ctx, "totp enrolment started", enrolment)
+}
+
+// ConfirmTOTP enables MFA and returns the recovery codes. They are only shown in this response.
+func (h *MFAHandler) ConfirmTOTP(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, mfaAPIKeyForbidden)
+	if !ok {
+		return
+	}
+
+	code, ok := bindMFACode(ctx)
+	if !ok {
+		return
+	}
+
+	codes, err := h.service.ConfirmEnrolment(ctx.Request.Context(), claims.UserID, code)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "mfa enabled", codes)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes after checking a current code.
+func (h *MFAHandler) RegenerateRecoveryCodes(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, mfaAPIKeyForbidden)
+	if !ok {
+		return
+	}
+
+	code, ok := bindMFACode(ctx)
+	if !ok {
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(ctx.Request.Context(), claims.UserID, code)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "recovery codes regenerated", codes)
+}
+
+// Disable removes the second factor after checking a current code.
+func (h *MFAHandler) Disable(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, mfaAPIKeyForbidden)
+	if !ok {
+		return
+	}
+
+	code, ok := bindMFACode(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Disable(ctx.Request.Context(), claims.UserID, code); err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.NoContent(ctx)
+}
+
+func bindMFACode(ctx *gin.Context) (string, bool) {
+	var req dto.MFACodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return "", false
+	}
+
+	if err := validation.ValidateMFACode(req); err != nil {
+		response.BadRequest(ctx, err.Error(), nil)
+		return "", false
+	}
+
+	return req.Code, true
+}
+
+func (h *MFAHandler) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authservice.ErrInvalidMFACode):
+		response.Unauthorized(ctx, err.Error())
+	case errors.Is(err, authservice.ErrMFALocked):
+		response.JSON(ctx, http.StatusTooManyRequests, err.Error(), nil, nil)
+	case errors.Is(err, authservice.ErrMFANotEnabled),
+		errors.Is(err, authservice.ErrMFAAlreadyEnabled),
+		errors.Is(err, authservice.ErrMFAEnrolmentNotStarted):
+		response.BadRequest(ctx, err.Error(), nil)
+	default:
+		response.InternalError(ctx, "failed to manage mfa", err.Error())
+	}
+}
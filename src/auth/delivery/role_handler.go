@@ -0,0 +1,109 @@
+package delivery
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/identity"
+	"gobackend/shared/response"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/middleware"
+	authservice "gobackend/src/auth/service"
+)
+
+// RoleHandler exposes role administration endpoints.
+type RoleHandler struct {
+	service    authinterfaces.RoleService
+	refEncoder *identity.UserReferenceEncoder
+}
+
+// NewRoleHandler builds a RoleHandler.
+func NewRoleHandler(service authinterfaces.RoleService, refEncoder *identity.UserReferenceEncoder) *RoleHandler {
+	return &RoleHandler{service: service, refEncoder: refEncoder}
+}
+
+// ListRoles returns the roles of the referenced user.
+func (h *RoleHandler) ListRoles(ctx *gin.Context) {
+	reference := ctx.Param("reference")
+	userID, err := h.refEncoder.Decode(reference)
+	if err != nil {
+		response.BadRequest(ctx, "invalid user reference", err.Error())
+		return
+	}
+
+	roles, err := h.service.ListRoles(ctx.Request.Context(), userID)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "roles retrieved successfully", dto.UserRoles{Reference: reference, Roles: roles})
+}
+
+// GrantRole grants the role in the payload to the referenced user.
+func (h *RoleHandler) GrantRole(ctx *gin.Context) {
+	actorID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	reference := ctx.Param("reference")
+	userID, err := h.refEncoder.Decode(reference)
+	if err != nil {
+		response.BadRequest(ctx, "invalid user reference", err.Error())
+		return
+	}
+
+	var req dto.RoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	roles, err := h.service.GrantRole(ctx.Request.Context(), actorID, userID, strings.TrimSpace(req.Role))
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "role granted", dto.UserRoles{Reference: reference, Roles: roles})
+}
+
+// RevokeRole removes the role in the path from the referenced user.
+func (h *RoleHandler) RevokeRole(ctx *gin.Context) {
+	actorID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	reference := ctx.Param("reference")
+	userID, err := h.refEncoder.Decode(reference)
+	if err != nil {
+		response.BadRequest(ctx, "invalid user reference", err.Error())
+		return
+	}
+
+	roles, err := h.service.RevokeRole(ctx.Request.Context(), actorID, userID, ctx.Param("role"))
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "role revoked", dto.UserRoles{Reference: reference, Roles: roles})
+}
+
+func (h *RoleHandler) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authservice.ErrUserNotFound):
+		response.NotFound(ctx, err.Error())
+	case errors.Is(err, authservice.ErrUnknownRole), errors.Is(err, authservice.ErrSelfAdminRevoke):
+		response.BadRequest(ctx, err.Error(), nil)
+	default:
+		response.InternalError(ctx, "failed to manage roles", err.Error())
+	}
+}
@@ -0,0 +1,51 @@
+package delivery
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/identity"
+	"gobackend/shared/response"
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/middleware"
+	authservice "gobackend/src/auth/service"
+)
+
+// SessionHandler exposes session administration endpoints.
+type SessionHandler struct {
+	service    authinterfaces.SessionService
+	refEncoder *identity.UserReferenceEncoder
+}
+
+// NewSessionHandler builds a SessionHandler.
+func NewSessionHandler(service authinterfaces.SessionService, refEncoder *identity.UserReferenceEncoder) *SessionHandler {
+	return &SessionHandler{service: service, refEncoder: refEncoder}
+}
+
+// SignOutEverywhere revokes every session of the referenced user.
+func (h *SessionHandler) SignOutEverywhere(ctx *gin.Context) {
+	actorID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	userID, err := h.refEncoder.Decode(ctx.Param("reference"))
+	if err != nil {
+		response.BadRequest(ctx, "invalid user reference", err.Error())
+		return
+	}
+
+	if err := h.service.SignOutEverywhere(ctx.Request.Context(), actorID, userID); err != nil {
+		if errors.Is(err, authservice.ErrUserNotFound) {
+			response.NotFound(ctx, err.Error())
+			return
+		}
+
+		response.InternalError(ctx, "failed to revoke sessions", err.Error())
+		return
+	}
+
+	response.OK(ctx, "sessions revoked", gin.H{"status": "ok"})
+}
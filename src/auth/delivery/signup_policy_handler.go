@@ -0,0 +1,165 @@
+package delivery
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/pagination"
+	"gobackend/shared/response"
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/middleware"
+	authservice "gobackend/src/auth/service"
+)
+
+// SignupPolicyHandler exposes the sign-up allowlist and invitation administration endpoints.
+type SignupPolicyHandler struct {
+	service authinterfaces.SignupPolicyService
+}
+
+// NewSignupPolicyHandler builds a SignupPolicyHandler.
+func NewSignupPolicyHandler(service authinterfaces.SignupPolicyService) *SignupPolicyHandler {
+	return &SignupPolicyHandler{service: service}
+}
+
+// GetPolicy returns the allowlisted emails and domains.
+func (h *SignupPolicyHandler) GetPolicy(ctx *gin.Context) {
+	policy, err := h.service.GetPolicy(ctx.Request.Context())
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "signup policy retrieved successfully", policy)
+}
+
+// AddEmail allowlists the email in the payload.
+func (h *SignupPolicyHandler) AddEmail(ctx *gin.Context) {
+	h.addRule(ctx, dao.SignupRuleEmail)
+}
+
+// RemoveEmail removes the email in the path from the allowlist.
+func (h *SignupPolicyHandler) RemoveEmail(ctx *gin.Context) {
+	h.removeRule(ctx, dao.SignupRuleEmail)
+}
+
+// AddDomain allowlists the Google Workspace domain in the payload.
+func (h *SignupPolicyHandler) AddDomain(ctx *gin.Context) {
+	h.addRule(ctx, dao.SignupRuleDomain)
+}
+
+// RemoveDomain removes the domain in the path from the allowlist.
+func (h *SignupPolicyHandler) RemoveDomain(ctx *gin.Context) {
+	h.removeRule(ctx, dao.SignupRuleDomain)
+}
+
+// ListInvitations returns the paginated invitations without their codes.
+func (h *SignupPolicyHandler) ListInvitations(ctx *gin.Context) {
+	params := pagination.FromQuery(ctx)
+
+	invitations, total, err := h.service.ListInvitations(ctx.Request.Context(), params)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	meta := pagination.NewMetadata(total, params)
+	response.Paginated(ctx, "invitations retrieved successfully", invitations, meta)
+}
+
+// CreateInvitation issues a single-use invitation code. The code is only shown in this response.
+func (h *SignupPolicyHandler) CreateInvitation(ctx *gin.Context) {
+	actorID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	var req dto.InvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	invitation, err := h.service.CreateInvitation(ctx.Request.Context(), actorID, req)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.Created(ctx, "invitation created", invitation)
+}
+
+// RevokeInvitation deletes an unused invitation.
+func (h *SignupPolicyHandler) RevokeInvitation(ctx *gin.Context) {
+	actorID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	invitationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || invitationID <= 0 {
+		response.BadRequest(ctx, "invalid invitation id", nil)
+		return
+	}
+
+	if err := h.service.RevokeInvitation(ctx.Request.Context(), actorID, invitationID); err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.NoContent(ctx)
+}
+
+func (h *SignupPolicyHandler) addRule(ctx *gin.Context, kind string) {
+	actorID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	var req dto.SignupRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	policy, err := h.service.AddRule(ctx.Request.Context(), actorID, kind, req.Value)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "signup policy updated", policy)
+}
+
+func (h *SignupPolicyHandler) removeRule(ctx *gin.Context, kind string) {
+	actorID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	policy, err := h.service.RemoveRule(ctx.Request.Context(), actorID, kind, ctx.Param("value"))
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "signup policy updated", policy)
+}
+
+func (h *SignupPolicyHandler) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authservice.ErrSignupRuleNotFound), errors.Is(err, authservice.ErrInvitationNotFound):
+		response.NotFound(ctx, err.Error())
+	case errors.Is(err, authservice.ErrInvalidSignupRule), errors.Is(err, authservice.ErrInvalidInvitationTTL):
+		response.BadRequest(ctx, err.Error(), nil)
+	default:
+		response.InternalError(ctx, "failed to manage signup policy", err.Error())
+	}
+}
@@ -0,0 +1,46 @@
+package delivery
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/src/auth/dto"
+	"gobackend/src/auth/middleware"
+)
+
+// Ways the callback hands tokens to the client, selected per deployment.
+const (
+	// TokenDeliveryCode redirects with a one-time code that the client exchanges via POST /auth/token.
+	TokenDeliveryCode = "code"
+	// TokenDeliveryCookie stores the tokens in HttpOnly, Secure cookies.
+	TokenDeliveryCookie = "cookie"
+	// TokenDeliveryQuery puts the tokens and profile in the redirect query string. Tokens then leak into
+	// browser history and proxy logs; only kept for existing clients.
+	TokenDeliveryQuery = "query"
+)
+
+const refreshCookieName = "refresh_token"
+
+// IsValidTokenDelivery reports whether mode is a known token delivery mode.
+func IsValidTokenDelivery(mode string) bool {
+	switch mode {
+	case TokenDeliveryCode, TokenDeliveryCookie, TokenDeliveryQuery:
+		return true
+	default:
+		return false
+	}
+}
+
+// setSessionCookies stores the token pair in browser-session cookies. The refresh token is only sent to /auth/.
+func setSessionCookies(ctx *gin.Context, result *dto.AuthResponse) {
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(middleware.AccessTokenCookie, result.Token, 0, "/", "", true, true)
+	ctx.SetCookie(refreshCookieName, result.RefreshToken, 0, "/auth/", "", true, true)
+}
+
+func clearSessionCookies(ctx *gin.Context) {
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(middleware.AccessTokenCookie, "", -1, "/", "", true, true)
+	ctx.SetCookie(refreshCookieName, "", -1, "/auth/", "", true, true)
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// APIKeyRequest is the payload used to create a personal API key.
+type APIKeyRequest struct {
+	Label string `json:"label"`
+	// Scopes are permissions such as "logs:read"; each must be granted by the caller's roles.
+	Scopes []string `json:"scopes"`
+}
+
+// APIKeyUpdateRequest is the payload used to relabel a personal API key.
+type APIKeyUpdateRequest struct {
+	Label string `json:"label"`
+}
+
+// APIKey is a personal API key exposed via the API. Key is only populated when the key is created.
+type APIKey struct {
+	ID         int64      `json:"id"`
+	Key        string     `json:"key,omitempty"`
+	Label      string     `json:"label"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
@@ -3,7 +3,11 @@ package dto
 import "gobackend/src/auth/dao"
 
 // AuthResponse is delivered back to the client after a successful OAuth2 flow.
+// When MFARequired is set no tokens are included; MFAToken must be exchanged at /auth/mfa/verify together with a code.
 type AuthResponse struct {
-	Token string   `json:"token"`
-	User  dao.User `json:"user"`
+	Token        string   `json:"token"`
+	RefreshToken string   `json:"refresh_token"`
+	User         dao.User `json:"user"`
+	MFARequired  bool     `json:"mfa_required,omitempty"`
+	MFAToken     string   `json:"mfa_token,omitempty"`
 }
@@ -0,0 +1,25 @@
+package dto
+
+// AuthParams are the per-login values binding a provider callback to the browser that started the flow.
+type AuthParams struct {
+	State string
+	// CodeVerifier is the PKCE secret whose S256 challenge is sent with the authorization request.
+	CodeVerifier string
+	// Nonce is echoed in the provider's ID token; empty for providers without OpenID Connect.
+	Nonce string
+}
+
+// AuthStart is the provider consent URL together with the parameters the callback must present.
+type AuthStart struct {
+	URL    string
+	Params AuthParams
+}
+
+// CallbackRequest represents the data received from an identity provider on the OAuth2 callback flow,
+// completed with the parameters stored when the flow started.
+type CallbackRequest struct {
+	Code   string
+	Params AuthParams
+	// InvitationCode is the sign-up invitation presented when the flow started, if any.
+	InvitationCode string
+}
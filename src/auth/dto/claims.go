@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// Claims describes the verified contents of an access token or, when APIKeyID is set, of a personal API key.
+type Claims struct {
+	TokenID   string    `json:"jti"`
+	UserID    int64     `json:"user_id"`
+	Email     string    `json:"email"`
+	Provider  string    `json:"provider"`
+	Roles     []string  `json:"roles"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// APIKeyID identifies the API key the request was made with.
+	APIKeyID int64 `json:"api_key_id,omitempty"`
+	// Scopes limits the permissions of an API key request; unused for access tokens.
+	Scopes []string `json:"scopes,omitempty"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// ExternalIdentity is the normalised profile an identity provider returns after a successful login.
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	PictureURL    string
+	// HostedDomain is the Google Workspace domain (`hd` claim); empty for other providers.
+	HostedDomain string
+}
+
+// LinkedIdentity is a provider identity linked to the current user.
+type LinkedIdentity struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LinkStart tells the client where to send the browser to link a provider.
+type LinkStart struct {
+	URL string `json:"url"`
+}
@@ -3,4 +3,16 @@ package dto
 // LogoutRequest represents the payload to record a logout event.
 type LogoutRequest struct {
 	Detail string `json:"detail"`
+	// RefreshToken, when provided, revokes the refresh token and every token rotated from the same login.
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshRequest is the payload used to exchange a refresh token for a new token pair.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenRequest is the payload used to exchange a one-time login code for tokens.
+type TokenRequest struct {
+	Code string `json:"code"`
 }
@@ -0,0 +1,12 @@
+package dto
+
+// RoleRequest is the payload used to grant a role to a user.
+type RoleRequest struct {
+	Role string `json:"role"`
+}
+
+// UserRoles lists the roles held by a user.
+type UserRoles struct {
+	Reference string   `json:"reference"`
+	Roles     []string `json:"roles"`
+}
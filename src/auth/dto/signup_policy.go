@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// SignupCandidate describes a first-time user asking to create an account.
+type SignupCandidate struct {
+	Email          string
+	EmailVerified  bool
+	HostedDomain   string
+	InvitationCode string
+}
+
+// SignupPolicy lists the allowlisted emails and domains.
+type SignupPolicy struct {
+	Emails  []SignupRule `json:"emails"`
+	Domains []SignupRule `json:"domains"`
+}
+
+// SignupRule is an allowlist entry exposed via the API.
+type SignupRule struct {
+	ID        int64     `json:"id"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SignupRuleRequest is the payload used to add an email or domain to the allowlist.
+type SignupRuleRequest struct {
+	Value string `json:"value"`
+}
+
+// InvitationRequest is the payload used to create an invitation.
+type InvitationRequest struct {
+	// Email optionally restricts the invitation to one address.
+	Email string `json:"email"`
+	// ExpiresInHours defaults to 72 when zero.
+	ExpiresInHours int `json:"expires_in_hours"`
+}
+
+// Invitation is an invitation exposed via the API. Code is only populated when the invitation is created.
+type Invitation struct {
+	ID        int64      `json:"id"`
+	Code      string     `json:"code,omitempty"`
+	Email     string     `json:"email,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
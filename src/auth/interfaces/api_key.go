@@ -0,0 +1,37 @@
+package authinterfaces
+
+import (
+	"context"
+	"time"
+
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+)
+
+// APIKeyRepository describes storage operations for personal API keys.
+type APIKeyRepository interface {
+	// FindAPIKeys returns the unrevoked keys of a user, newest first.
+	FindAPIKeys(ctx context.Context, userID int64) ([]dao.APIKey, error)
+	FindAPIKeyByHash(ctx context.Context, keyHash string) (*dao.APIKey, error)
+	CreateAPIKey(ctx context.Context, key dao.APIKey) (*dao.APIKey, error)
+	// UpdateAPIKeyLabel renames an unrevoked key of the user; it returns nil when there is no such key.
+	UpdateAPIKeyLabel(ctx context.Context, userID, keyID int64, label string) (*dao.APIKey, error)
+	// RevokeAPIKey reports whether an unrevoked key of the user was revoked.
+	RevokeAPIKey(ctx context.Context, userID, keyID int64, now time.Time) (bool, error)
+	TouchAPIKey(ctx context.Context, keyID int64, now time.Time) error
+}
+
+// APIKeyAuthenticator resolves a presented API key to the claims of its owner.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*dto.Claims, error)
+}
+
+// APIKeyService encapsulates management of the personal API keys of the current user.
+type APIKeyService interface {
+	APIKeyAuthenticator
+	ListAPIKeys(ctx context.Context, userID int64) ([]dto.APIKey, error)
+	// CreateAPIKey issues a key whose scopes must be granted by roles, the creator's current roles.
+	CreateAPIKey(ctx context.Context, userID int64, roles []string, req dto.APIKeyRequest) (*dto.APIKey, error)
+	UpdateAPIKey(ctx context.Context, userID, keyID int64, req dto.APIKeyUpdateRequest) (*dto.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID int64) error
+}
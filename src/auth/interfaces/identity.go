@@ -0,0 +1,32 @@
+package authinterfaces
+
+import (
+	"context"
+
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+)
+
+// IdentityRepository describes storage operations for the provider identities linked to users.
+type IdentityRepository interface {
+	FindIdentities(ctx context.Context, userID int64) ([]dao.Identity, error)
+	// FindIdentity returns the identity for a provider account, or nil when it is not linked.
+	FindIdentity(ctx context.Context, provider, providerID string) (*dao.Identity, error)
+	// FindIdentitiesByEmail returns every identity whose email matches case-insensitively.
+	FindIdentitiesByEmail(ctx context.Context, email string) ([]dao.Identity, error)
+	LinkIdentity(ctx context.Context, identity dao.Identity) (*dao.Identity, error)
+	// TouchIdentity stores the latest email reported by the provider.
+	TouchIdentity(ctx context.Context, provider, providerID, email string, emailVerified bool) error
+	// UnlinkIdentity removes the user's identity for provider unless it is the last one and reports whether it was removed.
+	UnlinkIdentity(ctx context.Context, userID int64, provider string) (bool, error)
+}
+
+// IdentityLinkService links and unlinks provider identities of a signed-in user.
+type IdentityLinkService interface {
+	ListIdentities(ctx context.Context, userID int64) ([]dto.LinkedIdentity, error)
+	// StartLink returns the provider's consent URL and the parameters CompleteLink must be given.
+	StartLink(ctx context.Context, provider string) (*dto.AuthStart, error)
+	// CompleteLink links the identity returned by the provider callback to userID.
+	CompleteLink(ctx context.Context, userID int64, provider string, req dto.CallbackRequest) (*dto.LinkedIdentity, error)
+	Unlink(ctx context.Context, userID int64, provider string) error
+}
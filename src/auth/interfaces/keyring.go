@@ -0,0 +1,18 @@
+package authinterfaces
+
+import (
+	"context"
+	"time"
+
+	"gobackend/src/auth/dao"
+)
+
+// SigningKeyRepository describes storage operations for the access token keyring.
+type SigningKeyRepository interface {
+	// FindSigningKeys returns the active key and the keys retired after retiredAfter, newest first.
+	FindSigningKeys(ctx context.Context, retiredAfter time.Time) ([]dao.SigningKey, error)
+	// RotateSigningKey retires the active key, if any, and stores key as the new active key in one transaction.
+	RotateSigningKey(ctx context.Context, key dao.SigningKey, now time.Time) error
+	// DeleteRetiredSigningKeys removes keys retired before the given time and reports how many were removed.
+	DeleteRetiredSigningKeys(ctx context.Context, before time.Time) (int64, error)
+}
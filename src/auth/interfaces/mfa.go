@@ -0,0 +1,44 @@
+package authinterfaces
+
+import (
+	"context"
+	"time"
+
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+)
+
+// MFARepository describes storage operations for TOTP enrolments and recovery codes.
+type MFARepository interface {
+	FindMFA(ctx context.Context, userID int64) (*dao.MFA, error)
+	// SaveMFASecret starts or restarts an unconfirmed enrolment; it reports false when MFA is already enabled.
+	SaveMFASecret(ctx context.Context, userID int64, secret string) (bool, error)
+	// EnableMFA confirms the enrolment at step and replaces the recovery codes in one transaction.
+	EnableMFA(ctx context.Context, userID, step int64, now time.Time, codeHashes []string) error
+	// UseTOTPStep records a successful code; it reports false when the step was already used.
+	UseTOTPStep(ctx context.Context, userID, step int64) (bool, error)
+	// UseRecoveryCode marks an unused recovery code as used and reports whether one matched.
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string, now time.Time) (bool, error)
+	// RecordMFAFailure counts a failed attempt and locks the factor until lockedUntil once maxAttempts is reached.
+	RecordMFAFailure(ctx context.Context, userID int64, maxAttempts int, lockedUntil time.Time) error
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	DeleteMFA(ctx context.Context, userID int64) error
+}
+
+// MFAVerifier checks the second factor during login.
+type MFAVerifier interface {
+	IsEnabled(ctx context.Context, userID int64) (bool, error)
+	// VerifyCode accepts a TOTP code or an unused recovery code of the user.
+	VerifyCode(ctx context.Context, userID int64, code string) error
+}
+
+// MFAService encapsulates TOTP enrolment for the current user.
+type MFAService interface {
+	MFAVerifier
+	Status(ctx context.Context, userID int64) (*dto.MFAStatus, error)
+	StartEnrolment(ctx context.Context, userID int64, account string) (*dto.MFAEnrolment, error)
+	// ConfirmEnrolment enables MFA once the user proves the authenticator works and returns the recovery codes.
+	ConfirmEnrolment(ctx context.Context, userID int64, code string) (*dto.MFARecoveryCodes, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) (*dto.MFARecoveryCodes, error)
+	Disable(ctx context.Context, userID int64, code string) error
+}
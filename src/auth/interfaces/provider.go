@@ -0,0 +1,19 @@
+package authinterfaces
+
+import (
+	"context"
+
+	"gobackend/src/auth/dto"
+)
+
+// IdentityProvider is an OAuth2/OIDC provider users can sign in with.
+type IdentityProvider interface {
+	// Name is the provider key used in routes and stored in users.provider.
+	Name() string
+	// SupportsNonce reports whether the provider returns an OpenID Connect ID token that echoes a nonce.
+	SupportsNonce() bool
+	// AuthCodeURL returns the consent screen URL carrying the state, PKCE challenge and nonce.
+	AuthCodeURL(ctx context.Context, params dto.AuthParams) (string, error)
+	// Exchange trades the authorization code and PKCE verifier for the user's identity, checking the nonce.
+	Exchange(ctx context.Context, code string, params dto.AuthParams) (*dto.ExternalIdentity, error)
+}
@@ -9,6 +9,7 @@ import (
 // UserRepository describes storage operations required by the auth service.
 type UserRepository interface {
 	FindByProvider(ctx context.Context, provider, providerID string) (*dao.User, error)
+	FindByID(ctx context.Context, userID int64) (*dao.User, error)
 	Create(ctx context.Context, user dao.User) (*dao.User, error)
 	UpdateLoginTimestamp(ctx context.Context, userID int64) error
 }
@@ -0,0 +1,19 @@
+package authinterfaces
+
+import "context"
+
+// RoleRepository describes storage operations for user roles.
+type RoleRepository interface {
+	FindRoles(ctx context.Context, userID int64) ([]string, error)
+	// GrantRole reports whether the role was newly granted.
+	GrantRole(ctx context.Context, userID int64, role string, grantedBy *int64) (bool, error)
+	// RevokeRole reports whether the role was held and has been removed.
+	RevokeRole(ctx context.Context, userID int64, role string) (bool, error)
+}
+
+// RoleService encapsulates role administration.
+type RoleService interface {
+	ListRoles(ctx context.Context, userID int64) ([]string, error)
+	GrantRole(ctx context.Context, actorID, userID int64, role string) ([]string, error)
+	RevokeRole(ctx context.Context, actorID, userID int64, role string) ([]string, error)
+}
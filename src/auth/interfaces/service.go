@@ -3,12 +3,35 @@ package authinterfaces
 import (
 	"context"
 
+	"gobackend/shared/jwks"
+	"gobackend/src/auth/dao"
 	"gobackend/src/auth/dto"
 )
 
 // AuthService encapsulates authentication business logic.
 type AuthService interface {
-	GetGoogleLoginURL(state string) string
-	HandleGoogleCallback(ctx context.Context, req dto.GoogleCallbackRequest) (*dto.AuthResponse, error)
-	ExtractUserID(token string) (int64, error)
+	// Providers returns the names of the identity providers users can sign in with.
+	Providers() []string
+	// StartLogin returns the provider's consent URL and the parameters HandleCallback must be given.
+	StartLogin(ctx context.Context, provider string) (*dto.AuthStart, error)
+	// HandleCallback signs the user in with the provider and returns the user; it does not issue tokens.
+	HandleCallback(ctx context.Context, provider string, req dto.CallbackRequest) (*dao.User, error)
+	// IssueSession returns a new access and refresh token pair for the user, or an MFA token when the user
+	// must present a second factor first.
+	IssueSession(ctx context.Context, userID int64) (*dto.AuthResponse, error)
+	// CompleteMFALogin exchanges an MFA token and a TOTP or recovery code for the user's tokens.
+	CompleteMFALogin(ctx context.Context, mfaToken, code string) (*dto.AuthResponse, error)
+	// CreateLoginCode returns a one-time code that ExchangeLoginCode trades for the user's tokens.
+	CreateLoginCode(ctx context.Context, userID int64) (string, error)
+	ExchangeLoginCode(ctx context.Context, code string) (*dto.AuthResponse, error)
+	// Refresh rotates a refresh token and returns a new access and refresh token pair.
+	Refresh(ctx context.Context, refreshToken string) (*dto.AuthResponse, error)
+	// RevokeSession revokes the access token described by claims and, when given, the refresh token's family.
+	RevokeSession(ctx context.Context, claims dto.Claims, refreshToken string) error
+	ExtractUserID(ctx context.Context, token string) (int64, error)
+	ParseClaims(ctx context.Context, token string) (*dto.Claims, error)
+	// Authenticate accepts an access token or a personal API key and returns the caller's claims.
+	Authenticate(ctx context.Context, credential string) (*dto.Claims, error)
+	// PublicKeys returns the keys that verify access tokens, for other services.
+	PublicKeys(ctx context.Context) (*jwks.Set, error)
 }
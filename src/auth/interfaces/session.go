@@ -0,0 +1,36 @@
+package authinterfaces
+
+import (
+	"context"
+	"time"
+
+	"gobackend/src/auth/dao"
+)
+
+// SessionRepository describes storage operations for refresh tokens and revoked access tokens.
+type SessionRepository interface {
+	CreateRefreshToken(ctx context.Context, token dao.RefreshToken) (*dao.RefreshToken, error)
+	FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*dao.RefreshToken, error)
+	// UseRefreshToken atomically marks an unused, unrevoked and unexpired token as used and reports whether it succeeded.
+	UseRefreshToken(ctx context.Context, tokenID int64, now time.Time) (bool, error)
+	RevokeRefreshFamily(ctx context.Context, familyID string, now time.Time) error
+	RevokeUserRefreshTokens(ctx context.Context, userID int64, now time.Time) error
+
+	// RevokeAccessToken adds a token ID to the revocation list until the token expires.
+	RevokeAccessToken(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) error
+	// RevokeUserAccessTokens revokes every access token issued to the user up to and including before.
+	RevokeUserAccessTokens(ctx context.Context, userID int64, before time.Time) error
+	// IsAccessTokenRevoked also reports the tokens of deactivated or deleted users as revoked.
+	IsAccessTokenRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error)
+
+	// CreateLoginCode stores the hash of a one-time login code for the user.
+	CreateLoginCode(ctx context.Context, codeHash string, userID int64, expiresAt time.Time) error
+	// ConsumeLoginCode deletes an unexpired login code and returns its user, or zero when there is no such code.
+	ConsumeLoginCode(ctx context.Context, codeHash string, now time.Time) (int64, error)
+}
+
+// SessionService encapsulates session administration.
+type SessionService interface {
+	// SignOutEverywhere revokes every refresh and access token of the user on behalf of actorID.
+	SignOutEverywhere(ctx context.Context, actorID, userID int64) error
+}
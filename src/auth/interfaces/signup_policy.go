@@ -0,0 +1,52 @@
+package authinterfaces
+
+import (
+	"context"
+	"time"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+)
+
+// SignupPolicyRepository describes storage operations for the sign-up allowlist and invitations.
+type SignupPolicyRepository interface {
+	FindRules(ctx context.Context) ([]dao.SignupRule, error)
+	RuleExists(ctx context.Context, kind, value string) (bool, error)
+	// AddRule reports whether the rule was newly added.
+	AddRule(ctx context.Context, rule dao.SignupRule) (bool, error)
+	// RemoveRule reports whether a rule was removed.
+	RemoveRule(ctx context.Context, kind, value string) (bool, error)
+
+	FindInvitations(ctx context.Context, params pagination.Params) ([]dao.Invitation, int64, error)
+	FindInvitationByCodeHash(ctx context.Context, codeHash string) (*dao.Invitation, error)
+	CreateInvitation(ctx context.Context, invitation dao.Invitation) (*dao.Invitation, error)
+	// ConsumeInvitation atomically marks an unused, unexpired invitation as used and reports whether it succeeded.
+	ConsumeInvitation(ctx context.Context, invitationID int64, now time.Time) (bool, error)
+	// ReleaseInvitation makes a consumed invitation usable again when account creation fails.
+	ReleaseInvitation(ctx context.Context, invitationID int64) error
+	AttachInvitationUser(ctx context.Context, invitationID, userID int64) error
+	// DeleteInvitation reports whether an unused invitation was deleted.
+	DeleteInvitation(ctx context.Context, invitationID int64) (bool, error)
+}
+
+// SignupGate decides whether a first-time user may create an account.
+type SignupGate interface {
+	// AdmitSignup returns the consumed invitation ID (zero when admitted by the allowlist) or an access denied error.
+	AdmitSignup(ctx context.Context, candidate dto.SignupCandidate) (int64, error)
+	// CompleteSignup links a consumed invitation to the created user.
+	CompleteSignup(ctx context.Context, invitationID, userID int64) error
+	// AbortSignup releases a consumed invitation after account creation failed.
+	AbortSignup(ctx context.Context, invitationID int64) error
+}
+
+// SignupPolicyService encapsulates administration of the sign-up policy.
+type SignupPolicyService interface {
+	SignupGate
+	GetPolicy(ctx context.Context) (*dto.SignupPolicy, error)
+	AddRule(ctx context.Context, actorID int64, kind, value string) (*dto.SignupPolicy, error)
+	RemoveRule(ctx context.Context, actorID int64, kind, value string) (*dto.SignupPolicy, error)
+	ListInvitations(ctx context.Context, params pagination.Params) ([]dto.Invitation, int64, error)
+	CreateInvitation(ctx context.Context, actorID int64, req dto.InvitationRequest) (*dto.Invitation, error)
+	RevokeInvitation(ctx context.Context, actorID, invitationID int64) error
+}
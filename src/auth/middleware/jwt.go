@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/response"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+const (
+	userIDKey = "auth.user_id"
+	claimsKey = "auth.claims"
+
+	bearerPrefix = "Bearer"
+
+	// AccessTokenCookie carries the access token when tokens are delivered as cookies.
+	AccessTokenCookie = "access_token"
+)
+
+type contextKey string
+
+const (
+	userIDContextKey contextKey = userIDKey
+	claimsContextKey contextKey = claimsKey
+)
+
+// RequireAuth rejects requests without a valid bearer token; the token may be an access token or a personal API key.
+// Without an Authorization header the access token cookie is used.
+// publicRoutes lists "METHOD /route/pattern" entries (as registered with gin, e.g. "GET /api/kanji/:character")
+// that are let through without a token.
+func RequireAuth(service authinterfaces.AuthService, publicRoutes ...string) gin.HandlerFunc {
+	allowed := make(map[string]struct{}, len(publicRoutes))
+	for _, route := range publicRoutes {
+		allowed[route] = struct{}{}
+	}
+
+	return func(ctx *gin.Context) {
+		if _, ok := allowed[ctx.Request.Method+" "+ctx.FullPath()]; ok {
+			ctx.Next()
+			return
+		}
+
+		token, ok := RequestToken(ctx)
+		if !ok {
+			response.Unauthorized(ctx, "missing or invalid authorization header")
+			ctx.Abort()
+			return
+		}
+
+		claims, err := service.Authenticate(ctx.Request.Context(), token)
+		if err != nil {
+			response.Unauthorized(ctx, "invalid or expired token")
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(userIDKey, claims.UserID)
+		ctx.Set(claimsKey, claims)
+
+		requestCtx := context.WithValue(ctx.Request.Context(), userIDContextKey, claims.UserID)
+		requestCtx = context.WithValue(requestCtx, claimsContextKey, claims)
+		ctx.Request = ctx.Request.WithContext(requestCtx)
+
+		ctx.Next()
+	}
+}
+
+// RequestToken returns the bearer token of the request, falling back to the access token cookie when no
+// Authorization header is sent.
+func RequestToken(ctx *gin.Context) (string, bool) {
+	if header := ctx.GetHeader("Authorization"); header != "" {
+		return BearerToken(header)
+	}
+
+	token, err := ctx.Cookie(AccessTokenCookie)
+	if err != nil || token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
+// BearerToken extracts the token from an Authorization header value.
+func BearerToken(header string) (string, bool) {
+	header = strings.TrimSpace(header)
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[len(bearerPrefix):])
+	return token, token != ""
+}
+
+// CurrentUserID returns the authenticated user ID stored by RequireAuth.
+func CurrentUserID(ctx *gin.Context) (int64, bool) {
+	value, ok := ctx.Get(userIDKey)
+	if !ok {
+		return 0, false
+	}
+
+	userID, ok := value.(int64)
+	return userID, ok
+}
+
+// CurrentClaims returns the token claims stored by RequireAuth.
+func CurrentClaims(ctx *gin.Context) (*dto.Claims, bool) {
+	value, ok := ctx.Get(claimsKey)
+	if !ok {
+		return nil, false
+	}
+
+	claims, ok := value.(*dto.Claims)
+	return claims, ok
+}
+
+// UserIDFromContext returns the authenticated user ID from a request context.
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(userIDContextKey).(int64)
+	return userID, ok
+}
+
+// ClaimsFromContext returns the token claims from a request context.
+func ClaimsFromContext(ctx context.Context) (*dto.Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*dto.Claims)
+	return claims, ok
+}
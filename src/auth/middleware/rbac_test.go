@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/src/auth/dto"
+	"gobackend/src/auth/rbac"
+)
+
+func TestAuthorize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	policies := map[string]string{"GET /api/users/logs": rbac.PermissionLogsRead}
+
+	session := &dto.Claims{UserID: 1, Roles: []string{rbac.RoleAdmin}}
+	learner := &dto.Claims{UserID: 2, Roles: []string{rbac.RoleLearner}}
+	logsKey := &dto.Claims{UserID: 1, Roles: []string{rbac.RoleAdmin}, APIKeyID: 7, Scopes: []string{rbac.PermissionLogsRead}}
+	usersKey := &dto.Claims{UserID: 1, Roles: []string{rbac.RoleAdmin}, APIKeyID: 8, Scopes: []string{rbac.PermissionUsersRead}}
+
+	tests := []struct {
+		name   string
+		claims *dto.Claims
+		method string
+		path   string
+		want   int
+	}{
+		{name: "policy granted by role", claims: session, method: http.MethodGet, path: "/api/users/logs", want: http.StatusOK},
+		{name: "policy not granted by role", claims: learner, method: http.MethodGet, path: "/api/users/logs", want: http.StatusForbidden},
+		{name: "api key with scope", claims: logsKey, method: http.MethodGet, path: "/api/users/logs", want: http.StatusOK},
+		{name: "api key without scope", claims: usersKey, method: http.MethodGet, path: "/api/users/logs", want: http.StatusForbidden},
+		{name: "unpolicied route with access token", claims: learner, method: http.MethodPatch, path: "/api/me", want: http.StatusOK},
+		{name: "unpolicied route with api key", claims: logsKey, method: http.MethodPatch, path: "/api/me", want: http.StatusForbidden},
+		{name: "identity link with api key", claims: logsKey, method: http.MethodPost, path: "/api/me/identities/github", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(ctx *gin.Context) {
+				ctx.Set(claimsKey, tt.claims)
+				ctx.Set(userIDKey, tt.claims.UserID)
+			}, Authorize(policies))
+
+			ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+			router.GET("/api/users/logs", ok)
+			router.PATCH("/api/me", ok)
+			router.POST("/api/me/identities/:provider", ok)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+}
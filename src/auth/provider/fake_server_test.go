@@ -0,0 +1,160 @@
+package provider_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+
+	"gobackend/src/auth/dto"
+)
+
+const (
+	testClientID     = "client-id"
+	testClientSecret = "client-secret"
+	testRedirectURL  = "https://app.example/callback"
+	testCode         = "auth-code"
+	testVerifier     = "pkce-verifier-0123456789-0123456789-0123456789"
+	testNonce        = "nonce-123"
+	testAccessToken  = "access-token"
+	testKeyID        = "key-1"
+)
+
+// fakeAuthServer is a local OAuth2 / OpenID Connect provider. Its token endpoint only accepts testCode
+// together with testVerifier, and its API endpoints only accept testAccessToken.
+type fakeAuthServer struct {
+	*httptest.Server
+
+	key *rsa.PrivateKey
+
+	// idTokenClaims are signed into the token response; no id_token is returned when nil.
+	idTokenClaims jwt.MapClaims
+	// idTokenKeyID is the kid header of the id_token.
+	idTokenKeyID string
+	// tokenError, when set, is returned by the token endpoint as an OAuth2 error.
+	tokenError string
+
+	userInfo     map[string]interface{}
+	githubUser   map[string]interface{}
+	githubEmails []map[string]interface{}
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+
+	fake := &fakeAuthServer{key: key, idTokenKeyID: testKeyID}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", fake.token)
+	mux.HandleFunc("/.well-known/openid-configuration", fake.discovery)
+	mux.HandleFunc("/userinfo", fake.authorized(func() interface{} { return fake.userInfo }))
+	mux.HandleFunc("/user", fake.authorized(func() interface{} { return fake.githubUser }))
+	mux.HandleFunc("/user/emails", fake.authorized(func() interface{} { return fake.githubEmails }))
+
+	fake.Server = httptest.NewServer(mux)
+	t.Cleanup(fake.Close)
+
+	return fake
+}
+
+// endpoint returns the authorization and token endpoints of the server.
+func (f *fakeAuthServer) endpoint() oauth2.Endpoint {
+	return oauth2.Endpoint{
+		AuthURL:   f.URL + "/authorize",
+		TokenURL:  f.URL + "/token",
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+}
+
+// validIDTokenClaims returns claims that pass verification for issuer.
+func validIDTokenClaims(issuer string) jwt.MapClaims {
+	now := time.Now()
+
+	return jwt.MapClaims{
+		"iss":   issuer,
+		"sub":   "subject-1",
+		"aud":   testClientID,
+		"nonce": testNonce,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+}
+
+func (f *fakeAuthServer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	if f.tokenError != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": f.tokenError})
+		return
+	}
+
+	if r.PostForm.Get("code") != testCode || r.PostForm.Get("code_verifier") != testVerifier {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	body := map[string]interface{}{
+		"access_token": testAccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	}
+
+	if f.idTokenClaims != nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, f.idTokenClaims)
+		token.Header["kid"] = f.idTokenKeyID
+
+		signed, err := token.SignedString(f.key)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+			return
+		}
+		body["id_token"] = signed
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (f *fakeAuthServer) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 f.URL,
+		"authorization_endpoint": f.URL + "/authorize",
+		"token_endpoint":         f.URL + "/token",
+		"userinfo_endpoint":      f.URL + "/userinfo",
+	})
+}
+
+func (f *fakeAuthServer) authorized(body func() interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testAccessToken {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, body())
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// testParams returns the callback parameters the fake server accepts.
+func testParams() dto.AuthParams {
+	return dto.AuthParams{State: "state", CodeVerifier: testVerifier, Nonce: testNonce}
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+// GitHubName is the key of the GitHub provider.
+const GitHubName = "github"
+
+const githubAPIBaseURL = "https://api.github.com"
+
+var _ authinterfaces.IdentityProvider = (*GitHub)(nil)
+
+// GitHubConfig configures the GitHub provider. Endpoint overrides are only needed in tests or for GitHub Enterprise.
+type GitHubConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client
+
+	Endpoint   oauth2.Endpoint
+	APIBaseURL string
+}
+
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// GitHub signs users in with their GitHub account.
+type GitHub struct {
+	oauthConfig *oauth2.Config
+	apiBaseURL  string
+	httpClient  *http.Client
+}
+
+// NewGitHub builds the GitHub provider.
+func NewGitHub(cfg GitHubConfig) (*GitHub, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("github provider requires client id, client secret and redirect url")
+	}
+
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"read:user", "user:email"}
+	}
+
+	if cfg.Endpoint.AuthURL == "" {
+		cfg.Endpoint = github.Endpoint
+	}
+
+	if cfg.APIBaseURL == "" {
+		cfg.APIBaseURL = githubAPIBaseURL
+	}
+
+	return &GitHub{
+		oauthConfig: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       cfg.Scopes,
+			Endpoint:     cfg.Endpoint,
+		},
+		apiBaseURL: strings.TrimSuffix(cfg.APIBaseURL, "/"),
+		httpClient: httpClientOrDefault(cfg.HTTPClient),
+	}, nil
+}
+
+// Name returns GitHubName.
+func (g *GitHub) Name() string {
+	return GitHubName
+}
+
+// SupportsNonce returns false; GitHub OAuth apps do not issue ID tokens.
+func (g *GitHub) SupportsNonce() bool {
+	return false
+}
+
+// AuthCodeURL produces the GitHub authorization URL.
+func (g *GitHub) AuthCodeURL(_ context.Context, params dto.AuthParams) (string, error) {
+	return g.oauthConfig.AuthCodeURL(params.State, authCodeOptions(params.CodeVerifier, "")...), nil
+}
+
+// Exchange trades the code and PKCE verifier for a token and reads the GitHub profile and primary email.
+func (g *GitHub) Exchange(ctx context.Context, code string, params dto.AuthParams) (*dto.ExternalIdentity, error) {
+	token, err := exchangeCode(ctx, g.oauthConfig, g.httpClient, code, params.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var user githubUser
+	if err := getJSON(ctx, g.httpClient, g.apiBaseURL+"/user", token.AccessToken, &user); err != nil {
+		return nil, fmt.Errorf("fetch github user: %w", err)
+	}
+
+	if user.ID == 0 {
+		return nil, errors.New("github user has no id")
+	}
+
+	// The profile email is optional and unverified; the emails endpoint reports the verified primary address.
+	var emails []githubEmail
+	if err := getJSON(ctx, g.httpClient, g.apiBaseURL+"/user/emails", token.AccessToken, &emails); err != nil {
+		return nil, fmt.Errorf("fetch github emails: %w", err)
+	}
+
+	identity := &dto.ExternalIdentity{
+		Provider:   GitHubName,
+		Subject:    strconv.FormatInt(user.ID, 10),
+		Name:       user.Name,
+		PictureURL: user.AvatarURL,
+	}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+
+	for _, email := range emails {
+		if email.Primary {
+			identity.Email = email.Email
+			identity.EmailVerified = email.Verified
+			break
+		}
+	}
+
+	return identity, nil
+}
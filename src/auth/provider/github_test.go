@@ -0,0 +1,102 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"gobackend/src/auth/provider"
+)
+
+func newTestGitHub(t *testing.T, server *fakeAuthServer) *provider.GitHub {
+	t.Helper()
+
+	github, err := provider.NewGitHub(provider.GitHubConfig{
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+		HTTPClient:   server.Client(),
+		Endpoint:     server.endpoint(),
+		APIBaseURL:   server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewGitHub: %v", err)
+	}
+
+	return github
+}
+
+func TestGitHubExchange(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.githubUser = map[string]interface{}{"id": 42, "login": "octocat", "avatar_url": "https://avatars.example/42"}
+	server.githubEmails = []map[string]interface{}{
+		{"email": "old@example.com", "primary": false, "verified": true},
+		{"email": "octocat@example.com", "primary": true, "verified": true},
+	}
+
+	identity, err := newTestGitHub(t, server).Exchange(context.Background(), testCode, testParams())
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	if identity.Provider != provider.GitHubName || identity.Subject != "42" {
+		t.Errorf("identity = %s/%s, want github/42", identity.Provider, identity.Subject)
+	}
+	if identity.Email != "octocat@example.com" || !identity.EmailVerified {
+		t.Errorf("email = %q (verified %t), want the verified primary address", identity.Email, identity.EmailVerified)
+	}
+	if identity.Name != "octocat" {
+		t.Errorf("name = %q, want the login when the profile has no name", identity.Name)
+	}
+	if identity.PictureURL != "https://avatars.example/42" {
+		t.Errorf("picture = %q", identity.PictureURL)
+	}
+}
+
+func TestGitHubExchangeTokenError(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.tokenError = "bad_verification_code"
+
+	if _, err := newTestGitHub(t, server).Exchange(context.Background(), testCode, testParams()); err == nil {
+		t.Fatal("Exchange succeeded, want the token endpoint error")
+	}
+}
+
+func TestGitHubExchangeEmail(t *testing.T) {
+	tests := []struct {
+		name         string
+		emails       []map[string]interface{}
+		wantEmail    string
+		wantVerified bool
+	}{
+		{
+			name:      "unverified primary",
+			emails:    []map[string]interface{}{{"email": "octocat@example.com", "primary": true, "verified": false}},
+			wantEmail: "octocat@example.com",
+		},
+		{
+			name:   "no primary",
+			emails: []map[string]interface{}{{"email": "octocat@example.com", "primary": false, "verified": true}},
+		},
+		{
+			name:   "no emails",
+			emails: []map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeAuthServer(t)
+			server.githubUser = map[string]interface{}{"id": 42, "login": "octocat"}
+			server.githubEmails = tt.emails
+
+			identity, err := newTestGitHub(t, server).Exchange(context.Background(), testCode, testParams())
+			if err != nil {
+				t.Fatalf("Exchange: %v", err)
+			}
+
+			if identity.Email != tt.wantEmail || identity.EmailVerified != tt.wantVerified {
+				t.Errorf("email = %q (verified %t), want %q (verified %t)", identity.Email, identity.EmailVerified, tt.wantEmail, tt.wantVerified)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"gobackend/shared/jwks"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+// GoogleName is the key of the Google provider.
+const GoogleName = "google"
+
+const googleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+var googleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+// ErrEmailUnverified indicates the provider vouched for the identity but not for its email address.
+var ErrEmailUnverified = errors.New("provider has not verified the email address")
+
+var _ authinterfaces.IdentityProvider = (*Google)(nil)
+
+// GoogleConfig configures the Google provider. Endpoint and JWKS overrides are only needed in tests.
+type GoogleConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client
+
+	Endpoint oauth2.Endpoint
+	// JWKS supplies Google's signing keys; defaults to downloading them from googleJWKSURL.
+	JWKS         jwks.Fetcher
+	JWKSCacheTTL time.Duration
+}
+
+// googleUserInfo holds the profile claims of a Google ID token.
+type googleUserInfo struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	GivenName     string `json:"given_name"`
+	Picture       string `json:"picture"`
+	HostedDomain  string `json:"hd"`
+}
+
+type googleIDTokenClaims struct {
+	googleUserInfo
+	idTokenClaims
+}
+
+// Google signs users in with their Google account.
+type Google struct {
+	oauthConfig *oauth2.Config
+	keys        *jwks.Cache
+	httpClient  *http.Client
+}
+
+// NewGoogle builds the Google provider.
+func NewGoogle(cfg GoogleConfig) (*Google, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("google provider requires client id, client secret and redirect url")
+	}
+
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{
+			"https://www.googleapis.com/auth/userinfo.email",
+			"https://www.googleapis.com/auth/userinfo.profile",
+			"openid",
+		}
+	}
+
+	if cfg.Endpoint.AuthURL == "" {
+		cfg.Endpoint = google.Endpoint
+	}
+
+	httpClient := httpClientOrDefault(cfg.HTTPClient)
+	if cfg.JWKS == nil {
+		cfg.JWKS = jwks.NewHTTPFetcher(googleJWKSURL, httpClient)
+	}
+
+	return &Google{
+		oauthConfig: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       cfg.Scopes,
+			Endpoint:     cfg.Endpoint,
+		},
+		keys:       jwks.NewCache(cfg.JWKS, cfg.JWKSCacheTTL),
+		httpClient: httpClient,
+	}, nil
+}
+
+// Name returns GoogleName.
+func (g *Google) Name() string {
+	return GoogleName
+}
+
+// SupportsNonce returns true; Google issues OpenID Connect ID tokens.
+func (g *Google) SupportsNonce() bool {
+	return true
+}
+
+// AuthCodeURL produces the Google consent screen URL.
+func (g *Google) AuthCodeURL(_ context.Context, params dto.AuthParams) (string, error) {
+	options := append(
+		authCodeOptions(params.CodeVerifier, params.Nonce),
+		oauth2.AccessTypeOffline,
+		oauth2.SetAuthURLParam("prompt", "select_account"),
+		oauth2.SetAuthURLParam("include_granted_scopes", "true"),
+	)
+
+	return g.oauthConfig.AuthCodeURL(params.State, options...), nil
+}
+
+// Exchange trades the code for a token and builds the identity from the verified ID token, so no
+// userinfo request is made. Tokens whose email is not verified are refused with ErrEmailUnverified.
+func (g *Google) Exchange(ctx context.Context, code string, params dto.AuthParams) (*dto.ExternalIdentity, error) {
+	token, err := exchangeCode(ctx, g.oauthConfig, g.httpClient, code, params.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &googleIDTokenClaims{}
+	if err := verifySignedIDToken(ctx, token, g.keys, claims, googleIssuers, g.oauthConfig.ClientID, params.Nonce); err != nil {
+		return nil, fmt.Errorf("verify google id token: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("google id token has no subject")
+	}
+
+	info := claims.googleUserInfo
+	if !info.EmailVerified {
+		return nil, ErrEmailUnverified
+	}
+
+	name := info.Name
+	if name == "" {
+		name = info.GivenName
+	}
+
+	return &dto.ExternalIdentity{
+		Provider:      GoogleName,
+		Subject:       claims.Subject,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Name:          name,
+		PictureURL:    info.Picture,
+		HostedDomain:  info.HostedDomain,
+	}, nil
+}
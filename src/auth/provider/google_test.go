@@ -0,0 +1,157 @@
+package provider_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"gobackend/shared/jwks"
+	"gobackend/src/auth/provider"
+)
+
+const googleIssuer = "https://accounts.google.com"
+
+// newTestGoogle builds a Google provider that exchanges codes with server and verifies ID tokens against
+// server's signing key without any network access to Google.
+func newTestGoogle(t *testing.T, server *fakeAuthServer) *provider.Google {
+	t.Helper()
+
+	key := rsaJWK(testKeyID, &server.key.PublicKey)
+
+	google, err := provider.NewGoogle(provider.GoogleConfig{
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+		HTTPClient:   server.Client(),
+		Endpoint:     server.endpoint(),
+		JWKS: jwks.FetcherFunc(func(context.Context) (*jwks.Set, error) {
+			return &jwks.Set{Keys: []jwks.Key{key}}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewGoogle: %v", err)
+	}
+
+	return google
+}
+
+// rsaJWK encodes public as an RS256 JSON Web Key named keyID.
+func rsaJWK(keyID string, public *rsa.PublicKey) jwks.Key {
+	return jwks.Key{
+		KeyType:   "RSA",
+		KeyID:     keyID,
+		Use:       "sig",
+		Algorithm: jwt.SigningMethodRS256.Alg(),
+		N:         base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+		E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+	}
+}
+
+func validGoogleClaims() jwt.MapClaims {
+	claims := validIDTokenClaims(googleIssuer)
+	claims["email"] = "ada@example.com"
+	claims["email_verified"] = true
+	claims["name"] = "Ada"
+	claims["hd"] = "example.com"
+
+	return claims
+}
+
+func TestGoogleExchange(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.idTokenClaims = validGoogleClaims()
+
+	identity, err := newTestGoogle(t, server).Exchange(context.Background(), testCode, testParams())
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	if identity.Provider != provider.GoogleName || identity.Subject != "subject-1" {
+		t.Errorf("identity = %s/%s, want google/subject-1", identity.Provider, identity.Subject)
+	}
+	if identity.Email != "ada@example.com" || !identity.EmailVerified || identity.HostedDomain != "example.com" {
+		t.Errorf("identity = %+v, want the verified email and hosted domain from the id_token", identity)
+	}
+}
+
+func TestGoogleExchangeRejectsUnverifiedEmail(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.idTokenClaims = validGoogleClaims()
+	server.idTokenClaims["email_verified"] = false
+
+	_, err := newTestGoogle(t, server).Exchange(context.Background(), testCode, testParams())
+	if !errors.Is(err, provider.ErrEmailUnverified) {
+		t.Fatalf("Exchange error = %v, want ErrEmailUnverified", err)
+	}
+}
+
+func TestGoogleExchangeVerifiesIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(server *fakeAuthServer)
+	}{
+		{
+			name:   "unknown kid",
+			mutate: func(server *fakeAuthServer) { server.idTokenKeyID = "key-2" },
+		},
+		{
+			name:   "wrong audience",
+			mutate: func(server *fakeAuthServer) { server.idTokenClaims["aud"] = "another-client" },
+		},
+		{
+			name:   "wrong issuer",
+			mutate: func(server *fakeAuthServer) { server.idTokenClaims["iss"] = "https://accounts.google.invalid" },
+		},
+		{
+			name: "expired",
+			mutate: func(server *fakeAuthServer) {
+				server.idTokenClaims["iat"] = time.Now().Add(-3 * time.Hour).Unix()
+				server.idTokenClaims["exp"] = time.Now().Add(-2 * time.Hour).Unix()
+			},
+		},
+		{
+			name:   "nonce mismatch",
+			mutate: func(server *fakeAuthServer) { server.idTokenClaims["nonce"] = "another-nonce" },
+		},
+		{
+			name:   "no expiry",
+			mutate: func(server *fakeAuthServer) { delete(server.idTokenClaims, "exp") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeAuthServer(t)
+			server.idTokenClaims = validGoogleClaims()
+			tt.mutate(server)
+
+			if _, err := newTestGoogle(t, server).Exchange(context.Background(), testCode, testParams()); err == nil {
+				t.Fatal("Exchange succeeded, want the id_token to be rejected")
+			}
+		})
+	}
+}
+
+func TestGoogleExchangeRejectsForeignSignature(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.idTokenClaims = validGoogleClaims()
+	google := newTestGoogle(t, server)
+
+	// The server now signs with a key the provider has never seen, under the trusted kid.
+	foreign, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	server.key = foreign
+
+	if _, err := google.Exchange(context.Background(), testCode, testParams()); err == nil {
+		t.Fatal("Exchange succeeded with an id_token signed by an untrusted key")
+	}
+}
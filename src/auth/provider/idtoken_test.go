@@ -0,0 +1,65 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestExchangeRejectsWrongCodeVerifier(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.idTokenClaims = validIDTokenClaims(server.URL)
+	server.userInfo = map[string]interface{}{"sub": "subject-1"}
+	server.githubUser = map[string]interface{}{"id": 42}
+
+	params := testParams()
+	params.CodeVerifier = "another-verifier-0123456789-0123456789-01234"
+
+	if _, err := newTestOIDC(t, server).Exchange(context.Background(), testCode, params); err == nil {
+		t.Error("OIDC Exchange succeeded with the wrong PKCE verifier")
+	}
+
+	if _, err := newTestGitHub(t, server).Exchange(context.Background(), testCode, params); err == nil {
+		t.Error("GitHub Exchange succeeded with the wrong PKCE verifier")
+	}
+}
+
+func TestOIDCExchangeVerifiesIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(server *fakeAuthServer)
+	}{
+		{
+			name:   "nonce mismatch",
+			mutate: func(server *fakeAuthServer) { server.idTokenClaims["nonce"] = "another-nonce" },
+		},
+		{
+			name:   "nonce missing",
+			mutate: func(server *fakeAuthServer) { delete(server.idTokenClaims, "nonce") },
+		},
+		{
+			name:   "wrong audience",
+			mutate: func(server *fakeAuthServer) { server.idTokenClaims["aud"] = "another-client" },
+		},
+		{
+			name:   "wrong issuer",
+			mutate: func(server *fakeAuthServer) { server.idTokenClaims["iss"] = "https://issuer.invalid" },
+		},
+		{
+			name:   "no id_token",
+			mutate: func(server *fakeAuthServer) { server.idTokenClaims = nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeAuthServer(t)
+			server.idTokenClaims = validIDTokenClaims(server.URL)
+			server.userInfo = map[string]interface{}{"sub": "subject-1", "email": "ada@example.com", "email_verified": true}
+			tt.mutate(server)
+
+			if _, err := newTestOIDC(t, server).Exchange(context.Background(), testCode, testParams()); err == nil {
+				t.Fatal("Exchange succeeded, want the id_token to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+var _ authinterfaces.IdentityProvider = (*OIDC)(nil)
+
+// OIDCConfig configures a generic OpenID Connect provider discovered from its issuer URL.
+type OIDCConfig struct {
+	// Name is the provider key used in routes, e.g. "keycloak".
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+type oidcUserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	PreferredName string `json:"preferred_username"`
+	Picture       string `json:"picture"`
+}
+
+// OIDC signs users in with any OpenID Connect provider. The discovery document is fetched on first use
+// and cached, so an unreachable provider does not prevent the application from starting.
+type OIDC struct {
+	name       string
+	cfg        OIDCConfig
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	oauthConfig *oauth2.Config
+	userInfoURL string
+	issuer      string
+}
+
+// NewOIDC builds a generic OIDC provider.
+func NewOIDC(cfg OIDCConfig) (*OIDC, error) {
+	if cfg.Name == "" || cfg.IssuerURL == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("oidc provider requires name, issuer url, client id and redirect url")
+	}
+
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+
+	return &OIDC{
+		name:       cfg.Name,
+		cfg:        cfg,
+		httpClient: httpClientOrDefault(cfg.HTTPClient),
+	}, nil
+}
+
+// Name returns the configured provider key.
+func (o *OIDC) Name() string {
+	return o.name
+}
+
+// SupportsNonce returns true; OpenID Connect providers issue ID tokens.
+func (o *OIDC) SupportsNonce() bool {
+	return true
+}
+
+// AuthCodeURL produces the provider's authorization URL.
+func (o *OIDC) AuthCodeURL(ctx context.Context, params dto.AuthParams) (string, error) {
+	config, _, err := o.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return config.AuthCodeURL(params.State, authCodeOptions(params.CodeVerifier, params.Nonce)...), nil
+}
+
+// Exchange trades the code for a token, checks the ID token nonce and reads the userinfo endpoint.
+func (o *OIDC) Exchange(ctx context.Context, code string, params dto.AuthParams) (*dto.ExternalIdentity, error) {
+	config, userInfoURL, err := o.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := exchangeCode(ctx, config, o.httpClient, code, params.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyIDTokenNonce(token, []string{o.issuer}, o.cfg.ClientID, params.Nonce); err != nil {
+		return nil, fmt.Errorf("verify %s id token: %w", o.name, err)
+	}
+
+	var info oidcUserInfo
+	if err := getJSON(ctx, o.httpClient, userInfoURL, token.AccessToken, &info); err != nil {
+		return nil, fmt.Errorf("fetch %s user info: %w", o.name, err)
+	}
+
+	if info.Subject == "" {
+		return nil, fmt.Errorf("%s user info has no subject", o.name)
+	}
+
+	name := info.Name
+	if name == "" {
+		name = info.PreferredName
+	}
+
+	return &dto.ExternalIdentity{
+		Provider:      o.name,
+		Subject:       info.Subject,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Name:          name,
+		PictureURL:    info.Picture,
+	}, nil
+}
+
+func (o *OIDC) discover(ctx context.Context) (*oauth2.Config, string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.oauthConfig != nil {
+		return o.oauthConfig, o.userInfoURL, nil
+	}
+
+	var document oidcDiscovery
+	if err := getJSON(ctx, o.httpClient, o.cfg.IssuerURL+discoveryPath, "", &document); err != nil {
+		return nil, "", fmt.Errorf("discover %s: %w", o.name, err)
+	}
+
+	if strings.TrimSuffix(document.Issuer, "/") != o.cfg.IssuerURL {
+		return nil, "", fmt.Errorf("discover %s: issuer %q does not match %q", o.name, document.Issuer, o.cfg.IssuerURL)
+	}
+
+	if document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" || document.UserInfoEndpoint == "" {
+		return nil, "", fmt.Errorf("discover %s: document is missing endpoints", o.name)
+	}
+
+	o.oauthConfig = &oauth2.Config{
+		ClientID:     o.cfg.ClientID,
+		ClientSecret: o.cfg.ClientSecret,
+		RedirectURL:  o.cfg.RedirectURL,
+		Scopes:       o.cfg.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  document.AuthorizationEndpoint,
+			TokenURL: document.TokenEndpoint,
+		},
+	}
+	o.userInfoURL = document.UserInfoEndpoint
+	o.issuer = document.Issuer
+
+	return o.oauthConfig, o.userInfoURL, nil
+}
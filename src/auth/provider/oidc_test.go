@@ -0,0 +1,120 @@
+package provider_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gobackend/src/auth/provider"
+)
+
+func newTestOIDC(t *testing.T, server *fakeAuthServer) *provider.OIDC {
+	t.Helper()
+
+	oidc, err := provider.NewOIDC(provider.OIDCConfig{
+		Name:         "keycloak",
+		IssuerURL:    server.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+		HTTPClient:   server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("NewOIDC: %v", err)
+	}
+
+	return oidc
+}
+
+func TestOIDCExchange(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.idTokenClaims = validIDTokenClaims(server.URL)
+	server.userInfo = map[string]interface{}{
+		"sub":                "subject-1",
+		"email":              "ada@example.com",
+		"email_verified":     true,
+		"preferred_username": "ada",
+	}
+
+	identity, err := newTestOIDC(t, server).Exchange(context.Background(), testCode, testParams())
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	if identity.Provider != "keycloak" || identity.Subject != "subject-1" {
+		t.Errorf("identity = %s/%s, want keycloak/subject-1", identity.Provider, identity.Subject)
+	}
+	if identity.Email != "ada@example.com" || !identity.EmailVerified {
+		t.Errorf("email = %q (verified %t), want a verified ada@example.com", identity.Email, identity.EmailVerified)
+	}
+	if identity.Name != "ada" {
+		t.Errorf("name = %q, want the preferred username when there is no name", identity.Name)
+	}
+}
+
+func TestOIDCAuthCodeURL(t *testing.T) {
+	server := newFakeAuthServer(t)
+
+	loginURL, err := newTestOIDC(t, server).AuthCodeURL(context.Background(), testParams())
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+
+	for _, want := range []string{server.URL + "/authorize?", "code_challenge_method=S256", "nonce=" + testNonce, "state=state"} {
+		if !strings.Contains(loginURL, want) {
+			t.Errorf("login URL %q does not contain %q", loginURL, want)
+		}
+	}
+}
+
+func TestOIDCExchangeTokenError(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.tokenError = "invalid_grant"
+
+	if _, err := newTestOIDC(t, server).Exchange(context.Background(), testCode, testParams()); err == nil {
+		t.Fatal("Exchange succeeded, want the token endpoint error")
+	}
+}
+
+func TestOIDCExchangeEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		userInfo map[string]interface{}
+	}{
+		{
+			name:     "unverified",
+			userInfo: map[string]interface{}{"sub": "subject-1", "email": "ada@example.com", "email_verified": false},
+		},
+		{
+			name:     "missing",
+			userInfo: map[string]interface{}{"sub": "subject-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeAuthServer(t)
+			server.idTokenClaims = validIDTokenClaims(server.URL)
+			server.userInfo = tt.userInfo
+
+			identity, err := newTestOIDC(t, server).Exchange(context.Background(), testCode, testParams())
+			if err != nil {
+				t.Fatalf("Exchange: %v", err)
+			}
+
+			if identity.EmailVerified {
+				t.Errorf("email %q reported as verified", identity.Email)
+			}
+		})
+	}
+}
+
+func TestOIDCExchangeRequiresSubject(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.idTokenClaims = validIDTokenClaims(server.URL)
+	server.userInfo = map[string]interface{}{"email": "ada@example.com", "email_verified": true}
+
+	if _, err := newTestOIDC(t, server).Exchange(context.Background(), testCode, testParams()); err == nil {
+		t.Fatal("Exchange succeeded without a subject")
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+// Registry holds the configured identity providers keyed by name.
+type Registry struct {
+	providers map[string]authinterfaces.IdentityProvider
+}
+
+// NewRegistry builds a Registry. Provider names must be unique.
+func NewRegistry(providers ...authinterfaces.IdentityProvider) (*Registry, error) {
+	registry := &Registry{providers: make(map[string]authinterfaces.IdentityProvider, len(providers))}
+	for _, provider := range providers {
+		if provider == nil {
+			continue
+		}
+
+		name := provider.Name()
+		if _, exists := registry.providers[name]; exists {
+			return nil, fmt.Errorf("identity provider %q registered twice", name)
+		}
+		registry.providers[name] = provider
+	}
+
+	return registry, nil
+}
+
+// Get returns the provider registered under name.
+func (r *Registry) Get(name string) (authinterfaces.IdentityProvider, bool) {
+	provider, ok := r.providers[name]
+	return provider, ok
+}
+
+// Names returns the registered provider names in alphabetical order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Len returns the number of registered providers.
+func (r *Registry) Len() int {
+	return len(r.providers)
+}
@@ -0,0 +1,57 @@
+package rbac
+
+// Roles that can be granted to a user.
+const (
+	RoleAdmin   = "admin"
+	RoleEditor  = "editor"
+	RoleLearner = "learner"
+)
+
+// Permissions checked by route policies.
+const (
+	PermissionUsersRead      = "users:read"
+	PermissionUsersManage    = "users:manage"
+	PermissionLogsRead       = "logs:read"
+	PermissionRolesManage    = "roles:manage"
+	PermissionContentWrite   = "content:write"
+	PermissionSignupManage   = "signup:manage"
+	PermissionSessionsRevoke = "sessions:revoke"
+)
+
+// DefaultRole is granted to every newly registered user.
+const DefaultRole = RoleLearner
+
+var rolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionUsersRead,
+		PermissionUsersManage,
+		PermissionLogsRead,
+		PermissionRolesManage,
+		PermissionContentWrite,
+		PermissionSignupManage,
+		PermissionSessionsRevoke,
+	},
+	RoleEditor: {
+		PermissionContentWrite,
+	},
+	RoleLearner: {},
+}
+
+// IsValidRole reports whether role is a known role.
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Grants reports whether any of the roles carries the permission.
+func Grants(roles []string, permission string) bool {
+	for _, role := range roles {
+		for _, granted := range rolePermissions[role] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
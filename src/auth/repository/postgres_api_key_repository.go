@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"gobackend/src/auth/dao"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+var _ authinterfaces.APIKeyRepository = (*PostgresAPIKeyRepository)(nil)
+
+const apiKeyColumns = `id, user_id, label, prefix, key_hash, scopes, created_at, last_used_at, revoked_at`
+
+// PostgresAPIKeyRepository persists personal API keys in Postgres.
+type PostgresAPIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAPIKeyRepository constructs a PostgresAPIKeyRepository and ensures the expected schema exists.
+func NewPostgresAPIKeyRepository(db *sql.DB) (*PostgresAPIKeyRepository, error) {
+	repo := &PostgresAPIKeyRepository{db: db}
+	if err := repo.ensureSchema(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r *PostgresAPIKeyRepository) ensureSchema() error {
+	const tableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = 'api_keys'
+`
+
+	var exists int
+	if err := r.db.QueryRow(tableQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("api_keys table not found; please run database migrations: %w", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindAPIKeys returns the unrevoked keys of a user, newest first.
+func (r *PostgresAPIKeyRepository) FindAPIKeys(ctx context.Context, userID int64) ([]dao.APIKey, error) {
+	query := fmt.Sprintf(`
+SELECT %s
+FROM api_keys
+WHERE user_id = $1 AND revoked_at IS NULL
+ORDER BY created_at DESC, id DESC
+`, apiKeyColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []dao.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, rows.Err()
+}
+
+// FindAPIKeyByHash locates a key by the hash of its value.
+func (r *PostgresAPIKeyRepository) FindAPIKeyByHash(ctx context.Context, keyHash string) (*dao.APIKey, error) {
+	query := fmt.Sprintf("SELECT %s FROM api_keys WHERE key_hash = $1", apiKeyColumns)
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// CreateAPIKey inserts a new key.
+func (r *PostgresAPIKeyRepository) CreateAPIKey(ctx context.Context, key dao.APIKey) (*dao.APIKey, error) {
+	query := fmt.Sprintf(`
+INSERT INTO api_keys (user_id, label, prefix, key_hash, scopes)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING %s
+`, apiKeyColumns)
+
+	return scanAPIKey(r.db.QueryRowContext(ctx, query, key.UserID, key.Label, key.Prefix, key.KeyHash, pq.Array(key.Scopes)))
+}
+
+// UpdateAPIKeyLabel renames an unrevoked key of the user; it returns nil when there is no such key.
+func (r *PostgresAPIKeyRepository) UpdateAPIKeyLabel(ctx context.Context, userID, keyID int64, label string) (*dao.APIKey, error) {
+	query := fmt.Sprintf(`
+UPDATE api_keys
+SET label = $3
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+RETURNING %s
+`, apiKeyColumns)
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, keyID, userID, label))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// RevokeAPIKey reports whether an unrevoked key of the user was revoked.
+func (r *PostgresAPIKeyRepository) RevokeAPIKey(ctx context.Context, userID, keyID int64, now time.Time) (bool, error) {
+	const query = `
+UPDATE api_keys
+SET revoked_at = $3
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+`
+
+	return execAffected(ctx, r.db, query, keyID, userID, now)
+}
+
+// TouchAPIKey records that the key was used at now.
+func (r *PostgresAPIKeyRepository) TouchAPIKey(ctx context.Context, keyID int64, now time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE api_keys SET last_used_at = $2 WHERE id = $1", keyID, now)
+	return err
+}
+
+func scanAPIKey(row rowScanner) (*dao.APIKey, error) {
+	var (
+		key        dao.APIKey
+		lastUsedAt sql.NullTime
+		revokedAt  sql.NullTime
+	)
+
+	if err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Label,
+		&key.Prefix,
+		&key.KeyHash,
+		pq.Array(&key.Scopes),
+		&key.CreatedAt,
+		&lastUsedAt,
+		&revokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if lastUsedAt.Valid {
+		key.LastUsedAt = lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = revokedAt.Time
+	}
+
+	return &key, nil
+}
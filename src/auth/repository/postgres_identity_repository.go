@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"gobackend/src/auth/dao"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+var _ authinterfaces.IdentityRepository = (*PostgresIdentityRepository)(nil)
+
+const identityColumns = `id, user_id, provider, provider_id, email, email_verified, created_at`
+
+// PostgresIdentityRepository persists linked provider identities in Postgres.
+type PostgresIdentityRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresIdentityRepository constructs a PostgresIdentityRepository and ensures the expected schema exists.
+func NewPostgresIdentityRepository(db *sql.DB) (*PostgresIdentityRepository, error) {
+	repo := &PostgresIdentityRepository{db: db}
+	if err := repo.ensureSchema(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r *PostgresIdentityRepository) ensureSchema() error {
+	const identitiesTableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = 'user_identities'
+`
+
+	var exists int
+	if err := r.db.QueryRow(identitiesTableQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user_identities table not found; please run database migrations: %w", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindIdentities returns the identities of a user, oldest first.
+func (r *PostgresIdentityRepository) FindIdentities(ctx context.Context, userID int64) ([]dao.Identity, error) {
+	query := fmt.Sprintf("SELECT %s FROM user_identities WHERE user_id = $1 ORDER BY created_at, id", identityColumns)
+
+	return r.queryIdentities(ctx, query, userID)
+}
+
+// FindIdentity locates an identity by provider account.
+func (r *PostgresIdentityRepository) FindIdentity(ctx context.Context, provider, providerID string) (*dao.Identity, error) {
+	query := fmt.Sprintf("SELECT %s FROM user_identities WHERE provider = $1 AND provider_id = $2", identityColumns)
+
+	identity, err := scanIdentity(r.db.QueryRowContext(ctx, query, provider, providerID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// FindIdentitiesByEmail returns identities reporting the given email.
+func (r *PostgresIdentityRepository) FindIdentitiesByEmail(ctx context.Context, email string) ([]dao.Identity, error) {
+	query := fmt.Sprintf("SELECT %s FROM user_identities WHERE LOWER(email) = LOWER($1) ORDER BY user_id, id", identityColumns)
+
+	return r.queryIdentities(ctx, query, email)
+}
+
+// LinkIdentity inserts a new identity for a user.
+func (r *PostgresIdentityRepository) LinkIdentity(ctx context.Context, identity dao.Identity) (*dao.Identity, error) {
+	query := fmt.Sprintf(`
+INSERT INTO user_identities (user_id, provider, provider_id, email, email_verified)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING %s
+`, identityColumns)
+
+	return scanIdentity(r.db.QueryRowContext(
+		ctx,
+		query,
+		identity.UserID,
+		identity.Provider,
+		identity.ProviderID,
+		identity.Email,
+		identity.EmailVerified,
+	))
+}
+
+// TouchIdentity updates the email details reported by the provider.
+func (r *PostgresIdentityRepository) TouchIdentity(ctx context.Context, provider, providerID, email string, emailVerified bool) error {
+	const query = `
+UPDATE user_identities
+SET email = $3, email_verified = $4
+WHERE provider = $1 AND provider_id = $2
+`
+
+	_, err := r.db.ExecContext(ctx, query, provider, providerID, email, emailVerified)
+	return err
+}
+
+// UnlinkIdentity deletes the user's identity for provider when another identity remains, and moves the
+// user's primary provider columns to the oldest remaining identity if needed.
+func (r *PostgresIdentityRepository) UnlinkIdentity(ctx context.Context, userID int64, provider string) (removed bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// Lock the user's identities so concurrent unlinks cannot remove the last one.
+	if _, err = tx.ExecContext(ctx, "SELECT id FROM user_identities WHERE user_id = $1 FOR UPDATE", userID); err != nil {
+		return false, err
+	}
+
+	const deleteQuery = `
+DELETE FROM user_identities
+WHERE user_id = $1 AND provider = $2
+  AND (SELECT COUNT(*) FROM user_identities WHERE user_id = $1) > 1
+`
+
+	result, err := tx.ExecContext(ctx, deleteQuery, userID, provider)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		err = tx.Rollback()
+		return false, err
+	}
+
+	const primaryQuery = `
+UPDATE users u
+SET provider = i.provider, provider_id = i.provider_id
+FROM (
+    SELECT provider, provider_id
+    FROM user_identities
+    WHERE user_id = $1
+    ORDER BY created_at, id
+    LIMIT 1
+) i
+WHERE u.id = $1 AND u.provider = $2
+`
+
+	if _, err = tx.ExecContext(ctx, primaryQuery, userID, provider); err != nil {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *PostgresIdentityRepository) queryIdentities(ctx context.Context, query string, args ...interface{}) ([]dao.Identity, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []dao.Identity
+	for rows.Next() {
+		identity, err := scanIdentity(rows)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, *identity)
+	}
+
+	return identities, rows.Err()
+}
+
+func scanIdentity(row rowScanner) (*dao.Identity, error) {
+	var identity dao.Identity
+	if err := row.Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.ProviderID,
+		&identity.Email,
+		&identity.EmailVerified,
+		&identity.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &identity, nil
+}
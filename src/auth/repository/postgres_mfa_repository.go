@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"gobackend/src/auth/dao"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+var _ authinterfaces.MFARepository = (*PostgresMFARepository)(nil)
+
+// PostgresMFARepository persists TOTP enrolments and recovery codes in Postgres.
+type PostgresMFARepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMFARepository constructs a PostgresMFARepository and ensures the expected schema exists.
+func NewPostgresMFARepository(db *sql.DB) (*PostgresMFARepository, error) {
+	repo := &PostgresMFARepository{db: db}
+	if err := repo.ensureSchema(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r *PostgresMFARepository) ensureSchema() error {
+	const tableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = $1
+`
+
+	for _, table := range []string{"user_mfa", "mfa_recovery_codes"} {
+		var exists int
+		if err := r.db.QueryRow(tableQuery, table).Scan(&exists); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%s table not found; please run database migrations: %w", table, err)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindMFA returns the enrolment of a user with the number of unused recovery codes.
+func (r *PostgresMFARepository) FindMFA(ctx context.Context, userID int64) (*dao.MFA, error) {
+	const query = `
+SELECT
+    m.user_id, m.secret, m.created_at, m.enabled_at, m.last_used_step, m.failed_attempts, m.locked_until,
+    (SELECT COUNT(*) FROM mfa_recovery_codes c WHERE c.user_id = m.user_id AND c.used_at IS NULL)
+FROM user_mfa m
+WHERE m.user_id = $1
+`
+
+	var (
+		mfa         dao.MFA
+		enabledAt   sql.NullTime
+		lockedUntil sql.NullTime
+	)
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&mfa.UserID,
+		&mfa.Secret,
+		&mfa.CreatedAt,
+		&enabledAt,
+		&mfa.LastUsedStep,
+		&mfa.FailedAttempts,
+		&lockedUntil,
+		&mfa.RecoveryCodesLeft,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if enabledAt.Valid {
+		mfa.EnabledAt = enabledAt.Time
+	}
+	if lockedUntil.Valid {
+		mfa.LockedUntil = lockedUntil.Time
+	}
+
+	return &mfa, nil
+}
+
+// SaveMFASecret starts or restarts an unconfirmed enrolment; it reports false when MFA is already enabled.
+func (r *PostgresMFARepository) SaveMFASecret(ctx context.Context, userID int64, secret string) (bool, error) {
+	const query = `
+INSERT INTO user_mfa (user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, created_at = NOW(), last_used_step = 0, failed_attempts = 0, locked_until = NULL
+WHERE user_mfa.enabled_at IS NULL
+`
+
+	return execAffected(ctx, r.db, query, userID, secret)
+}
+
+// EnableMFA confirms the enrolment at step and replaces the recovery codes in one transaction.
+func (r *PostgresMFARepository) EnableMFA(ctx context.Context, userID, step int64, now time.Time, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const enableQuery = `
+UPDATE user_mfa
+SET enabled_at = $2, last_used_step = $3, failed_attempts = 0, locked_until = NULL
+WHERE user_id = $1 AND enabled_at IS NULL
+`
+	if _, err := tx.ExecContext(ctx, enableQuery, userID, now, step); err != nil {
+		return fmt.Errorf("enable mfa: %w", err)
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UseTOTPStep records a successful code; it reports false when the step was already used.
+func (r *PostgresMFARepository) UseTOTPStep(ctx context.Context, userID, step int64) (bool, error) {
+	const query = `
+UPDATE user_mfa
+SET last_used_step = $2, failed_attempts = 0, locked_until = NULL
+WHERE user_id = $1 AND last_used_step < $2
+`
+
+	return execAffected(ctx, r.db, query, userID, step)
+}
+
+// UseRecoveryCode marks an unused recovery code as used and reports whether one matched.
+func (r *PostgresMFARepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string, now time.Time) (bool, error) {
+	const query = `
+UPDATE mfa_recovery_codes
+SET used_at = $3
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+`
+
+	used, err := execAffected(ctx, r.db, query, userID, codeHash, now)
+	if err != nil || !used {
+		return used, err
+	}
+
+	if _, err := r.db.ExecContext(ctx, "UPDATE user_mfa SET failed_attempts = 0, locked_until = NULL WHERE user_id = $1", userID); err != nil {
+		return false, fmt.Errorf("reset mfa failures: %w", err)
+	}
+
+	return true, nil
+}
+
+// RecordMFAFailure counts a failed attempt and locks the factor until lockedUntil once maxAttempts is reached.
+func (r *PostgresMFARepository) RecordMFAFailure(ctx context.Context, userID int64, maxAttempts int, lockedUntil time.Time) error {
+	const query = `
+UPDATE user_mfa
+SET
+    failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+    locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END
+WHERE user_id = $1
+`
+
+	_, err := r.db.ExecContext(ctx, query, userID, maxAttempts, lockedUntil)
+	return err
+}
+
+// ReplaceRecoveryCodes swaps every recovery code of the user for the given ones.
+func (r *PostgresMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteMFA removes the enrolment and recovery codes of the user.
+func (r *PostgresMFARepository) DeleteMFA(ctx context.Context, userID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("delete recovery codes: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_mfa WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("delete mfa: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID int64, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("delete recovery codes: %w", err)
+	}
+
+	for _, codeHash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)", userID, codeHash); err != nil {
+			return fmt.Errorf("insert recovery code: %w", err)
+		}
+	}
+
+	return nil
+}
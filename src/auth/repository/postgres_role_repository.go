@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+var _ authinterfaces.RoleRepository = (*PostgresRoleRepository)(nil)
+
+// PostgresRoleRepository persists user roles in Postgres.
+type PostgresRoleRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRoleRepository constructs a PostgresRoleRepository and ensures the expected schema exists.
+func NewPostgresRoleRepository(db *sql.DB) (*PostgresRoleRepository, error) {
+	repo := &PostgresRoleRepository{db: db}
+	if err := repo.ensureSchema(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r *PostgresRoleRepository) ensureSchema() error {
+	const rolesTableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = 'user_roles'
+`
+
+	var exists int
+	if err := r.db.QueryRow(rolesTableQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user_roles table not found; please run database migrations: %w", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindRoles returns the roles held by a user, sorted by name.
+func (r *PostgresRoleRepository) FindRoles(ctx context.Context, userID int64) ([]string, error) {
+	const query = `
+SELECT role
+FROM user_roles
+WHERE user_id = $1
+ORDER BY role
+`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+// GrantRole assigns a role to a user. Granting a role the user already holds is a no-op.
+func (r *PostgresRoleRepository) GrantRole(ctx context.Context, userID int64, role string, grantedBy *int64) (bool, error) {
+	const query = `
+INSERT INTO user_roles (user_id, role, granted_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, role) DO NOTHING
+`
+
+	result, err := r.db.ExecContext(ctx, query, userID, role, grantedBy)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// RevokeRole removes a role from a user.
+func (r *PostgresRoleRepository) RevokeRole(ctx context.Context, userID int64, role string) (bool, error) {
+	const query = `
+DELETE FROM user_roles
+WHERE user_id = $1 AND role = $2
+`
+
+	result, err := r.db.ExecContext(ctx, query, userID, role)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
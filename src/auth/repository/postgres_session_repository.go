@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"gobackend/shared/dbtx"
+	"gobackend/src/auth/dao"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+var _ authinterfaces.SessionRepository = (*PostgresSessionRepository)(nil)
+
+const refreshTokenColumns = `id, user_id, token_hash, family_id, COALESCE(parent_id, 0), created_at, expires_at, used_at, revoked_at`
+
+// PostgresSessionRepository persists refresh tokens and the access token revocation list in Postgres.
+type PostgresSessionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSessionRepository constructs a PostgresSessionRepository and ensures the expected schema exists.
+func NewPostgresSessionRepository(db *sql.DB) (*PostgresSessionRepository, error) {
+	repo := &PostgresSessionRepository{db: db}
+	if err := repo.ensureSchema(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r *PostgresSessionRepository) ensureSchema() error {
+	const tableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = $1
+`
+
+	for _, table := range []string{"refresh_tokens", "revoked_tokens", "user_token_cutoffs", "login_codes"} {
+		var exists int
+		if err := r.db.QueryRow(tableQuery, table).Scan(&exists); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%s table not found; please run database migrations: %w", table, err)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateRefreshToken inserts a new refresh token.
+func (r *PostgresSessionRepository) CreateRefreshToken(ctx context.Context, token dao.RefreshToken) (*dao.RefreshToken, error) {
+	query := fmt.Sprintf(`
+INSERT INTO refresh_tokens (user_id, token_hash, family_id, parent_id, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING %s
+`, refreshTokenColumns)
+
+	return scanRefreshToken(r.db.QueryRowContext(
+		ctx,
+		query,
+		token.UserID,
+		token.TokenHash,
+		token.FamilyID,
+		nullableID(token.ParentID),
+		token.ExpiresAt,
+	))
+}
+
+// FindRefreshTokenByHash locates a refresh token by the hash of its value.
+func (r *PostgresSessionRepository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*dao.RefreshToken, error) {
+	query := fmt.Sprintf("SELECT %s FROM refresh_tokens WHERE token_hash = $1", refreshTokenColumns)
+
+	token, err := scanRefreshToken(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// UseRefreshToken marks a refresh token as used if it is still valid at now.
+func (r *PostgresSessionRepository) UseRefreshToken(ctx context.Context, tokenID int64, now time.Time) (bool, error) {
+	const query = `
+UPDATE refresh_tokens
+SET used_at = $2
+WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL AND expires_at > $2
+`
+
+	return execAffected(ctx, r.db, query, tokenID, now)
+}
+
+// RevokeRefreshFamily revokes every token descending from the same login.
+func (r *PostgresSessionRepository) RevokeRefreshFamily(ctx context.Context, familyID string, now time.Time) error {
+	const query = `
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE family_id = $1 AND revoked_at IS NULL
+`
+
+	_, err := r.db.ExecContext(ctx, query, familyID, now)
+	return err
+}
+
+// RevokeUserRefreshTokens revokes every refresh token of a user, inside the transaction carried by ctx when there
+// is one.
+func (r *PostgresSessionRepository) RevokeUserRefreshTokens(ctx context.Context, userID int64, now time.Time) error {
+	const query = `
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+	_, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, query, userID, now)
+	return err
+}
+
+// RevokeAccessToken records a revoked access token ID.
+func (r *PostgresSessionRepository) RevokeAccessToken(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) error {
+	const query = `
+INSERT INTO revoked_tokens (jti, user_id, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (jti) DO NOTHING
+`
+
+	_, err := r.db.ExecContext(ctx, query, tokenID, userID, expiresAt)
+	return err
+}
+
+// RevokeUserAccessTokens moves the user's token cutoff forward to before, inside the transaction carried by ctx
+// when there is one.
+func (r *PostgresSessionRepository) RevokeUserAccessTokens(ctx context.Context, userID int64, before time.Time) error {
+	const query = `
+INSERT INTO user_token_cutoffs (user_id, not_before)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET not_before = GREATEST(user_token_cutoffs.not_before, EXCLUDED.not_before)
+`
+
+	_, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, query, userID, before)
+	return err
+}
+
+// IsAccessTokenRevoked reports whether the token ID is on the revocation list, was issued before the user's cutoff
+// or belongs to a deactivated or deleted user.
+func (r *PostgresSessionRepository) IsAccessTokenRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error) {
+	const query = `
+SELECT
+    EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)
+    OR EXISTS (SELECT 1 FROM user_token_cutoffs WHERE user_id = $2 AND not_before >= $3)
+    OR NOT EXISTS (SELECT 1 FROM users WHERE id = $2 AND deactivated_at IS NULL)
+`
+
+	var revoked bool
+	if err := r.db.QueryRowContext(ctx, query, tokenID, userID, issuedAt).Scan(&revoked); err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// expiredRowPurges clear rows that can no longer be used: login codes, refresh tokens and revocation list
+// entries for access tokens that have expired anyway.
+var expiredRowPurges = []struct {
+	name  string
+	query string
+}{
+	{name: "login codes", query: "DELETE FROM login_codes WHERE expires_at <= NOW()"},
+	{name: "refresh tokens", query: "DELETE FROM refresh_tokens WHERE expires_at <= NOW()"},
+	{name: "revoked tokens", query: "DELETE FROM revoked_tokens WHERE expires_at <= NOW()"},
+}
+
+// CreateLoginCode stores the hash of a one-time login code and clears expired login codes, refresh tokens and
+// revoked access tokens.
+func (r *PostgresSessionRepository) CreateLoginCode(ctx context.Context, codeHash string, userID int64, expiresAt time.Time) error {
+	for _, purge := range expiredRowPurges {
+		if _, err := r.db.ExecContext(ctx, purge.query); err != nil {
+			return fmt.Errorf("delete expired %s: %w", purge.name, err)
+		}
+	}
+
+	const query = `
+INSERT INTO login_codes (code_hash, user_id, expires_at)
+VALUES ($1, $2, $3)
+`
+
+	_, err := r.db.ExecContext(ctx, query, codeHash, userID, expiresAt)
+	return err
+}
+
+// ConsumeLoginCode deletes an unexpired login code and returns its user, or zero when there is no such code.
+func (r *PostgresSessionRepository) ConsumeLoginCode(ctx context.Context, codeHash string, now time.Time) (int64, error) {
+	const query = `
+DELETE FROM login_codes
+WHERE code_hash = $1
+RETURNING user_id, expires_at
+`
+
+	var (
+		userID    int64
+		expiresAt time.Time
+	)
+	if err := r.db.QueryRowContext(ctx, query, codeHash).Scan(&userID, &expiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	if !expiresAt.After(now) {
+		return 0, nil
+	}
+
+	return userID, nil
+}
+
+func scanRefreshToken(row rowScanner) (*dao.RefreshToken, error) {
+	var (
+		token     dao.RefreshToken
+		usedAt    sql.NullTime
+		revokedAt sql.NullTime
+	)
+
+	if err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.FamilyID,
+		&token.ParentID,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&usedAt,
+		&revokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = usedAt.Time
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = revokedAt.Time
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"gobackend/src/auth/dao"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+var _ authinterfaces.SigningKeyRepository = (*PostgresSigningKeyRepository)(nil)
+
+// PostgresSigningKeyRepository persists the access token keyring in Postgres.
+type PostgresSigningKeyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSigningKeyRepository constructs a PostgresSigningKeyRepository and ensures the expected schema exists.
+func NewPostgresSigningKeyRepository(db *sql.DB) (*PostgresSigningKeyRepository, error) {
+	repo := &PostgresSigningKeyRepository{db: db}
+	if err := repo.ensureSchema(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r *PostgresSigningKeyRepository) ensureSchema() error {
+	const tableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = 'signing_keys'
+`
+
+	var exists int
+	if err := r.db.QueryRow(tableQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("signing_keys table not found; please run database migrations: %w", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindSigningKeys returns the active key and the keys retired after retiredAfter, newest first.
+func (r *PostgresSigningKeyRepository) FindSigningKeys(ctx context.Context, retiredAfter time.Time) ([]dao.SigningKey, error) {
+	const query = `
+SELECT kid, algorithm, private_key, created_at, retired_at
+FROM signing_keys
+WHERE retired_at IS NULL OR retired_at > $1
+ORDER BY created_at DESC
+`
+
+	rows, err := r.db.QueryContext(ctx, query, retiredAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []dao.SigningKey
+	for rows.Next() {
+		var (
+			key       dao.SigningKey
+			retiredAt sql.NullTime
+		)
+		if err := rows.Scan(&key.KeyID, &key.Algorithm, &key.PrivateKey, &key.CreatedAt, &retiredAt); err != nil {
+			return nil, err
+		}
+		if retiredAt.Valid {
+			key.RetiredAt = retiredAt.Time
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RotateSigningKey retires the active key, if any, and stores key as the new active key in one transaction.
+func (r *PostgresSigningKeyRepository) RotateSigningKey(ctx context.Context, key dao.SigningKey, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "UPDATE signing_keys SET retired_at = $1 WHERE retired_at IS NULL", now); err != nil {
+		return fmt.Errorf("retire active signing key: %w", err)
+	}
+
+	const insertQuery = `
+INSERT INTO signing_keys (kid, algorithm, private_key, created_at)
+VALUES ($1, $2, $3, $4)
+`
+	if _, err := tx.ExecContext(ctx, insertQuery, key.KeyID, key.Algorithm, key.PrivateKey, now); err != nil {
+		return fmt.Errorf("insert signing key: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// DeleteRetiredSigningKeys removes keys retired before the given time and reports how many were removed.
+func (r *PostgresSigningKeyRepository) DeleteRetiredSigningKeys(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM signing_keys WHERE retired_at IS NOT NULL AND retired_at <= $1", before)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/auth/dao"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+var _ authinterfaces.SignupPolicyRepository = (*PostgresSignupPolicyRepository)(nil)
+
+const invitationColumns = `id, code_hash, email, COALESCE(created_by, 0), created_at, expires_at, COALESCE(used_by, 0), used_at`
+
+// PostgresSignupPolicyRepository persists the sign-up allowlist and invitations in Postgres.
+type PostgresSignupPolicyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSignupPolicyRepository constructs a PostgresSignupPolicyRepository and ensures the expected schema exists.
+func NewPostgresSignupPolicyRepository(db *sql.DB) (*PostgresSignupPolicyRepository, error) {
+	repo := &PostgresSignupPolicyRepository{db: db}
+	if err := repo.ensureSchema(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r *PostgresSignupPolicyRepository) ensureSchema() error {
+	const tableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = $1
+`
+
+	for _, table := range []string{"signup_rules", "signup_invitations"} {
+		var exists int
+		if err := r.db.QueryRow(tableQuery, table).Scan(&exists); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%s table not found; please run database migrations: %w", table, err)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindRules returns every allowlist rule ordered by kind and value.
+func (r *PostgresSignupPolicyRepository) FindRules(ctx context.Context) ([]dao.SignupRule, error) {
+	const query = `
+SELECT id, kind, value, COALESCE(created_by, 0), created_at
+FROM signup_rules
+ORDER BY kind, value
+`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []dao.SignupRule
+	for rows.Next() {
+		var rule dao.SignupRule
+		if err := rows.Scan(&rule.ID, &rule.Kind, &rule.Value, &rule.CreatedBy, &rule.CreatedAt); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// RuleExists reports whether the given rule is allowlisted.
+func (r *PostgresSignupPolicyRepository) RuleExists(ctx context.Context, kind, value string) (bool, error) {
+	const query = `
+SELECT EXISTS (SELECT 1 FROM signup_rules WHERE kind = $1 AND value = $2)
+`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, kind, value).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// AddRule inserts an allowlist rule. Adding an existing rule is a no-op.
+func (r *PostgresSignupPolicyRepository) AddRule(ctx context.Context, rule dao.SignupRule) (bool, error) {
+	const query = `
+INSERT INTO signup_rules (kind, value, created_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (kind, value) DO NOTHING
+`
+
+	return execAffected(ctx, r.db, query, rule.Kind, rule.Value, nullableID(rule.CreatedBy))
+}
+
+// RemoveRule deletes an allowlist rule.
+func (r *PostgresSignupPolicyRepository) RemoveRule(ctx context.Context, kind, value string) (bool, error) {
+	const query = `
+DELETE FROM signup_rules
+WHERE kind = $1 AND value = $2
+`
+
+	return execAffected(ctx, r.db, query, kind, value)
+}
+
+// FindInvitations returns invitations, newest first, with the total count.
+func (r *PostgresSignupPolicyRepository) FindInvitations(ctx context.Context, params pagination.Params) ([]dao.Invitation, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM signup_invitations").Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+SELECT %s
+FROM signup_invitations
+ORDER BY created_at DESC, id DESC
+LIMIT $1 OFFSET $2
+`, invitationColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, params.Limit(), params.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var invitations []dao.Invitation
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		invitations = append(invitations, *invitation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return invitations, total, nil
+}
+
+// FindInvitationByCodeHash locates an invitation by the hash of its code.
+func (r *PostgresSignupPolicyRepository) FindInvitationByCodeHash(ctx context.Context, codeHash string) (*dao.Invitation, error) {
+	query := fmt.Sprintf("SELECT %s FROM signup_invitations WHERE code_hash = $1", invitationColumns)
+
+	invitation, err := scanInvitation(r.db.QueryRowContext(ctx, query, codeHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// CreateInvitation inserts a new invitation.
+func (r *PostgresSignupPolicyRepository) CreateInvitation(ctx context.Context, invitation dao.Invitation) (*dao.Invitation, error) {
+	query := fmt.Sprintf(`
+INSERT INTO signup_invitations (code_hash, email, created_by, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING %s
+`, invitationColumns)
+
+	return scanInvitation(r.db.QueryRowContext(
+		ctx,
+		query,
+		invitation.CodeHash,
+		invitation.Email,
+		nullableID(invitation.CreatedBy),
+		invitation.ExpiresAt,
+	))
+}
+
+// ConsumeInvitation marks an invitation as used if it is still valid at now.
+func (r *PostgresSignupPolicyRepository) ConsumeInvitation(ctx context.Context, invitationID int64, now time.Time) (bool, error) {
+	const query = `
+UPDATE signup_invitations
+SET used_at = $2
+WHERE id = $1 AND used_at IS NULL AND expires_at > $2
+`
+
+	return execAffected(ctx, r.db, query, invitationID, now)
+}
+
+// ReleaseInvitation clears the usage of an invitation that was not linked to a user.
+func (r *PostgresSignupPolicyRepository) ReleaseInvitation(ctx context.Context, invitationID int64) error {
+	const query = `
+UPDATE signup_invitations
+SET used_at = NULL
+WHERE id = $1 AND used_by IS NULL
+`
+
+	_, err := r.db.ExecContext(ctx, query, invitationID)
+	return err
+}
+
+// AttachInvitationUser records which user consumed the invitation.
+func (r *PostgresSignupPolicyRepository) AttachInvitationUser(ctx context.Context, invitationID, userID int64) error {
+	const query = `
+UPDATE signup_invitations
+SET used_by = $2
+WHERE id = $1
+`
+
+	_, err := r.db.ExecContext(ctx, query, invitationID, userID)
+	return err
+}
+
+// DeleteInvitation removes an invitation that has not been used.
+func (r *PostgresSignupPolicyRepository) DeleteInvitation(ctx context.Context, invitationID int64) (bool, error) {
+	const query = `
+DELETE FROM signup_invitations
+WHERE id = $1 AND used_at IS NULL
+`
+
+	return execAffected(ctx, r.db, query, invitationID)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanInvitation(row rowScanner) (*dao.Invitation, error) {
+	var (
+		invitation dao.Invitation
+		usedAt     sql.NullTime
+	)
+
+	if err := row.Scan(
+		&invitation.ID,
+		&invitation.CodeHash,
+		&invitation.Email,
+		&invitation.CreatedBy,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+		&invitation.UsedBy,
+		&usedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		invitation.UsedAt = usedAt.Time
+	}
+
+	return &invitation, nil
+}
+
+func execAffected(ctx context.Context, db *sql.DB, query string, args ...interface{}) (bool, error) {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func nullableID(id int64) interface{} {
+	if id <= 0 {
+		return nil
+	}
+	return id
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"gobackend/shared/dbtx"
 	"gobackend/src/auth/dao"
 	authinterfaces "gobackend/src/auth/interfaces"
 )
@@ -70,20 +71,34 @@ WHERE schemaname = 'public' AND indexname = 'users_provider_provider_id_idx'
 	return nil
 }
 
-// FindByProvider locates a user by provider details.
+// FindByProvider locates a user by any of their linked provider identities.
 func (r *PostgresUserRepository) FindByProvider(ctx context.Context, provider, providerID string) (*dao.User, error) {
 	const query = `
-SELECT id, email, name, provider, provider_id, picture_url, created_at, last_login_at
+SELECT u.id, u.email, u.name, u.provider, u.provider_id, u.picture_url, u.created_at, u.last_login_at, u.deactivated_at
+FROM user_identities i
+JOIN users u ON u.id = i.user_id
+WHERE i.provider = $1 AND i.provider_id = $2
+`
+
+	return scanUser(r.db.QueryRowContext(ctx, query, provider, providerID))
+}
+
+// FindByID locates a user by primary key.
+func (r *PostgresUserRepository) FindByID(ctx context.Context, userID int64) (*dao.User, error) {
+	const query = `
+SELECT id, email, name, provider, provider_id, picture_url, created_at, last_login_at, deactivated_at
 FROM users
-WHERE provider = $1 AND provider_id = $2
-LIMIT 1
+WHERE id = $1
 `
 
-	row := r.db.QueryRowContext(ctx, query, provider, providerID)
+	return scanUser(r.db.QueryRowContext(ctx, query, userID))
+}
 
+func scanUser(row *sql.Row) (*dao.User, error) {
 	var (
-		user      dao.User
-		lastLogin sql.NullTime
+		user        dao.User
+		lastLogin   sql.NullTime
+		deactivated sql.NullTime
 	)
 
 	if err := row.Scan(
@@ -95,6 +110,7 @@ LIMIT 1
 		&user.PictureURL,
 		&user.CreatedAt,
 		&lastLogin,
+		&deactivated,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -105,16 +121,25 @@ LIMIT 1
 	if lastLogin.Valid {
 		user.LastLoginAt = lastLogin.Time
 	}
+	if deactivated.Valid {
+		user.DeactivatedAt = deactivated.Time
+	}
 
 	return &user, nil
 }
 
-// Create inserts a new user record.
+// Create inserts a new user record together with its first provider identity. It joins the transaction
+// carried by ctx, if any, so callers can store related rows atomically.
 func (r *PostgresUserRepository) Create(ctx context.Context, user dao.User) (*dao.User, error) {
-	const query = `
+	const userQuery = `
 INSERT INTO users (email, name, provider, provider_id, picture_url, created_at, last_login_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING id, email, name, provider, provider_id, picture_url, created_at, last_login_at
+`
+
+	const identityQuery = `
+INSERT INTO user_identities (user_id, provider, provider_id, email, created_at)
+VALUES ($1, $2, $3, $4, $5)
 `
 
 	now := r.nowProvider()
@@ -123,26 +148,35 @@ RETURNING id, email, name, provider, provider_id, picture_url, created_at, last_
 	var result dao.User
 	var lastLoginTime sql.NullTime
 
-	err := r.db.QueryRowContext(
-		ctx,
-		query,
-		user.Email,
-		user.Name,
-		user.Provider,
-		user.ProviderID,
-		user.PictureURL,
-		now,
-		lastLogin,
-	).Scan(
-		&result.ID,
-		&result.Email,
-		&result.Name,
-		&result.Provider,
-		&result.ProviderID,
-		&result.PictureURL,
-		&result.CreatedAt,
-		&lastLoginTime,
-	)
+	err := dbtx.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		tx := dbtx.Conn(ctx, r.db)
+
+		if err := tx.QueryRowContext(
+			ctx,
+			userQuery,
+			user.Email,
+			user.Name,
+			user.Provider,
+			user.ProviderID,
+			user.PictureURL,
+			now,
+			lastLogin,
+		).Scan(
+			&result.ID,
+			&result.Email,
+			&result.Name,
+			&result.Provider,
+			&result.ProviderID,
+			&result.PictureURL,
+			&result.CreatedAt,
+			&lastLoginTime,
+		); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, identityQuery, result.ID, result.Provider, result.ProviderID, result.Email, now)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +190,8 @@ RETURNING id, email, name, provider, provider_id, picture_url, created_at, last_
 	return &result, nil
 }
 
-// UpdateLoginTimestamp refreshes the user's last_login_at column.
+// UpdateLoginTimestamp refreshes the user's last_login_at column, inside the transaction carried by ctx when
+// there is one.
 func (r *PostgresUserRepository) UpdateLoginTimestamp(ctx context.Context, userID int64) error {
 	const query = `
 UPDATE users
@@ -164,6 +199,6 @@ SET last_login_at = $1
 WHERE id = $2
 `
 
-	_, err := r.db.ExecContext(ctx, query, r.nowProvider(), userID)
+	_, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, query, r.nowProvider(), userID)
 	return err
 }
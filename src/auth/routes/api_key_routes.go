@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"gobackend/src/auth/delivery"
+)
+
+// RegisterAPIKeys attaches the personal API key endpoints of the current user to the provided router.
+func RegisterAPIKeys(router gin.IRoutes, handler *delivery.APIKeyHandler) {
+	router.GET("/api/me/api-keys", handler.ListAPIKeys)
+	router.POST("/api/me/api-keys", handler.CreateAPIKey)
+	router.PATCH("/api/me/api-keys/:id", handler.UpdateAPIKey)
+	router.DELETE("/api/me/api-keys/:id", handler.RevokeAPIKey)
+}
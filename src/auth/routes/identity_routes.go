@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"gobackend/src/auth/delivery"
+)
+
+// RegisterIdentities attaches the linked identity endpoints of the current user to the provided router.
+func RegisterIdentities(router gin.IRoutes, handler *delivery.IdentityHandler) {
+	router.GET("/api/me/identities", handler.ListIdentities)
+	router.POST("/api/me/identities/:provider", handler.StartLink)
+	router.DELETE("/api/me/identities/:provider", handler.Unlink)
+}
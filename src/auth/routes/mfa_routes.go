@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"gobackend/src/auth/delivery"
+)
+
+// RegisterMFA attaches the second factor endpoints of the current user to the provided router.
+func RegisterMFA(router gin.IRoutes, handler *delivery.MFAHandler) {
+	router.GET("/api/me/mfa", handler.Status)
+	router.DELETE("/api/me/mfa", handler.Disable)
+	router.POST("/api/me/mfa/totp", handler.StartTOTP)
+	router.POST("/api/me/mfa/totp/verify", handler.ConfirmTOTP)
+	router.POST("/api/me/mfa/recovery-codes", handler.RegenerateRecoveryCodes)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"gobackend/src/auth/delivery"
+)
+
+// RegisterRoles attaches the role administration endpoints to the provided router.
+func RegisterRoles(router gin.IRoutes, handler *delivery.RoleHandler) {
+	router.GET("/api/users/:reference/roles", handler.ListRoles)
+	router.POST("/api/users/:reference/roles", handler.GrantRole)
+	router.DELETE("/api/users/:reference/roles/:role", handler.RevokeRole)
+}
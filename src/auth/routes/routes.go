@@ -1,15 +1,19 @@
 package routes
 
 import (
-    "github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin"
 
-    "gobackend/src/auth/delivery"
+	"gobackend/src/auth/delivery"
 )
 
 // Register attaches the auth endpoints to the provided router.
 func Register(router gin.IRoutes, handler *delivery.Handler) {
-    router.GET("/auth/google/login", handler.GoogleLogin)
-    router.GET("/auth/google/callback", handler.GoogleCallback)
-    router.POST("/auth/logout", handler.Logout)
+	router.GET("/.well-known/jwks.json", handler.JWKS)
+	router.GET("/auth/providers", handler.Providers)
+	router.GET("/auth/:provider/login", handler.Login)
+	router.GET("/auth/:provider/callback", handler.Callback)
+	router.POST("/auth/token", handler.Token)
+	router.POST("/auth/mfa/verify", handler.MFAVerify)
+	router.POST("/auth/refresh", handler.Refresh)
+	router.POST("/auth/logout", handler.Logout)
 }
-
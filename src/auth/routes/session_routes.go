@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"gobackend/src/auth/delivery"
+)
+
+// RegisterSessions attaches the session administration endpoints to the provided router.
+func RegisterSessions(router gin.IRoutes, handler *delivery.SessionHandler) {
+	router.POST("/api/users/:reference/sessions/revoke", handler.SignOutEverywhere)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"gobackend/src/auth/delivery"
+)
+
+// RegisterSignupPolicy attaches the sign-up allowlist and invitation endpoints to the provided router.
+func RegisterSignupPolicy(router gin.IRoutes, handler *delivery.SignupPolicyHandler) {
+	router.GET("/api/admin/signup-policy", handler.GetPolicy)
+	router.POST("/api/admin/signup-policy/emails", handler.AddEmail)
+	router.DELETE("/api/admin/signup-policy/emails/:value", handler.RemoveEmail)
+	router.POST("/api/admin/signup-policy/domains", handler.AddDomain)
+	router.DELETE("/api/admin/signup-policy/domains/:value", handler.RemoveDomain)
+	router.GET("/api/admin/invitations", handler.ListInvitations)
+	router.POST("/api/admin/invitations", handler.CreateInvitation)
+	router.DELETE("/api/admin/invitations/:id", handler.RevokeInvitation)
+}
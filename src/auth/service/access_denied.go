@@ -0,0 +1,39 @@
+package service
+
+// AccessDeniedError explains why an authenticated identity may not sign in.
+// It wraps ErrUnauthorized so callers can keep matching on that sentinel.
+type AccessDeniedError struct {
+	// Reason is a stable, machine-readable code passed to the failure redirect.
+	Reason string
+}
+
+func (e *AccessDeniedError) Error() string {
+	return ErrUnauthorized.Error() + ": " + e.Reason
+}
+
+// Unwrap returns ErrUnauthorized.
+func (e *AccessDeniedError) Unwrap() error {
+	return ErrUnauthorized
+}
+
+var (
+	// ErrNotAllowlisted indicates a new account matched no allowlisted email or domain and had no invitation.
+	ErrNotAllowlisted = &AccessDeniedError{Reason: "not_allowlisted"}
+	// ErrEmailUnverified indicates the provider did not verify the email address.
+	ErrEmailUnverified = &AccessDeniedError{Reason: "email_unverified"}
+	// ErrInvitationInvalid indicates the invitation code does not exist or belongs to another email.
+	ErrInvitationInvalid = &AccessDeniedError{Reason: "invitation_invalid"}
+	// ErrInvitationExpired indicates the invitation code is past its expiry.
+	ErrInvitationExpired = &AccessDeniedError{Reason: "invitation_expired"}
+	// ErrInvitationUsed indicates the invitation code was already redeemed.
+	ErrInvitationUsed = &AccessDeniedError{Reason: "invitation_used"}
+	// ErrLinkRequired indicates an account with the same email exists but cannot be merged automatically;
+	// the user must sign in with their original provider and link the new one.
+	ErrLinkRequired = &AccessDeniedError{Reason: "link_required"}
+	// ErrIdentityInUse indicates the provider account is already linked to another user.
+	ErrIdentityInUse = &AccessDeniedError{Reason: "identity_in_use"}
+	// ErrAccountDeactivated indicates an administrator deactivated the account.
+	ErrAccountDeactivated = &AccessDeniedError{Reason: "account_deactivated"}
+	// ErrProviderAlreadyLinked indicates the user already linked another account of the same provider.
+	ErrProviderAlreadyLinked = &AccessDeniedError{Reason: "provider_already_linked"}
+)
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/rbac"
+	logdto "gobackend/src/logs/dto"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+// APIKeyPrefix starts every personal API key so it can be told apart from an access token.
+const APIKeyPrefix = "gbk_"
+
+const (
+	apiKeyBytes         = 32
+	apiKeyVisibleLength = len(APIKeyPrefix) + 8
+	maxAPIKeyLabel      = 100
+	maxAPIKeysPerUser   = 25
+)
+
+var (
+	// ErrInvalidAPIKey indicates the presented API key is unknown or revoked.
+	ErrInvalidAPIKey = errors.New("invalid or revoked api key")
+	// ErrAPIKeyNotFound indicates the key does not exist, belongs to another user or was revoked.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrInvalidAPIKeyLabel indicates the label is empty or too long.
+	ErrInvalidAPIKeyLabel = fmt.Errorf("label is required and must be at most %d characters", maxAPIKeyLabel)
+	// ErrInvalidAPIKeyScope indicates a requested scope is unknown or not granted by the caller's roles.
+	ErrInvalidAPIKeyScope = errors.New("scope is unknown or not granted by your roles")
+	// ErrTooManyAPIKeys indicates the user already holds the maximum number of keys.
+	ErrTooManyAPIKeys = fmt.Errorf("at most %d api keys can be active", maxAPIKeysPerUser)
+)
+
+var _ authinterfaces.APIKeyService = (*APIKeyService)(nil)
+
+// APIKeyService issues personal API keys and authenticates requests made with them.
+type APIKeyService struct {
+	keys        authinterfaces.APIKeyRepository
+	users       authinterfaces.UserRepository
+	roles       authinterfaces.RoleRepository
+	logService  loginterfaces.Service
+	nowProvider func() time.Time
+}
+
+// NewAPIKeyService constructs an APIKeyService.
+func NewAPIKeyService(
+	keys authinterfaces.APIKeyRepository,
+	users authinterfaces.UserRepository,
+	roles authinterfaces.RoleRepository,
+	logService loginterfaces.Service,
+) *APIKeyService {
+	return &APIKeyService{keys: keys, users: users, roles: roles, logService: logService, nowProvider: time.Now}
+}
+
+// AuthenticateAPIKey resolves key to the claims of its owner. The owner's current roles apply, narrowed to the
+// key's scopes by the authorization middleware. Every use updates the key's last-used time and is logged.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*dto.Claims, error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	stored, err := s.keys.FindAPIKeyByHash(ctx, hashToken(key))
+	if err != nil {
+		return nil, fmt.Errorf("find api key: %w", err)
+	}
+	if stored == nil || !stored.RevokedAt.IsZero() {
+		return nil, ErrInvalidAPIKey
+	}
+
+	user, err := s.users.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if user == nil || !user.DeactivatedAt.IsZero() {
+		return nil, ErrInvalidAPIKey
+	}
+
+	roles, err := s.roles.FindRoles(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("find user roles: %w", err)
+	}
+
+	if err := s.keys.TouchAPIKey(ctx, stored.ID, s.nowProvider()); err != nil {
+		return nil, fmt.Errorf("touch api key: %w", err)
+	}
+
+	if err := s.audit(ctx, user.ID, logdto.ActionAPIKeyUsed, fmt.Sprintf("used api key %d (%s)", stored.ID, stored.Prefix), apiKeyMetadata(stored.ID, stored.Prefix)); err != nil {
+		return nil, err
+	}
+
+	return &dto.Claims{
+		UserID:   user.ID,
+		Email:    user.Email,
+		Provider: user.Provider,
+		Roles:    roles,
+		APIKeyID: stored.ID,
+		Scopes:   stored.Scopes,
+	}, nil
+}
+
+// ListAPIKeys returns the active keys of a user without their values.
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, userID int64) ([]dto.APIKey, error) {
+	keys, err := s.keys.FindAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find api keys: %w", err)
+	}
+
+	result := make([]dto.APIKey, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, toAPIKeyDTO(key, ""))
+	}
+
+	return result, nil
+}
+
+// CreateAPIKey issues a key for userID. The plain key is only returned here.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, userID int64, roles []string, req dto.APIKeyRequest) (*dto.APIKey, error) {
+	label, err := normalizeAPIKeyLabel(req.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]string, 0, len(req.Scopes))
+	seen := make(map[string]struct{}, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scope = strings.TrimSpace(scope)
+		if !rbac.Grants(roles, scope) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAPIKeyScope, scope)
+		}
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		scopes = append(scopes, scope)
+	}
+
+	existing, err := s.keys.FindAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find api keys: %w", err)
+	}
+	if len(existing) >= maxAPIKeysPerUser {
+		return nil, ErrTooManyAPIKeys
+	}
+
+	secret, err := generateRandomToken(apiKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("generate api key: %w", err)
+	}
+	plain := APIKeyPrefix + secret
+
+	created, err := s.keys.CreateAPIKey(ctx, dao.APIKey{
+		UserID:  userID,
+		Label:   label,
+		Prefix:  plain[:apiKeyVisibleLength],
+		KeyHash: hashToken(plain),
+		Scopes:  scopes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create api key: %w", err)
+	}
+
+	if err := s.audit(ctx, userID, logdto.ActionAPIKeyCreated, fmt.Sprintf("created api key %d (%s)", created.ID, created.Prefix), apiKeyMetadata(created.ID, created.Prefix)); err != nil {
+		return nil, err
+	}
+
+	result := toAPIKeyDTO(*created, plain)
+	return &result, nil
+}
+
+// UpdateAPIKey relabels a key of userID.
+func (s *APIKeyService) UpdateAPIKey(ctx context.Context, userID, keyID int64, req dto.APIKeyUpdateRequest) (*dto.APIKey, error) {
+	label, err := normalizeAPIKeyLabel(req.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.keys.UpdateAPIKeyLabel(ctx, userID, keyID, label)
+	if err != nil {
+		return nil, fmt.Errorf("update api key: %w", err)
+	}
+	if updated == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	result := toAPIKeyDTO(*updated, "")
+	return &result, nil
+}
+
+// RevokeAPIKey revokes a key of userID; it stops authenticating immediately.
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, userID, keyID int64) error {
+	revoked, err := s.keys.RevokeAPIKey(ctx, userID, keyID, s.nowProvider())
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+
+	return s.audit(ctx, userID, logdto.ActionAPIKeyRevoked, fmt.Sprintf("revoked api key %d", keyID), logdto.Metadata{"api_key_id": keyID})
+}
+
+func (s *APIKeyService) audit(ctx context.Context, userID int64, action logdto.Action, detail string, metadata logdto.Metadata) error {
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID:   userID,
+		Action:   action,
+		Detail:   detail,
+		Metadata: metadata,
+	}); err != nil {
+		return fmt.Errorf("record %s log: %w", action, err)
+	}
+
+	return nil
+}
+
+func normalizeAPIKeyLabel(label string) (string, error) {
+	label = strings.TrimSpace(label)
+	if label == "" || utf8.RuneCountInString(label) > maxAPIKeyLabel {
+		return "", ErrInvalidAPIKeyLabel
+	}
+
+	return label, nil
+}
+
+func toAPIKeyDTO(key dao.APIKey, plain string) dto.APIKey {
+	result := dto.APIKey{
+		ID:        key.ID,
+		Key:       plain,
+		Label:     key.Label,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+	}
+
+	if result.Scopes == nil {
+		result.Scopes = []string{}
+	}
+
+	if !key.LastUsedAt.IsZero() {
+		lastUsedAt := key.LastUsedAt
+		result.LastUsedAt = &lastUsedAt
+	}
+
+	return result
+}
+
+func apiKeyMetadata(keyID int64, prefix string) logdto.Metadata {
+	return logdto.Metadata{"api_key_id": keyID, "prefix": prefix}
+}
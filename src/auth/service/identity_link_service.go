@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/provider"
+	logdto "gobackend/src/logs/dto"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+var (
+	// ErrIdentityNotFound indicates the user has no identity for the provider.
+	ErrIdentityNotFound = errors.New("identity not linked")
+	// ErrLastIdentity indicates the identity is the user's only way to sign in.
+	ErrLastIdentity = errors.New("cannot unlink the only sign-in method")
+)
+
+var _ authinterfaces.IdentityLinkService = (*IdentityLinkService)(nil)
+
+// IdentityLinkService links additional provider identities to a signed-in user.
+type IdentityLinkService struct {
+	providers  *provider.Registry
+	identities authinterfaces.IdentityRepository
+	logService loginterfaces.Service
+}
+
+// NewIdentityLinkService constructs an IdentityLinkService.
+func NewIdentityLinkService(
+	providers *provider.Registry,
+	identities authinterfaces.IdentityRepository,
+	logService loginterfaces.Service,
+) (*IdentityLinkService, error) {
+	if providers == nil || identities == nil || logService == nil {
+		return nil, ErrInvalidConfig
+	}
+
+	return &IdentityLinkService{
+		providers:  providers,
+		identities: identities,
+		logService: logService,
+	}, nil
+}
+
+// ListIdentities returns the provider identities linked to the user.
+func (s *IdentityLinkService) ListIdentities(ctx context.Context, userID int64) ([]dto.LinkedIdentity, error) {
+	identities, err := s.identities.FindIdentities(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.LinkedIdentity, 0, len(identities))
+	for _, identity := range identities {
+		result = append(result, toLinkedIdentity(identity))
+	}
+
+	return result, nil
+}
+
+// StartLink generates the parameters of a link flow and returns the named provider's consent URL.
+// The caller must keep the parameters, bound to the signed-in user, for CompleteLink.
+func (s *IdentityLinkService) StartLink(ctx context.Context, providerName string) (*dto.AuthStart, error) {
+	identityProvider, ok := s.providers.Get(providerName)
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	params, err := newAuthParams(identityProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	loginURL, err := identityProvider.AuthCodeURL(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthStart{URL: loginURL, Params: params}, nil
+}
+
+// CompleteLink exchanges the callback code and links the identity to userID.
+func (s *IdentityLinkService) CompleteLink(ctx context.Context, userID int64, providerName string, req dto.CallbackRequest) (*dto.LinkedIdentity, error) {
+	identityProvider, ok := s.providers.Get(providerName)
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	exchangeCtx, cancel := context.WithTimeout(ctx, defaultHTTPTimeout)
+	defer cancel()
+
+	external, err := identityProvider.Exchange(exchangeCtx, req.Code, req.Params)
+	if err != nil {
+		if errors.Is(err, provider.ErrEmailUnverified) {
+			return nil, ErrEmailUnverified
+		}
+		return nil, fmt.Errorf("complete %s link: %w", providerName, err)
+	}
+
+	owned, err := s.identities.FindIdentity(ctx, external.Provider, external.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("find identity: %w", err)
+	}
+
+	if owned != nil {
+		if owned.UserID != userID {
+			return nil, ErrIdentityInUse
+		}
+
+		linked := toLinkedIdentity(*owned)
+		return &linked, nil
+	}
+
+	existing, err := s.identities.FindIdentities(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find identities: %w", err)
+	}
+	for _, identity := range existing {
+		if identity.Provider == external.Provider {
+			return nil, ErrProviderAlreadyLinked
+		}
+	}
+
+	created, err := s.identities.LinkIdentity(ctx, dao.Identity{
+		UserID:        userID,
+		Provider:      external.Provider,
+		ProviderID:    external.Subject,
+		Email:         external.Email,
+		EmailVerified: external.EmailVerified,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("link identity: %w", err)
+	}
+
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID:   userID,
+		Action:   logdto.ActionIdentityLinked,
+		Detail:   fmt.Sprintf("linked %s identity", external.Provider),
+		Metadata: logdto.Metadata{"provider": external.Provider},
+	}); err != nil {
+		return nil, fmt.Errorf("record identity link log: %w", err)
+	}
+
+	linked := toLinkedIdentity(*created)
+	return &linked, nil
+}
+
+// Unlink removes the user's identity for provider. The last identity cannot be removed.
+func (s *IdentityLinkService) Unlink(ctx context.Context, userID int64, providerName string) error {
+	identities, err := s.identities.FindIdentities(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("find identities: %w", err)
+	}
+
+	found := false
+	for _, identity := range identities {
+		if identity.Provider == providerName {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return ErrIdentityNotFound
+	}
+
+	removed, err := s.identities.UnlinkIdentity(ctx, userID, providerName)
+	if err != nil {
+		return fmt.Errorf("unlink identity: %w", err)
+	}
+
+	if !removed {
+		return ErrLastIdentity
+	}
+
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID:   userID,
+		Action:   logdto.ActionIdentityUnlinked,
+		Detail:   fmt.Sprintf("unlinked %s identity", providerName),
+		Metadata: logdto.Metadata{"provider": providerName},
+	}); err != nil {
+		return fmt.Errorf("record identity unlink log: %w", err)
+	}
+
+	return nil
+}
+
+func toLinkedIdentity(identity dao.Identity) dto.LinkedIdentity {
+	return dto.LinkedIdentity{
+		Provider:  identity.Provider,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt,
+	}
+}
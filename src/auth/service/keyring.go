@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"gobackend/shared/jwks"
+	"gobackend/shared/securecookie"
+	"gobackend/src/auth/dao"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+const (
+	// AlgorithmRS256 signs access tokens with RSA PKCS#1 v1.5 and SHA-256.
+	AlgorithmRS256 = "RS256"
+	// AlgorithmEdDSA signs access tokens with Ed25519.
+	AlgorithmEdDSA = "EdDSA"
+
+	rsaKeyBits = 2048
+	keyIDBytes = 12
+
+	// keyringReloadInterval bounds how long a rotation made by another process goes unnoticed.
+	keyringReloadInterval = 5 * time.Minute
+	// unknownKeyReloadInterval limits reloads triggered by tokens naming a key that is not loaded.
+	unknownKeyReloadInterval = 10 * time.Second
+)
+
+var (
+	// ErrUnsupportedAlgorithm indicates a signing algorithm other than RS256 or EdDSA was requested.
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm; use RS256 or EdDSA")
+	// ErrNoSigningKey indicates the keyring has no active key; run the rotate-signing-key command.
+	ErrNoSigningKey = errors.New("no active signing key")
+	// ErrUnknownSigningKey indicates a token names a key that is not in the keyring.
+	ErrUnknownSigningKey = errors.New("unknown signing key")
+)
+
+// KeyringConfig configures a Keyring.
+type KeyringConfig struct {
+	Repo authinterfaces.SigningKeyRepository
+	// Sealer encrypts private keys before they are stored.
+	Sealer *securecookie.Sealer
+	// Retention is how long a retired key keeps verifying tokens and stays published. It must cover the access token TTL.
+	Retention time.Duration
+}
+
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+}
+
+// Keyring holds the access token signing keys. The active key signs new tokens; retired keys only verify
+// tokens issued before the last rotation, selected by the kid header.
+type Keyring struct {
+	repo      authinterfaces.SigningKeyRepository
+	sealer    *securecookie.Sealer
+	retention time.Duration
+
+	mu       sync.Mutex
+	active   *signingKey
+	keys     map[string]*signingKey
+	ordered  []*signingKey
+	loadedAt time.Time
+}
+
+// NewKeyring constructs a Keyring. Keys are loaded on first use.
+func NewKeyring(cfg KeyringConfig) (*Keyring, error) {
+	if cfg.Repo == nil || cfg.Sealer == nil || cfg.Retention <= 0 {
+		return nil, ErrInvalidConfig
+	}
+
+	return &Keyring{repo: cfg.Repo, sealer: cfg.Sealer, retention: cfg.Retention}, nil
+}
+
+// EnsureActiveKey creates an active key with algorithm when the keyring is empty, so a fresh deployment can sign tokens.
+func (k *Keyring) EnsureActiveKey(ctx context.Context, algorithm string) error {
+	if _, err := k.signingKey(ctx); !errors.Is(err, ErrNoSigningKey) {
+		return err
+	}
+
+	_, err := k.Rotate(ctx, algorithm)
+	return err
+}
+
+// Rotate generates a new active key with algorithm and retires the previous one. It returns the new kid.
+func (k *Keyring) Rotate(ctx context.Context, algorithm string) (string, error) {
+	private, err := generatePrivateKey(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return "", fmt.Errorf("encode signing key: %w", err)
+	}
+
+	sealed, err := k.sealer.Seal(der)
+	if err != nil {
+		return "", fmt.Errorf("seal signing key: %w", err)
+	}
+
+	keyID, err := generateRandomToken(keyIDBytes)
+	if err != nil {
+		return "", fmt.Errorf("generate key id: %w", err)
+	}
+
+	if err := k.repo.RotateSigningKey(ctx, dao.SigningKey{
+		KeyID:      keyID,
+		Algorithm:  algorithm,
+		PrivateKey: sealed,
+	}, time.Now()); err != nil {
+		return "", fmt.Errorf("store signing key: %w", err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err := k.load(ctx); err != nil {
+		return "", err
+	}
+
+	return keyID, nil
+}
+
+// Prune deletes keys retired longer than the retention period ago and reports how many were removed.
+func (k *Keyring) Prune(ctx context.Context) (int64, error) {
+	removed, err := k.repo.DeleteRetiredSigningKeys(ctx, time.Now().Add(-k.retention))
+	if err != nil {
+		return 0, fmt.Errorf("delete retired signing keys: %w", err)
+	}
+
+	return removed, nil
+}
+
+// PublicKeys returns the public half of the active and retired keys as a JSON Web Key Set.
+func (k *Keyring) PublicKeys(ctx context.Context) (*jwks.Set, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.loadIfStale(ctx); err != nil {
+		return nil, err
+	}
+
+	set := &jwks.Set{Keys: make([]jwks.Key, 0, len(k.ordered))}
+	for _, key := range k.ordered {
+		jwk, err := jwks.NewKey(key.id, key.method.Alg(), key.private.Public())
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set, nil
+}
+
+func (k *Keyring) signingKey(ctx context.Context) (*signingKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.loadIfStale(ctx); err != nil {
+		return nil, err
+	}
+
+	if k.active == nil {
+		return nil, ErrNoSigningKey
+	}
+
+	return k.active, nil
+}
+
+// verificationKey returns the key named by a token's kid header, reloading once when it was rotated in elsewhere.
+func (k *Keyring) verificationKey(ctx context.Context, keyID string) (*signingKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.loadIfStale(ctx); err != nil {
+		return nil, err
+	}
+
+	key, ok := k.keys[keyID]
+	if !ok && time.Since(k.loadedAt) >= unknownKeyReloadInterval {
+		if err := k.load(ctx); err != nil {
+			return nil, err
+		}
+		key, ok = k.keys[keyID]
+	}
+
+	if !ok {
+		return nil, ErrUnknownSigningKey
+	}
+
+	return key, nil
+}
+
+func (k *Keyring) loadIfStale(ctx context.Context) error {
+	if k.keys != nil && time.Since(k.loadedAt) < keyringReloadInterval {
+		return nil
+	}
+
+	return k.load(ctx)
+}
+
+// load replaces the cached keys with the stored ones. Callers must hold k.mu.
+func (k *Keyring) load(ctx context.Context) error {
+	stored, err := k.repo.FindSigningKeys(ctx, time.Now().Add(-k.retention))
+	if err != nil {
+		return fmt.Errorf("find signing keys: %w", err)
+	}
+
+	var active *signingKey
+	keys := make(map[string]*signingKey, len(stored))
+	ordered := make([]*signingKey, 0, len(stored))
+	for _, record := range stored {
+		key, err := k.open(record)
+		if err != nil {
+			return err
+		}
+
+		if record.RetiredAt.IsZero() {
+			active = key
+		}
+		keys[key.id] = key
+		ordered = append(ordered, key)
+	}
+
+	k.active = active
+	k.keys = keys
+	k.ordered = ordered
+	k.loadedAt = time.Now()
+	return nil
+}
+
+func (k *Keyring) open(record dao.SigningKey) (*signingKey, error) {
+	var der []byte
+	if err := k.sealer.Open(record.PrivateKey, &der); err != nil {
+		return nil, fmt.Errorf("open signing key %s: %w", record.KeyID, err)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("decode signing key %s: %w", record.KeyID, err)
+	}
+
+	key := &signingKey{id: record.KeyID}
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private = jwt.SigningMethodRS256, private
+	case ed25519.PrivateKey:
+		key.method, key.private = jwt.SigningMethodEdDSA, private
+	default:
+		return nil, fmt.Errorf("signing key %s has unsupported type %T", record.KeyID, parsed)
+	}
+
+	if key.method.Alg() != record.Algorithm {
+		return nil, fmt.Errorf("signing key %s does not match algorithm %s", record.KeyID, record.Algorithm)
+	}
+
+	return key, nil
+}
+
+func generatePrivateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, fmt.Errorf("generate rsa key: %w", err)
+		}
+		return key, nil
+	case AlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate ed25519 key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
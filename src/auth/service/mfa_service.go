@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gobackend/shared/securecookie"
+	"gobackend/shared/totp"
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	logdto "gobackend/src/logs/dto"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+const (
+	// totpSkew accepts codes from the previous and next time step to tolerate clock drift.
+	totpSkew          = 1
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 5
+	maxMFAAttempts    = 5
+	mfaLockout        = 15 * time.Minute
+)
+
+var (
+	// ErrMFANotEnabled indicates the user has no confirmed second factor.
+	ErrMFANotEnabled = errors.New("multi-factor authentication is not enabled")
+	// ErrMFAAlreadyEnabled indicates enrolment was requested while a second factor is active.
+	ErrMFAAlreadyEnabled = errors.New("multi-factor authentication is already enabled")
+	// ErrMFAEnrolmentNotStarted indicates a code was confirmed before enrolment started.
+	ErrMFAEnrolmentNotStarted = errors.New("start totp enrolment first")
+	// ErrInvalidMFACode indicates the TOTP or recovery code is wrong or was already used.
+	ErrInvalidMFACode = errors.New("invalid verification code")
+	// ErrMFALocked indicates too many wrong codes were entered; the factor is locked for a while.
+	ErrMFALocked = errors.New("too many invalid codes; try again later")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+var _ authinterfaces.MFAService = (*MFAService)(nil)
+
+// MFAService manages TOTP enrolment and recovery codes and checks the second factor at login.
+// Successful enrolments and verifications as well as failed attempts are written to the activity log.
+type MFAService struct {
+	repo        authinterfaces.MFARepository
+	sealer      *securecookie.Sealer
+	logService  loginterfaces.Service
+	issuer      string
+	nowProvider func() time.Time
+}
+
+// NewMFAService constructs an MFAService. TOTP secrets are sealed with sealer; issuer names the account in
+// authenticator apps.
+func NewMFAService(
+	repo authinterfaces.MFARepository,
+	sealer *securecookie.Sealer,
+	logService loginterfaces.Service,
+	issuer string,
+) *MFAService {
+	return &MFAService{repo: repo, sealer: sealer, logService: logService, issuer: issuer, nowProvider: time.Now}
+}
+
+// IsEnabled reports whether the user confirmed a TOTP enrolment.
+func (s *MFAService) IsEnabled(ctx context.Context, userID int64) (bool, error) {
+	mfa, err := s.repo.FindMFA(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("find mfa: %w", err)
+	}
+
+	return mfa != nil && !mfa.EnabledAt.IsZero(), nil
+}
+
+// Status describes the second factor of the user.
+func (s *MFAService) Status(ctx context.Context, userID int64) (*dto.MFAStatus, error) {
+	mfa, err := s.repo.FindMFA(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find mfa: %w", err)
+	}
+
+	status := &dto.MFAStatus{}
+	if mfa != nil && !mfa.EnabledAt.IsZero() {
+		enabledAt := mfa.EnabledAt
+		status.Enabled = true
+		status.EnabledAt = &enabledAt
+		status.RecoveryCodesLeft = mfa.RecoveryCodesLeft
+	}
+
+	return status, nil
+}
+
+// StartEnrolment generates a TOTP secret for the user. It replaces an unconfirmed enrolment.
+func (s *MFAService) StartEnrolment(ctx context.Context, userID int64, account string) (*dto.MFAEnrolment, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := s.sealer.Seal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("seal totp secret: %w", err)
+	}
+
+	saved, err := s.repo.SaveMFASecret(ctx, userID, sealed)
+	if err != nil {
+		return nil, fmt.Errorf("save totp secret: %w", err)
+	}
+	if !saved {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	if err := s.audit(ctx, userID, logdto.ActionMFAEnrolmentStarted, "started totp enrolment"); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAEnrolment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, account, secret),
+	}, nil
+}
+
+// ConfirmEnrolment enables MFA once code matches the new secret and returns the recovery codes.
+func (s *MFAService) ConfirmEnrolment(ctx context.Context, userID int64, code string) (*dto.MFARecoveryCodes, error) {
+	mfa, err := s.repo.FindMFA(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find mfa: %w", err)
+	}
+	if mfa == nil {
+		return nil, ErrMFAEnrolmentNotStarted
+	}
+	if !mfa.EnabledAt.IsZero() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	now := s.nowProvider()
+	if now.Before(mfa.LockedUntil) {
+		return nil, ErrMFALocked
+	}
+
+	secret, err := s.openSecret(*mfa)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := totp.Validate(secret, code, now, totpSkew)
+	if !ok {
+		return nil, s.fail(ctx, userID, "invalid code while confirming totp enrolment")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.EnableMFA(ctx, userID, step, now, hashes); err != nil {
+		return nil, fmt.Errorf("enable mfa: %w", err)
+	}
+
+	if err := s.audit(ctx, userID, logdto.ActionMFAEnrolled, "enabled totp"); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// VerifyCode accepts a TOTP code or an unused recovery code of the user. Each TOTP code works once.
+func (s *MFAService) VerifyCode(ctx context.Context, userID int64, code string) error {
+	mfa, err := s.repo.FindMFA(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("find mfa: %w", err)
+	}
+	if mfa == nil || mfa.EnabledAt.IsZero() {
+		return ErrMFANotEnabled
+	}
+
+	now := s.nowProvider()
+	if now.Before(mfa.LockedUntil) {
+		return ErrMFALocked
+	}
+
+	secret, err := s.openSecret(*mfa)
+	if err != nil {
+		return err
+	}
+
+	if step, ok := totp.Validate(secret, code, now, totpSkew); ok {
+		used, err := s.repo.UseTOTPStep(ctx, userID, step)
+		if err != nil {
+			return fmt.Errorf("use totp step: %w", err)
+		}
+		if !used {
+			return s.fail(ctx, userID, "reused totp code")
+		}
+
+		return s.audit(ctx, userID, logdto.ActionMFAVerified, "verified totp code")
+	}
+
+	used, err := s.repo.UseRecoveryCode(ctx, userID, hashToken(normalizeRecoveryCode(code)), now)
+	if err != nil {
+		return fmt.Errorf("use recovery code: %w", err)
+	}
+	if used {
+		return s.audit(ctx, userID, logdto.ActionMFAVerified, "used a recovery code")
+	}
+
+	return s.fail(ctx, userID, "invalid totp or recovery code")
+}
+
+// RegenerateRecoveryCodes replaces every recovery code once code verifies.
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) (*dto.MFARecoveryCodes, error) {
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("replace recovery codes: %w", err)
+	}
+
+	if err := s.audit(ctx, userID, logdto.ActionMFARecoveryCodesRegenerated, "regenerated recovery codes"); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// Disable removes the second factor once code verifies.
+func (s *MFAService) Disable(ctx context.Context, userID int64, code string) error {
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteMFA(ctx, userID); err != nil {
+		return fmt.Errorf("delete mfa: %w", err)
+	}
+
+	return s.audit(ctx, userID, logdto.ActionMFADisabled, "disabled totp")
+}
+
+// fail counts a failed attempt, logs it and returns ErrInvalidMFACode.
+func (s *MFAService) fail(ctx context.Context, userID int64, detail string) error {
+	if err := s.repo.RecordMFAFailure(ctx, userID, maxMFAAttempts, s.nowProvider().Add(mfaLockout)); err != nil {
+		return fmt.Errorf("record mfa failure: %w", err)
+	}
+
+	if err := s.audit(ctx, userID, logdto.ActionMFAFailed, detail); err != nil {
+		return err
+	}
+
+	return ErrInvalidMFACode
+}
+
+func (s *MFAService) openSecret(mfa dao.MFA) (string, error) {
+	var secret string
+	if err := s.sealer.Open(mfa.Secret, &secret); err != nil {
+		return "", fmt.Errorf("open totp secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+func (s *MFAService) audit(ctx context.Context, userID int64, action logdto.Action, detail string) error {
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID: userID,
+		Action: action,
+		Detail: detail,
+	}); err != nil {
+		return fmt.Errorf("record %s log: %w", action, err)
+	}
+
+	return nil
+}
+
+// generateRecoveryCodes returns codes formatted as "abcd-efgh" and their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for len(codes) < recoveryCodeCount {
+		raw, err := randomBytes(recoveryCodeBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generate recovery code: %w", err)
+		}
+
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(raw))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, hashToken(code))
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,573 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"gobackend/shared/dbtx"
+	"gobackend/shared/jwks"
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/provider"
+	"gobackend/src/auth/rbac"
+	logdto "gobackend/src/logs/dto"
+	loginterfaces "gobackend/src/logs/interfaces"
+	outboxdto "gobackend/src/outbox/dto"
+	outboxinterfaces "gobackend/src/outbox/interfaces"
+)
+
+const (
+	defaultHTTPTimeout     = 10 * time.Second
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var (
+	// ErrInvalidConfig signals that the service was initialised with an incomplete configuration.
+	ErrInvalidConfig = errors.New("invalid oauth config")
+	// ErrUnauthorized indicates the authenticated account is not permitted.
+	ErrUnauthorized = errors.New("unauthorize")
+	// ErrUnknownProvider indicates no identity provider is registered under the requested name.
+	ErrUnknownProvider = errors.New("unknown identity provider")
+)
+
+// OAuthConfig contains all configuration values required by OAuthService.
+type OAuthConfig struct {
+	// Providers are the identity providers users may sign in with.
+	Providers *provider.Registry
+	// Keyring signs and verifies access tokens.
+	Keyring  *Keyring
+	TokenTTL time.Duration
+	// RefreshTokenTTL is how long a refresh token may be exchanged; each refresh issues a new one.
+	RefreshTokenTTL time.Duration
+	LogService      loginterfaces.Service
+	RoleRepo        authinterfaces.RoleRepository
+	SessionRepo     authinterfaces.SessionRepository
+	IdentityRepo    authinterfaces.IdentityRepository
+	// APIKeys authenticates personal API keys presented instead of access tokens.
+	APIKeys authinterfaces.APIKeyAuthenticator
+	// MFA checks the second factor of users who enrolled one.
+	MFA authinterfaces.MFAVerifier
+	// SignupGate decides whether a first-time identity may create a user.
+	SignupGate authinterfaces.SignupGate
+	// Transactor and Outbox record user.created and user.logged_in events in the same transaction as the
+	// user row they describe.
+	Transactor dbtx.Transactor
+	Outbox     outboxinterfaces.Outbox
+	// BootstrapAdminEmails are granted the admin role when they sign in, so a fresh deployment has an administrator.
+	// They bypass the sign-up policy.
+	BootstrapAdminEmails []string
+}
+
+type authClaims struct {
+	Email    string   `json:"email"`
+	Provider string   `json:"provider"`
+	Roles    []string `json:"roles"`
+	// TokenType is empty for access tokens and mfaPendingTokenType for logins waiting for the second factor.
+	TokenType string `json:"typ,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// OAuthService implements the OAuth2 login flow against the registered identity providers and issues tokens.
+type OAuthService struct {
+	repo        authinterfaces.UserRepository
+	providers   *provider.Registry
+	keyring     *Keyring
+	tokenTTL    time.Duration
+	refreshTTL  time.Duration
+	logService  loginterfaces.Service
+	roleRepo    authinterfaces.RoleRepository
+	sessionRepo authinterfaces.SessionRepository
+	identities  authinterfaces.IdentityRepository
+	apiKeys     authinterfaces.APIKeyAuthenticator
+	mfa         authinterfaces.MFAVerifier
+	signupGate  authinterfaces.SignupGate
+	transactor  dbtx.Transactor
+	outbox      outboxinterfaces.Outbox
+	adminEmails map[string]struct{}
+}
+
+var _ authinterfaces.AuthService = (*OAuthService)(nil)
+
+// NewOAuthService constructs a new OAuthService.
+func NewOAuthService(repo authinterfaces.UserRepository, cfg OAuthConfig) (*OAuthService, error) {
+	if repo == nil ||
+		cfg.Providers == nil ||
+		cfg.Providers.Len() == 0 ||
+		cfg.Keyring == nil ||
+		cfg.LogService == nil ||
+		cfg.RoleRepo == nil ||
+		cfg.SessionRepo == nil ||
+		cfg.IdentityRepo == nil ||
+		cfg.APIKeys == nil ||
+		cfg.MFA == nil ||
+		cfg.SignupGate == nil ||
+		cfg.Transactor == nil ||
+		cfg.Outbox == nil {
+		return nil, ErrInvalidConfig
+	}
+
+	if cfg.TokenTTL <= 0 {
+		cfg.TokenTTL = time.Hour
+	}
+
+	if cfg.RefreshTokenTTL <= 0 {
+		cfg.RefreshTokenTTL = defaultRefreshTokenTTL
+	}
+
+	adminEmails := make(map[string]struct{}, len(cfg.BootstrapAdminEmails))
+	for _, email := range cfg.BootstrapAdminEmails {
+		if normalized := strings.ToLower(strings.TrimSpace(email)); normalized != "" {
+			adminEmails[normalized] = struct{}{}
+		}
+	}
+
+	return &OAuthService{
+		repo:        repo,
+		providers:   cfg.Providers,
+		keyring:     cfg.Keyring,
+		tokenTTL:    cfg.TokenTTL,
+		refreshTTL:  cfg.RefreshTokenTTL,
+		logService:  cfg.LogService,
+		roleRepo:    cfg.RoleRepo,
+		sessionRepo: cfg.SessionRepo,
+		identities:  cfg.IdentityRepo,
+		apiKeys:     cfg.APIKeys,
+		mfa:         cfg.MFA,
+		signupGate:  cfg.SignupGate,
+		transactor:  cfg.Transactor,
+		outbox:      cfg.Outbox,
+		adminEmails: adminEmails,
+	}, nil
+}
+
+// Providers returns the names of the registered identity providers.
+func (s *OAuthService) Providers() []string {
+	return s.providers.Names()
+}
+
+// StartLogin generates the state, PKCE verifier and nonce of a login and returns the named provider's consent URL.
+// The caller must keep the parameters for HandleCallback.
+func (s *OAuthService) StartLogin(ctx context.Context, providerName string) (*dto.AuthStart, error) {
+	identityProvider, ok := s.providers.Get(providerName)
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	params, err := newAuthParams(identityProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	loginURL, err := identityProvider.AuthCodeURL(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthStart{URL: loginURL, Params: params}, nil
+}
+
+// HandleCallback completes the OAuth2 flow once the named provider redirects back to the application and
+// returns the signed-in user. Tokens are issued separately by IssueSession or ExchangeLoginCode.
+func (s *OAuthService) HandleCallback(ctx context.Context, providerName string, req dto.CallbackRequest) (*dao.User, error) {
+	identityProvider, ok := s.providers.Get(providerName)
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	exchangeCtx, cancel := context.WithTimeout(ctx, defaultHTTPTimeout)
+	defer cancel()
+
+	identity, err := identityProvider.Exchange(exchangeCtx, req.Code, req.Params)
+	if err != nil {
+		if errors.Is(err, provider.ErrEmailUnverified) {
+			return nil, ErrEmailUnverified
+		}
+		return nil, fmt.Errorf("complete %s login: %w", providerName, err)
+	}
+
+	user, err := s.ensureUser(ctx, *identity, req.InvitationCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.ensureRoles(ctx, *user, *identity); err != nil {
+		return nil, err
+	}
+
+	loggedInAt := time.Now()
+	if err := s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateLoginTimestamp(ctx, user.ID); err != nil {
+			return fmt.Errorf("update login timestamp: %w", err)
+		}
+
+		return s.outbox.Add(ctx, outboxdto.Event{
+			Type: outboxdto.EventUserLoggedIn,
+			Payload: outboxdto.UserLoggedIn{
+				UserID:     user.ID,
+				Provider:   providerName,
+				LoggedInAt: loggedInAt,
+			},
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	user.LastLoginAt = loggedInAt
+
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID:   user.ID,
+		Action:   logdto.ActionLogin,
+		Detail:   fmt.Sprintf("authenticated via %s", providerName),
+		Metadata: logdto.Metadata{"provider": providerName},
+	}); err != nil {
+		// The login already succeeded; losing its audit entry must not sign the user out.
+		log.Printf("record login log for user %d: %v", user.ID, err)
+	}
+
+	return user, nil
+}
+
+func (s *OAuthService) ensureUser(ctx context.Context, identity dto.ExternalIdentity, invitationCode string) (*dao.User, error) {
+	displayName := identity.Name
+	if displayName == "" {
+		displayName = identity.Email
+	}
+
+	existing, err := s.repo.FindByProvider(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("find user by provider: %w", err)
+	}
+
+	if existing == nil {
+		existing, err = s.resolveByEmail(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if existing != nil {
+		if !existing.DeactivatedAt.IsZero() {
+			return nil, ErrAccountDeactivated
+		}
+
+		if err := s.identities.TouchIdentity(ctx, identity.Provider, identity.Subject, identity.Email, identity.EmailVerified); err != nil {
+			return nil, fmt.Errorf("update identity: %w", err)
+		}
+
+		// Keep latest picture if previously missing.
+		if existing.PictureURL == "" && identity.PictureURL != "" {
+			existing.PictureURL = identity.PictureURL
+		}
+		return existing, nil
+	}
+
+	var invitationID int64
+	if _, bootstrap := s.adminEmails[strings.ToLower(identity.Email)]; !bootstrap || !identity.EmailVerified {
+		invitationID, err = s.signupGate.AdmitSignup(ctx, dto.SignupCandidate{
+			Email:          identity.Email,
+			EmailVerified:  identity.EmailVerified,
+			HostedDomain:   identity.HostedDomain,
+			InvitationCode: invitationCode,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newUser := dao.User{
+		Email:      identity.Email,
+		Name:       displayName,
+		Provider:   identity.Provider,
+		ProviderID: identity.Subject,
+		PictureURL: identity.PictureURL,
+	}
+
+	var created *dao.User
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.repo.Create(ctx, newUser); err != nil {
+			return err
+		}
+
+		return s.outbox.Add(ctx, outboxdto.Event{
+			Type: outboxdto.EventUserCreated,
+			Payload: outboxdto.UserCreated{
+				UserID:    created.ID,
+				Email:     created.Email,
+				Name:      created.Name,
+				Provider:  created.Provider,
+				CreatedAt: created.CreatedAt,
+			},
+		})
+	})
+	if err != nil {
+		if abortErr := s.signupGate.AbortSignup(ctx, invitationID); abortErr != nil {
+			return nil, fmt.Errorf("create user: %w (release invitation: %v)", err, abortErr)
+		}
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	if err := s.signupGate.CompleteSignup(ctx, invitationID, created.ID); err != nil {
+		return nil, fmt.Errorf("complete signup: %w", err)
+	}
+
+	if err := s.identities.TouchIdentity(ctx, identity.Provider, identity.Subject, identity.Email, identity.EmailVerified); err != nil {
+		return nil, fmt.Errorf("update identity: %w", err)
+	}
+
+	if _, err := s.roleRepo.GrantRole(ctx, created.ID, rbac.DefaultRole, nil); err != nil {
+		return nil, fmt.Errorf("grant default role: %w", err)
+	}
+
+	return created, nil
+}
+
+// resolveByEmail links a new provider identity to an existing account with the same email. The merge only
+// happens when both the new provider and exactly one existing account report the address as verified;
+// any other email match is refused with ErrLinkRequired so nobody can take over an account with an
+// unverified address. It returns nil when no account uses the email.
+func (s *OAuthService) resolveByEmail(ctx context.Context, identity dto.ExternalIdentity) (*dao.User, error) {
+	if identity.Email == "" {
+		return nil, nil
+	}
+
+	matches, err := s.identities.FindIdentitiesByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, fmt.Errorf("find identities by email: %w", err)
+	}
+
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	verifiedOwners := make(map[int64]struct{})
+	for _, match := range matches {
+		if match.EmailVerified {
+			verifiedOwners[match.UserID] = struct{}{}
+		}
+	}
+
+	if !identity.EmailVerified || len(verifiedOwners) != 1 {
+		return nil, ErrLinkRequired
+	}
+
+	var ownerID int64
+	for userID := range verifiedOwners {
+		ownerID = userID
+	}
+
+	for _, match := range matches {
+		if match.UserID == ownerID && match.Provider == identity.Provider {
+			// The owner already holds another account of this provider.
+			return nil, ErrLinkRequired
+		}
+	}
+
+	owner, err := s.repo.FindByID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if owner == nil {
+		return nil, nil
+	}
+
+	if _, err := s.identities.LinkIdentity(ctx, dao.Identity{
+		UserID:        owner.ID,
+		Provider:      identity.Provider,
+		ProviderID:    identity.Subject,
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+	}); err != nil {
+		return nil, fmt.Errorf("link identity: %w", err)
+	}
+
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID:   owner.ID,
+		Action:   logdto.ActionIdentityLinked,
+		Detail:   fmt.Sprintf("linked %s identity automatically by verified email", identity.Provider),
+		Metadata: logdto.Metadata{"provider": identity.Provider, "automatic": true},
+	}); err != nil {
+		return nil, fmt.Errorf("record identity link log: %w", err)
+	}
+
+	return owner, nil
+}
+
+// ensureRoles grants the admin role to bootstrap administrators and returns the user's roles. The role is
+// only granted when the identity that signed in has verified the bootstrap address.
+func (s *OAuthService) ensureRoles(ctx context.Context, user dao.User, identity dto.ExternalIdentity) ([]string, error) {
+	_, bootstrap := s.adminEmails[strings.ToLower(user.Email)]
+	if bootstrap && identity.EmailVerified && strings.EqualFold(identity.Email, user.Email) {
+		granted, err := s.roleRepo.GrantRole(ctx, user.ID, rbac.RoleAdmin, nil)
+		if err != nil {
+			return nil, fmt.Errorf("grant bootstrap admin role: %w", err)
+		}
+
+		if granted {
+			if err := s.logService.Record(ctx, logdto.NewLog{
+				UserID:   user.ID,
+				Action:   logdto.ActionRoleGranted,
+				Detail:   "admin role granted from bootstrap admin list",
+				Metadata: logdto.Metadata{"role": rbac.RoleAdmin, "bootstrap": true},
+			}); err != nil {
+				return nil, fmt.Errorf("record role grant log: %w", err)
+			}
+		}
+	}
+
+	roles, err := s.roleRepo.FindRoles(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("find user roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (s *OAuthService) generateJWT(ctx context.Context, user dao.User, roles []string) (string, error) {
+	return s.signClaims(ctx, authClaims{
+		Email:    user.Email,
+		Provider: user.Provider,
+		Roles:    roles,
+	}, user.ID, s.tokenTTL)
+}
+
+// signClaims completes claims with the subject, a fresh jti and the validity period, and signs them with the
+// active key.
+func (s *OAuthService) signClaims(ctx context.Context, claims authClaims, userID int64, ttl time.Duration) (string, error) {
+	key, err := s.keyring.signingKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	tokenID, err := generateRandomToken(tokenIDBytes)
+	if err != nil {
+		return "", fmt.Errorf("generate token id: %w", err)
+	}
+
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        tokenID,
+		Subject:   strconv.FormatInt(userID, 10),
+		Issuer:    "gobackend",
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	signed, err := token.SignedString(key.private)
+	if err != nil {
+		return "", fmt.Errorf("sign jwt token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// PublicKeys returns the active and retired access token keys as a JSON Web Key Set.
+func (s *OAuthService) PublicKeys(ctx context.Context) (*jwks.Set, error) {
+	return s.keyring.PublicKeys(ctx)
+}
+
+// Authenticate validates credential as a personal API key when it carries APIKeyPrefix, otherwise as an access token.
+func (s *OAuthService) Authenticate(ctx context.Context, credential string) (*dto.Claims, error) {
+	if strings.HasPrefix(credential, APIKeyPrefix) {
+		return s.apiKeys.AuthenticateAPIKey(ctx, credential)
+	}
+
+	return s.ParseClaims(ctx, credential)
+}
+
+// ExtractUserID parses the JWT token and returns the embedded user ID.
+func (s *OAuthService) ExtractUserID(ctx context.Context, token string) (int64, error) {
+	claims, err := s.ParseClaims(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+
+	return claims.UserID, nil
+}
+
+// ParseClaims validates the JWT token, rejects revoked tokens and returns its claims.
+func (s *OAuthService) ParseClaims(ctx context.Context, token string) (*dto.Claims, error) {
+	claims, userID, err := s.parseToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != "" {
+		return nil, fmt.Errorf("%s token is not an access token", claims.TokenType)
+	}
+
+	result := &dto.Claims{
+		TokenID:  claims.ID,
+		UserID:   userID,
+		Email:    claims.Email,
+		Provider: claims.Provider,
+		Roles:    claims.Roles,
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	return result, nil
+}
+
+// parseToken verifies the signature of a token issued by signClaims and rejects it when revoked.
+func (s *OAuthService) parseToken(ctx context.Context, token string) (*authClaims, int64, error) {
+	if token == "" {
+		return nil, 0, fmt.Errorf("token is required")
+	}
+
+	claims := &authClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		keyID, _ := t.Header["kid"].(string)
+		key, err := s.keyring.verificationKey(ctx, keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		// A key only verifies the algorithm it was generated for.
+		if t.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("token algorithm %s does not match key %s", t.Method.Alg(), key.id)
+		}
+
+		return key.private.Public(), nil
+	}, jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmEdDSA}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, 0, fmt.Errorf("parse token: %w", err)
+	}
+
+	if !parsed.Valid {
+		return nil, 0, fmt.Errorf("invalid token")
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decode subject: %w", err)
+	}
+
+	if claims.ID == "" || claims.IssuedAt == nil {
+		return nil, 0, fmt.Errorf("token is missing jti or iat")
+	}
+
+	revoked, err := s.sessionRepo.IsAccessTokenRevoked(ctx, claims.ID, userID, claims.IssuedAt.Time)
+	if err != nil {
+		return nil, 0, fmt.Errorf("check token revocation: %w", err)
+	}
+	if revoked {
+		return nil, 0, ErrTokenRevoked
+	}
+
+	return claims, userID, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	authinterfaces "gobackend/src/auth/interfaces"
+	"gobackend/src/auth/rbac"
+	logdto "gobackend/src/logs/dto"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+var (
+	// ErrUnknownRole indicates the requested role does not exist.
+	ErrUnknownRole = errors.New("unknown role")
+	// ErrUserNotFound indicates the target user does not exist.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrSelfAdminRevoke prevents administrators from locking themselves out.
+	ErrSelfAdminRevoke = errors.New("administrators cannot revoke their own admin role")
+)
+
+var _ authinterfaces.RoleService = (*RoleService)(nil)
+
+// RoleService grants and revokes user roles, writing an audit entry for every change.
+type RoleService struct {
+	users      authinterfaces.UserRepository
+	roles      authinterfaces.RoleRepository
+	logService loginterfaces.Service
+}
+
+// NewRoleService constructs a RoleService.
+func NewRoleService(users authinterfaces.UserRepository, roles authinterfaces.RoleRepository, logService loginterfaces.Service) *RoleService {
+	return &RoleService{users: users, roles: roles, logService: logService}
+}
+
+// ListRoles returns the roles held by a user.
+func (s *RoleService) ListRoles(ctx context.Context, userID int64) ([]string, error) {
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.roles.FindRoles(ctx, userID)
+}
+
+// GrantRole assigns a role to a user on behalf of actorID and returns the user's roles.
+func (s *RoleService) GrantRole(ctx context.Context, actorID, userID int64, role string) ([]string, error) {
+	if !rbac.IsValidRole(role) {
+		return nil, ErrUnknownRole
+	}
+
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	granted, err := s.roles.GrantRole(ctx, userID, role, &actorID)
+	if err != nil {
+		return nil, fmt.Errorf("grant role: %w", err)
+	}
+
+	if granted {
+		if err := s.logService.Record(ctx, logdto.NewLog{
+			UserID:   actorID,
+			Action:   logdto.ActionRoleGranted,
+			Detail:   fmt.Sprintf("granted %s role to user %d", role, userID),
+			TargetID: userID,
+			Metadata: logdto.Metadata{"role": role},
+		}); err != nil {
+			return nil, fmt.Errorf("record role grant log: %w", err)
+		}
+	}
+
+	return s.roles.FindRoles(ctx, userID)
+}
+
+// RevokeRole removes a role from a user on behalf of actorID and returns the user's roles.
+func (s *RoleService) RevokeRole(ctx context.Context, actorID, userID int64, role string) ([]string, error) {
+	if !rbac.IsValidRole(role) {
+		return nil, ErrUnknownRole
+	}
+
+	if actorID == userID && role == rbac.RoleAdmin {
+		return nil, ErrSelfAdminRevoke
+	}
+
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.roles.RevokeRole(ctx, userID, role)
+	if err != nil {
+		return nil, fmt.Errorf("revoke role: %w", err)
+	}
+
+	if revoked {
+		if err := s.logService.Record(ctx, logdto.NewLog{
+			UserID:   actorID,
+			Action:   logdto.ActionRoleRevoked,
+			Detail:   fmt.Sprintf("revoked %s role from user %d", role, userID),
+			TargetID: userID,
+			Metadata: logdto.Metadata{"role": role},
+		}); err != nil {
+			return nil, fmt.Errorf("record role revoke log: %w", err)
+		}
+	}
+
+	return s.roles.FindRoles(ctx, userID)
+}
+
+func (s *RoleService) ensureUserExists(ctx context.Context, userID int64) error {
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("find user: %w", err)
+	}
+
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
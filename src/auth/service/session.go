@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+	logdto "gobackend/src/logs/dto"
+)
+
+const (
+	tokenIDBytes      = 16
+	familyIDBytes     = 16
+	refreshTokenBytes = 32
+	loginCodeBytes    = 32
+
+	// loginCodeTTL is how long the client has to exchange a login code after the callback redirect.
+	loginCodeTTL = time.Minute
+	// mfaPendingTTL is how long the client has to submit the second factor after the first one succeeded.
+	mfaPendingTTL = 5 * time.Minute
+
+	mfaPendingTokenType = "mfa_pending"
+)
+
+var (
+	// ErrInvalidRefreshToken indicates the refresh token is unknown, expired or revoked.
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	// ErrRefreshTokenReused indicates an already rotated refresh token was presented again; its family is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+	// ErrTokenRevoked indicates the access token was revoked by logout or by an administrator.
+	ErrTokenRevoked = errors.New("token has been revoked")
+	// ErrInvalidLoginCode indicates the login code is unknown, expired or was already exchanged.
+	ErrInvalidLoginCode = errors.New("invalid or expired login code")
+	// ErrInvalidMFAToken indicates the pending MFA token is malformed, expired or was already used.
+	ErrInvalidMFAToken = errors.New("invalid or expired mfa token")
+)
+
+// issueTokens signs an access token and stores a new refresh token. An empty familyID starts a new family.
+func (s *OAuthService) issueTokens(ctx context.Context, user dao.User, roles []string, familyID string, parentID int64) (*dto.AuthResponse, error) {
+	accessToken, err := s.generateJWT(ctx, user, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	if familyID == "" {
+		familyID, err = generateRandomToken(familyIDBytes)
+		if err != nil {
+			return nil, fmt.Errorf("generate refresh token family: %w", err)
+		}
+	}
+
+	refreshToken, err := generateRandomToken(refreshTokenBytes)
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	if _, err := s.sessionRepo.CreateRefreshToken(ctx, dao.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshToken),
+		FamilyID:  familyID,
+		ParentID:  parentID,
+		ExpiresAt: time.Now().Add(s.refreshTTL),
+	}); err != nil {
+		return nil, fmt.Errorf("store refresh token: %w", err)
+	}
+
+	return &dto.AuthResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		User:         user,
+	}, nil
+}
+
+// IssueSession signs an access token and starts a new refresh token family for the user. Users with MFA enabled
+// receive a short-lived MFA token instead, which CompleteMFALogin exchanges for tokens once the second factor is verified.
+func (s *OAuthService) IssueSession(ctx context.Context, userID int64) (*dto.AuthResponse, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if !user.DeactivatedAt.IsZero() {
+		return nil, ErrAccountDeactivated
+	}
+
+	enabled, err := s.mfa.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("check mfa: %w", err)
+	}
+	if enabled {
+		mfaToken, err := s.signClaims(ctx, authClaims{TokenType: mfaPendingTokenType}, user.ID, mfaPendingTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		return &dto.AuthResponse{MFARequired: true, MFAToken: mfaToken, User: *user}, nil
+	}
+
+	return s.issueSessionTokens(ctx, *user)
+}
+
+// CompleteMFALogin verifies the second factor for a token returned by IssueSession and issues the user's tokens.
+// The MFA token is revoked once the code is accepted so it cannot be replayed.
+func (s *OAuthService) CompleteMFALogin(ctx context.Context, mfaToken, code string) (*dto.AuthResponse, error) {
+	claims, userID, err := s.parseToken(ctx, mfaToken)
+	if err != nil || claims.TokenType != mfaPendingTokenType {
+		return nil, ErrInvalidMFAToken
+	}
+
+	if err := s.mfa.VerifyCode(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.RevokeAccessToken(ctx, claims.ID, userID, claims.ExpiresAt.Time); err != nil {
+		return nil, fmt.Errorf("revoke mfa token: %w", err)
+	}
+
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return s.issueSessionTokens(ctx, *user)
+}
+
+func (s *OAuthService) issueSessionTokens(ctx context.Context, user dao.User) (*dto.AuthResponse, error) {
+	roles, err := s.roleRepo.FindRoles(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("find user roles: %w", err)
+	}
+
+	return s.issueTokens(ctx, user, roles, "", 0)
+}
+
+// CreateLoginCode stores a short-lived, single-use code the client exchanges for tokens with ExchangeLoginCode,
+// so tokens never appear in a redirect URL.
+func (s *OAuthService) CreateLoginCode(ctx context.Context, userID int64) (string, error) {
+	code, err := generateRandomToken(loginCodeBytes)
+	if err != nil {
+		return "", fmt.Errorf("generate login code: %w", err)
+	}
+
+	if err := s.sessionRepo.CreateLoginCode(ctx, hashToken(code), userID, time.Now().Add(loginCodeTTL)); err != nil {
+		return "", fmt.Errorf("store login code: %w", err)
+	}
+
+	return code, nil
+}
+
+// ExchangeLoginCode consumes a code created by CreateLoginCode and issues the user's tokens.
+func (s *OAuthService) ExchangeLoginCode(ctx context.Context, code string) (*dto.AuthResponse, error) {
+	if code == "" {
+		return nil, ErrInvalidLoginCode
+	}
+
+	userID, err := s.sessionRepo.ConsumeLoginCode(ctx, hashToken(code), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("consume login code: %w", err)
+	}
+	if userID == 0 {
+		return nil, ErrInvalidLoginCode
+	}
+
+	return s.IssueSession(ctx, userID)
+}
+
+// Refresh exchanges a refresh token for a new token pair. Presenting a token that was already
+// exchanged revokes every token of its family, since either the client or an attacker holds a stolen copy.
+func (s *OAuthService) Refresh(ctx context.Context, refreshToken string) (*dto.AuthResponse, error) {
+	if refreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	stored, err := s.sessionRepo.FindRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("find refresh token: %w", err)
+	}
+
+	if stored == nil || !stored.RevokedAt.IsZero() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if !stored.UsedAt.IsZero() {
+		return nil, s.revokeReusedFamily(ctx, *stored)
+	}
+
+	now := time.Now()
+	if !stored.ExpiresAt.After(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	used, err := s.sessionRepo.UseRefreshToken(ctx, stored.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("use refresh token: %w", err)
+	}
+	if !used {
+		return nil, s.revokeReusedFamily(ctx, *stored)
+	}
+
+	user, err := s.repo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if user == nil || !user.DeactivatedAt.IsZero() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	roles, err := s.roleRepo.FindRoles(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("find user roles: %w", err)
+	}
+
+	return s.issueTokens(ctx, *user, roles, stored.FamilyID, stored.ID)
+}
+
+func (s *OAuthService) revokeReusedFamily(ctx context.Context, token dao.RefreshToken) error {
+	if err := s.sessionRepo.RevokeRefreshFamily(ctx, token.FamilyID, time.Now()); err != nil {
+		return fmt.Errorf("revoke refresh token family: %w", err)
+	}
+
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID:   token.UserID,
+		Action:   logdto.ActionRefreshTokenReuse,
+		Detail:   "reused refresh token detected; revoked its session",
+		Metadata: logdto.Metadata{"family_id": token.FamilyID},
+	}); err != nil {
+		return fmt.Errorf("record refresh token reuse log: %w", err)
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// RevokeSession revokes the current access token and, when provided, the refresh token family of the same user.
+func (s *OAuthService) RevokeSession(ctx context.Context, claims dto.Claims, refreshToken string) error {
+	if err := s.sessionRepo.RevokeAccessToken(ctx, claims.TokenID, claims.UserID, claims.ExpiresAt); err != nil {
+		return fmt.Errorf("revoke access token: %w", err)
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := s.sessionRepo.FindRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return fmt.Errorf("find refresh token: %w", err)
+	}
+
+	if stored == nil || stored.UserID != claims.UserID {
+		return nil
+	}
+
+	if err := s.sessionRepo.RevokeRefreshFamily(ctx, stored.FamilyID, time.Now()); err != nil {
+		return fmt.Errorf("revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authinterfaces "gobackend/src/auth/interfaces"
+	logdto "gobackend/src/logs/dto"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+var _ authinterfaces.SessionService = (*SessionService)(nil)
+
+// SessionService administers the sessions of other users.
+type SessionService struct {
+	users      authinterfaces.UserRepository
+	sessions   authinterfaces.SessionRepository
+	logService loginterfaces.Service
+}
+
+// NewSessionService constructs a SessionService.
+func NewSessionService(users authinterfaces.UserRepository, sessions authinterfaces.SessionRepository, logService loginterfaces.Service) *SessionService {
+	return &SessionService{users: users, sessions: sessions, logService: logService}
+}
+
+// SignOutEverywhere revokes every refresh token of the user and every access token issued so far. It joins the
+// transaction carried by ctx, so callers can end the sessions together with another change.
+func (s *SessionService) SignOutEverywhere(ctx context.Context, actorID, userID int64) error {
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("find user: %w", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	now := time.Now()
+	if err := s.sessions.RevokeUserRefreshTokens(ctx, userID, now); err != nil {
+		return fmt.Errorf("revoke refresh tokens: %w", err)
+	}
+
+	if err := s.sessions.RevokeUserAccessTokens(ctx, userID, now); err != nil {
+		return fmt.Errorf("revoke access tokens: %w", err)
+	}
+
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID:   actorID,
+		Action:   logdto.ActionSessionsRevoked,
+		Detail:   fmt.Sprintf("signed out user %d everywhere", userID),
+		TargetID: userID,
+	}); err != nil {
+		return fmt.Errorf("record sessions revoked log: %w", err)
+	}
+
+	return nil
+}
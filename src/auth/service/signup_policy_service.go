@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/auth/dao"
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+	logdto "gobackend/src/logs/dto"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+const (
+	invitationCodeBytes     = 18
+	defaultInvitationTTL    = 72 * time.Hour
+	maxInvitationTTLInHours = 24 * 30
+)
+
+var (
+	// ErrInvalidSignupRule indicates a malformed email or domain.
+	ErrInvalidSignupRule = errors.New("invalid email or domain")
+	// ErrSignupRuleNotFound indicates the allowlist entry does not exist.
+	ErrSignupRuleNotFound = errors.New("allowlist entry not found")
+	// ErrInvalidInvitationTTL indicates the requested invitation lifetime is out of range.
+	ErrInvalidInvitationTTL = fmt.Errorf("expires_in_hours must be between 1 and %d", maxInvitationTTLInHours)
+	// ErrInvitationNotFound indicates the invitation does not exist or was already used.
+	ErrInvitationNotFound = errors.New("invitation not found or already used")
+)
+
+var _ authinterfaces.SignupPolicyService = (*SignupPolicyService)(nil)
+
+// SignupPolicyService manages who may create an account: allowlisted emails, Google Workspace domains
+// and single-use invitation codes.
+type SignupPolicyService struct {
+	repo        authinterfaces.SignupPolicyRepository
+	logService  loginterfaces.Service
+	nowProvider func() time.Time
+}
+
+// NewSignupPolicyService constructs a SignupPolicyService.
+func NewSignupPolicyService(repo authinterfaces.SignupPolicyRepository, logService loginterfaces.Service) *SignupPolicyService {
+	return &SignupPolicyService{repo: repo, logService: logService, nowProvider: time.Now}
+}
+
+// AdmitSignup checks an invitation code first, then the email and hosted-domain allowlists.
+func (s *SignupPolicyService) AdmitSignup(ctx context.Context, candidate dto.SignupCandidate) (int64, error) {
+	email := strings.ToLower(strings.TrimSpace(candidate.Email))
+
+	if candidate.InvitationCode != "" {
+		return s.redeemInvitation(ctx, candidate.InvitationCode, email, candidate.EmailVerified)
+	}
+
+	if domain := strings.ToLower(strings.TrimSpace(candidate.HostedDomain)); domain != "" {
+		allowed, err := s.repo.RuleExists(ctx, dao.SignupRuleDomain, domain)
+		if err != nil {
+			return 0, fmt.Errorf("check domain allowlist: %w", err)
+		}
+		if allowed {
+			return 0, nil
+		}
+	}
+
+	allowed, err := s.repo.RuleExists(ctx, dao.SignupRuleEmail, email)
+	if err != nil {
+		return 0, fmt.Errorf("check email allowlist: %w", err)
+	}
+
+	if !allowed {
+		return 0, ErrNotAllowlisted
+	}
+
+	if !candidate.EmailVerified {
+		return 0, ErrEmailUnverified
+	}
+
+	return 0, nil
+}
+
+// CompleteSignup links a consumed invitation to the created user.
+func (s *SignupPolicyService) CompleteSignup(ctx context.Context, invitationID, userID int64) error {
+	if invitationID == 0 {
+		return nil
+	}
+
+	return s.repo.AttachInvitationUser(ctx, invitationID, userID)
+}
+
+// AbortSignup releases a consumed invitation after account creation failed.
+func (s *SignupPolicyService) AbortSignup(ctx context.Context, invitationID int64) error {
+	if invitationID == 0 {
+		return nil
+	}
+
+	return s.repo.ReleaseInvitation(ctx, invitationID)
+}
+
+func (s *SignupPolicyService) redeemInvitation(ctx context.Context, code, email string, emailVerified bool) (int64, error) {
+	invitation, err := s.repo.FindInvitationByCodeHash(ctx, hashToken(code))
+	if err != nil {
+		return 0, fmt.Errorf("find invitation: %w", err)
+	}
+
+	if invitation == nil || (invitation.Email != "" && invitation.Email != email) {
+		return 0, ErrInvitationInvalid
+	}
+
+	if invitation.Email != "" && !emailVerified {
+		return 0, ErrEmailUnverified
+	}
+
+	now := s.nowProvider()
+	if !invitation.UsedAt.IsZero() {
+		return 0, ErrInvitationUsed
+	}
+	if !invitation.ExpiresAt.After(now) {
+		return 0, ErrInvitationExpired
+	}
+
+	consumed, err := s.repo.ConsumeInvitation(ctx, invitation.ID, now)
+	if err != nil {
+		return 0, fmt.Errorf("consume invitation: %w", err)
+	}
+	if !consumed {
+		return 0, ErrInvitationUsed
+	}
+
+	return invitation.ID, nil
+}
+
+// GetPolicy returns the allowlisted emails and domains.
+func (s *SignupPolicyService) GetPolicy(ctx context.Context) (*dto.SignupPolicy, error) {
+	rules, err := s.repo.FindRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &dto.SignupPolicy{
+		Emails:  []dto.SignupRule{},
+		Domains: []dto.SignupRule{},
+	}
+
+	for _, rule := range rules {
+		entry := dto.SignupRule{ID: rule.ID, Value: rule.Value, CreatedAt: rule.CreatedAt}
+		switch rule.Kind {
+		case dao.SignupRuleEmail:
+			policy.Emails = append(policy.Emails, entry)
+		case dao.SignupRuleDomain:
+			policy.Domains = append(policy.Domains, entry)
+		}
+	}
+
+	return policy, nil
+}
+
+// AddRule allowlists an email or domain on behalf of actorID.
+func (s *SignupPolicyService) AddRule(ctx context.Context, actorID int64, kind, value string) (*dto.SignupPolicy, error) {
+	normalized, err := normalizeSignupRule(kind, value)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := s.repo.AddRule(ctx, dao.SignupRule{Kind: kind, Value: normalized, CreatedBy: actorID})
+	if err != nil {
+		return nil, fmt.Errorf("add allowlist entry: %w", err)
+	}
+
+	if added {
+		if err := s.audit(ctx, actorID, logdto.ActionSignupPolicyUpdated, fmt.Sprintf("allowlisted %s %s", kind, normalized)); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetPolicy(ctx)
+}
+
+// RemoveRule removes an allowlisted email or domain on behalf of actorID.
+func (s *SignupPolicyService) RemoveRule(ctx context.Context, actorID int64, kind, value string) (*dto.SignupPolicy, error) {
+	normalized, err := normalizeSignupRule(kind, value)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.repo.RemoveRule(ctx, kind, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("remove allowlist entry: %w", err)
+	}
+
+	if !removed {
+		return nil, ErrSignupRuleNotFound
+	}
+
+	if err := s.audit(ctx, actorID, logdto.ActionSignupPolicyUpdated, fmt.Sprintf("removed %s %s from allowlist", kind, normalized)); err != nil {
+		return nil, err
+	}
+
+	return s.GetPolicy(ctx)
+}
+
+// ListInvitations returns invitations without their codes.
+func (s *SignupPolicyService) ListInvitations(ctx context.Context, params pagination.Params) ([]dto.Invitation, int64, error) {
+	invitations, total, err := s.repo.FindInvitations(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]dto.Invitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		result = append(result, toInvitationDTO(invitation, ""))
+	}
+
+	return result, total, nil
+}
+
+// CreateInvitation issues a single-use invitation code. The plain code is only returned here.
+func (s *SignupPolicyService) CreateInvitation(ctx context.Context, actorID int64, req dto.InvitationRequest) (*dto.Invitation, error) {
+	ttl := defaultInvitationTTL
+	if req.ExpiresInHours != 0 {
+		if req.ExpiresInHours < 1 || req.ExpiresInHours > maxInvitationTTLInHours {
+			return nil, ErrInvalidInvitationTTL
+		}
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	email := ""
+	if strings.TrimSpace(req.Email) != "" {
+		normalized, err := normalizeSignupRule(dao.SignupRuleEmail, req.Email)
+		if err != nil {
+			return nil, err
+		}
+		email = normalized
+	}
+
+	code, err := generateRandomToken(invitationCodeBytes)
+	if err != nil {
+		return nil, fmt.Errorf("generate invitation code: %w", err)
+	}
+
+	created, err := s.repo.CreateInvitation(ctx, dao.Invitation{
+		CodeHash:  hashToken(code),
+		Email:     email,
+		CreatedBy: actorID,
+		ExpiresAt: s.nowProvider().Add(ttl),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create invitation: %w", err)
+	}
+
+	detail := fmt.Sprintf("created invitation %d", created.ID)
+	if email != "" {
+		detail += " for " + email
+	}
+	if err := s.audit(ctx, actorID, logdto.ActionInvitationCreated, detail); err != nil {
+		return nil, err
+	}
+
+	result := toInvitationDTO(*created, code)
+	return &result, nil
+}
+
+// RevokeInvitation deletes an unused invitation on behalf of actorID.
+func (s *SignupPolicyService) RevokeInvitation(ctx context.Context, actorID, invitationID int64) error {
+	deleted, err := s.repo.DeleteInvitation(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("delete invitation: %w", err)
+	}
+
+	if !deleted {
+		return ErrInvitationNotFound
+	}
+
+	return s.audit(ctx, actorID, logdto.ActionInvitationRevoked, fmt.Sprintf("revoked invitation %d", invitationID))
+}
+
+func (s *SignupPolicyService) audit(ctx context.Context, actorID int64, action logdto.Action, detail string) error {
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID: actorID,
+		Action: action,
+		Detail: detail,
+	}); err != nil {
+		return fmt.Errorf("record %s log: %w", action, err)
+	}
+
+	return nil
+}
+
+func normalizeSignupRule(kind, value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch kind {
+	case dao.SignupRuleEmail:
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return "", ErrInvalidSignupRule
+		}
+	case dao.SignupRuleDomain:
+		value = strings.TrimPrefix(value, "@")
+		if value == "" || strings.ContainsAny(value, "@/ ") || !strings.Contains(value, ".") {
+			return "", ErrInvalidSignupRule
+		}
+	default:
+		return "", ErrInvalidSignupRule
+	}
+
+	return value, nil
+}
+
+func toInvitationDTO(invitation dao.Invitation, code string) dto.Invitation {
+	result := dto.Invitation{
+		ID:        invitation.ID,
+		Code:      code,
+		Email:     invitation.Email,
+		CreatedAt: invitation.CreatedAt,
+		ExpiresAt: invitation.ExpiresAt,
+	}
+
+	if !invitation.UsedAt.IsZero() {
+		usedAt := invitation.UsedAt
+		result.UsedAt = &usedAt
+	}
+
+	return result
+}
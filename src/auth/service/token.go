@@ -0,0 +1,66 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"golang.org/x/oauth2"
+
+	"gobackend/src/auth/dto"
+	authinterfaces "gobackend/src/auth/interfaces"
+)
+
+const (
+	stateBytes = 32
+	nonceBytes = 16
+)
+
+// generateRandomToken returns size random bytes encoded as URL-safe base64.
+func generateRandomToken(size int) (string, error) {
+	random, err := randomBytes(size)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+func randomBytes(size int) ([]byte, error) {
+	random := make([]byte, size)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+
+	return random, nil
+}
+
+// hashToken returns the hex SHA-256 digest stored in place of a secret token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newAuthParams generates the state, PKCE verifier and, for OpenID Connect providers, the nonce of a login flow.
+func newAuthParams(identityProvider authinterfaces.IdentityProvider) (dto.AuthParams, error) {
+	state, err := generateRandomToken(stateBytes)
+	if err != nil {
+		return dto.AuthParams{}, fmt.Errorf("generate oauth state: %w", err)
+	}
+
+	params := dto.AuthParams{
+		State:        state,
+		CodeVerifier: oauth2.GenerateVerifier(),
+	}
+
+	if identityProvider.SupportsNonce() {
+		params.Nonce, err = generateRandomToken(nonceBytes)
+		if err != nil {
+			return dto.AuthParams{}, fmt.Errorf("generate oidc nonce: %w", err)
+		}
+	}
+
+	return params, nil
+}
@@ -10,13 +10,66 @@ import (
 var (
 	// ErrMissingCode indicates the OAuth2 callback payload does not contain the authorization code.
 	ErrMissingCode = errors.New("authorization code is required")
+	// ErrMissingState indicates the OAuth2 callback does not carry the state issued at login.
+	ErrMissingState = errors.New("state is required")
+	// ErrMissingRefreshToken indicates the refresh payload does not contain a refresh token.
+	ErrMissingRefreshToken = errors.New("refresh_token is required")
+	// ErrMissingLoginCode indicates the token payload does not contain the one-time login code.
+	ErrMissingLoginCode = errors.New("code is required")
+	// ErrInvalidInvitationCode indicates the invitation code contains unexpected characters.
+	ErrInvalidInvitationCode = errors.New("invalid invitation code")
 )
 
-// ValidateGoogleCallback ensures the mandatory fields are present in the callback request.
-func ValidateGoogleCallback(req dto.GoogleCallbackRequest) error {
-	if strings.TrimSpace(req.Code) == "" {
+const maxInvitationCodeLength = 64
+
+// ValidateCallback ensures the mandatory callback query parameters are present.
+func ValidateCallback(code, state string) error {
+	if strings.TrimSpace(code) == "" {
 		return ErrMissingCode
 	}
 
+	if strings.TrimSpace(state) == "" {
+		return ErrMissingState
+	}
+
+	return nil
+}
+
+// ValidateRefresh ensures the refresh payload carries a token.
+func ValidateRefresh(req dto.RefreshRequest) error {
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		return ErrMissingRefreshToken
+	}
+
+	return nil
+}
+
+// ValidateTokenExchange ensures the token payload carries a login code.
+func ValidateTokenExchange(req dto.TokenRequest) error {
+	if strings.TrimSpace(req.Code) == "" {
+		return ErrMissingLoginCode
+	}
+
+	return nil
+}
+
+// ValidateInvitationCode ensures an invitation code is URL-safe so it can travel inside the OAuth state.
+func ValidateInvitationCode(code string) error {
+	if code == "" {
+		return nil
+	}
+
+	if len(code) > maxInvitationCodeLength {
+		return ErrInvalidInvitationCode
+	}
+
+	for _, r := range code {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return ErrInvalidInvitationCode
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+
+	"gobackend/src/auth/dto"
+)
+
+var (
+	// ErrMissingMFACode indicates the payload does not contain a TOTP or recovery code.
+	ErrMissingMFACode = errors.New("code is required")
+	// ErrMissingMFAToken indicates the MFA verification payload does not contain the MFA token from the login.
+	ErrMissingMFAToken = errors.New("mfa_token is required")
+)
+
+// ValidateMFACode ensures the payload carries a code.
+func ValidateMFACode(req dto.MFACodeRequest) error {
+	if strings.TrimSpace(req.Code) == "" {
+		return ErrMissingMFACode
+	}
+
+	return nil
+}
+
+// ValidateMFAVerify ensures the MFA verification payload carries the MFA token and a code.
+func ValidateMFAVerify(req dto.MFAVerifyRequest) error {
+	if strings.TrimSpace(req.MFAToken) == "" {
+		return ErrMissingMFAToken
+	}
+
+	if strings.TrimSpace(req.Code) == "" {
+		return ErrMissingMFACode
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package dao
+
+import "time"
+
+// GrammarPoint represents a persisted grammar point record.
+type GrammarPoint struct {
+	ID        int64     `json:"id"`
+	Pattern   string    `json:"pattern"`
+	Meaning   string    `json:"meaning"`
+	Formation []string  `json:"formation"`
+	JLPTLevel int       `json:"jlpt_level"`
+	Nuance    string    `json:"nuance"`
+	Examples  []Example `json:"examples"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Example represents an example sentence attached to a grammar point.
+type Example struct {
+	ID          int64  `json:"id"`
+	Sentence    string `json:"sentence"`
+	Reading     string `json:"reading"`
+	Translation string `json:"translation"`
+}
@@ -1,10 +1,18 @@
 package delivery
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 
+	"gobackend/shared/pagination"
 	"gobackend/shared/response"
+	"gobackend/src/bunpo/dto"
 	bunpointerfaces "gobackend/src/bunpo/interfaces"
+	bunposervice "gobackend/src/bunpo/service"
+	"gobackend/src/bunpo/validation"
 )
 
 // Handler exposes bunpo HTTP endpoints.
@@ -17,13 +25,148 @@ func NewHandler(service bunpointerfaces.Service) *Handler {
 	return &Handler{service: service}
 }
 
-// Test responds with a simple success message.
-func (h *Handler) Test(ctx *gin.Context) {
-	message, err := h.service.Test(ctx.Request.Context())
+// ListGrammarPoints returns paginated grammar points, optionally narrowed by the jlpt query parameter.
+func (h *Handler) ListGrammarPoints(ctx *gin.Context) {
+	filter, ok := parseFilter(ctx)
+	if !ok {
+		return
+	}
+
+	h.respondWithList(ctx, filter)
+}
+
+// SearchGrammarPoints returns paginated grammar points matching the q query parameter.
+func (h *Handler) SearchGrammarPoints(ctx *gin.Context) {
+	filter, ok := parseFilter(ctx)
+	if !ok {
+		return
+	}
+
+	filter.Query = strings.TrimSpace(ctx.Query("q"))
+	if filter.Query == "" {
+		response.BadRequest(ctx, "search query is required", nil)
+		return
+	}
+
+	h.respondWithList(ctx, filter)
+}
+
+// GetGrammarPoint returns a single grammar point with its examples.
+func (h *Handler) GetGrammarPoint(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.BadRequest(ctx, "invalid grammar point id", nil)
+		return
+	}
+
+	point, err := h.service.GetGrammarPoint(ctx.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, bunposervice.ErrGrammarPointNotFound) {
+			response.NotFound(ctx, err.Error())
+			return
+		}
+
+		response.InternalError(ctx, "failed to fetch grammar point", err.Error())
+		return
+	}
+
+	response.OK(ctx, "grammar point retrieved successfully", point)
+}
+
+// CreateGrammarPoint stores a new grammar point.
+func (h *Handler) CreateGrammarPoint(ctx *gin.Context) {
+	var req dto.GrammarPointRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	point, err := h.service.CreateGrammarPoint(ctx.Request.Context(), req)
+	if err != nil {
+		h.handleWriteError(ctx, err)
+		return
+	}
+
+	response.Created(ctx, "grammar point created", point)
+}
+
+// UpdateGrammarPoint replaces an existing grammar point.
+func (h *Handler) UpdateGrammarPoint(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.BadRequest(ctx, "invalid grammar point id", nil)
+		return
+	}
+
+	var req dto.GrammarPointRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	point, err := h.service.UpdateGrammarPoint(ctx.Request.Context(), id, req)
 	if err != nil {
-		response.InternalError(ctx, "unable to execute bunpo test", err.Error())
+		h.handleWriteError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "grammar point updated", point)
+}
+
+// DeleteGrammarPoint removes a grammar point.
+func (h *Handler) DeleteGrammarPoint(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.BadRequest(ctx, "invalid grammar point id", nil)
+		return
+	}
+
+	if err := h.service.DeleteGrammarPoint(ctx.Request.Context(), id); err != nil {
+		h.handleWriteError(ctx, err)
 		return
 	}
 
-	response.OK(ctx, message, gin.H{"message": message})
+	response.NoContent(ctx)
+}
+
+func (h *Handler) handleWriteError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, bunposervice.ErrGrammarPointNotFound):
+		response.NotFound(ctx, err.Error())
+	case errors.Is(err, validation.ErrMissingPattern),
+		errors.Is(err, validation.ErrMissingMeaning),
+		errors.Is(err, validation.ErrInvalidJLPTLevel),
+		errors.Is(err, validation.ErrIncompleteExample):
+		response.BadRequest(ctx, err.Error(), nil)
+	default:
+		response.InternalError(ctx, "failed to save grammar point", err.Error())
+	}
+}
+
+func (h *Handler) respondWithList(ctx *gin.Context, filter dto.Filter) {
+	params := pagination.FromQuery(ctx)
+
+	points, total, err := h.service.ListGrammarPoints(ctx.Request.Context(), params, filter)
+	if err != nil {
+		response.InternalError(ctx, "failed to list grammar points", err.Error())
+		return
+	}
+
+	meta := pagination.NewMetadata(total, params)
+	response.Paginated(ctx, "grammar points retrieved successfully", points, meta)
+}
+
+func parseFilter(ctx *gin.Context) (dto.Filter, bool) {
+	var filter dto.Filter
+
+	if raw := ctx.Query("jlpt"); raw != "" {
+		level, err := validation.ParseJLPTLevel(raw)
+		if err != nil {
+			response.BadRequest(ctx, err.Error(), nil)
+			return filter, false
+		}
+		filter.JLPTLevel = level
+	}
+
+	return filter, true
 }
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// GrammarPoint represents the grammar point payload returned to API consumers.
+type GrammarPoint struct {
+	ID        int64     `json:"id"`
+	Pattern   string    `json:"pattern"`
+	Meaning   string    `json:"meaning"`
+	Formation []string  `json:"formation"`
+	JLPTLevel int       `json:"jlpt_level"`
+	Nuance    string    `json:"nuance,omitempty"`
+	Examples  []Example `json:"examples"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Example is an example sentence with its translation.
+type Example struct {
+	Sentence    string `json:"sentence"`
+	Reading     string `json:"reading,omitempty"`
+	Translation string `json:"translation"`
+}
+
+// GrammarPointRequest is the payload used to create or replace a grammar point.
+type GrammarPointRequest struct {
+	Pattern   string    `json:"pattern"`
+	Meaning   string    `json:"meaning"`
+	Formation []string  `json:"formation"`
+	JLPTLevel int       `json:"jlpt_level"`
+	Nuance    string    `json:"nuance"`
+	Examples  []Example `json:"examples"`
+}
+
+// Filter narrows grammar point listings. Zero values mean the criterion is not applied.
+type Filter struct {
+	JLPTLevel int
+	Query     string
+}
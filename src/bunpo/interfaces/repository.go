@@ -0,0 +1,19 @@
+package bunpointerfaces
+
+import (
+	"context"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/bunpo/dao"
+	"gobackend/src/bunpo/dto"
+)
+
+// Repository describes persistence operations for grammar points.
+type Repository interface {
+	FindAll(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dao.GrammarPoint, int64, error)
+	FindByID(ctx context.Context, id int64) (*dao.GrammarPoint, error)
+	Create(ctx context.Context, point dao.GrammarPoint) (*dao.GrammarPoint, error)
+	Update(ctx context.Context, point dao.GrammarPoint) (*dao.GrammarPoint, error)
+	Delete(ctx context.Context, id int64) (bool, error)
+	EnsureSchema(ctx context.Context) error
+}
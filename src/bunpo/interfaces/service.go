@@ -1,8 +1,17 @@
 package bunpointerfaces
 
-import "context"
+import (
+	"context"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/bunpo/dto"
+)
 
 // Service describes bunpo feature business logic.
 type Service interface {
-	Test(ctx context.Context) (string, error)
+	ListGrammarPoints(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dto.GrammarPoint, int64, error)
+	GetGrammarPoint(ctx context.Context, id int64) (*dto.GrammarPoint, error)
+	CreateGrammarPoint(ctx context.Context, req dto.GrammarPointRequest) (*dto.GrammarPoint, error)
+	UpdateGrammarPoint(ctx context.Context, id int64, req dto.GrammarPointRequest) (*dto.GrammarPoint, error)
+	DeleteGrammarPoint(ctx context.Context, id int64) error
 }
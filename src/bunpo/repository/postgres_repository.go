@@ -0,0 +1,345 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"gobackend/shared/pagination"
+	"gobackend/shared/utils"
+	"gobackend/src/bunpo/dao"
+	"gobackend/src/bunpo/dto"
+	bunpointerfaces "gobackend/src/bunpo/interfaces"
+)
+
+var _ bunpointerfaces.Repository = (*PostgresRepository)(nil)
+
+const grammarPointColumns = `g.id, g.pattern, g.meaning, g.formation, g.jlpt_level, g.nuance, g.created_at, g.updated_at`
+
+// PostgresRepository persists grammar points in Postgres.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new grammar point repository.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// EnsureSchema verifies that required tables exist.
+func (r *PostgresRepository) EnsureSchema(ctx context.Context) error {
+	const tableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = $1
+`
+
+	for _, table := range []string{"grammar_points", "grammar_examples"} {
+		var exists int
+		if err := r.db.QueryRowContext(ctx, tableQuery, table).Scan(&exists); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%s table not found; please run database migrations", table)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindAll retrieves grammar points matching the filter ordered by JLPT level (N5 first), returning the total count.
+func (r *PostgresRepository) FindAll(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dao.GrammarPoint, int64, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if filter.JLPTLevel > 0 {
+		args = append(args, filter.JLPTLevel)
+		conditions = append(conditions, fmt.Sprintf("g.jlpt_level = $%d", len(args)))
+	}
+
+	if filter.Query != "" {
+		args = append(args, "%"+utils.EscapeLike(filter.Query)+"%")
+		placeholder := len(args)
+		conditions = append(conditions, fmt.Sprintf(`(g.pattern ILIKE $%[1]d
+    OR g.meaning ILIKE $%[1]d
+    OR g.nuance ILIKE $%[1]d
+    OR EXISTS (SELECT 1 FROM grammar_examples e WHERE e.grammar_point_id = g.id AND (e.sentence ILIKE $%[1]d OR e.translation ILIKE $%[1]d)))`, placeholder))
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM grammar_points g"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM grammar_points g%s ORDER BY g.jlpt_level DESC, g.id ASC LIMIT $%d OFFSET $%d",
+		grammarPointColumns,
+		whereClause,
+		len(args)+1,
+		len(args)+2,
+	)
+	args = append(args, params.Limit(), params.Offset())
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var (
+		points []dao.GrammarPoint
+		ids    []int64
+	)
+	for rows.Next() {
+		point, err := scanGrammarPoint(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		points = append(points, *point)
+		ids = append(ids, point.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if len(points) == 0 {
+		return points, total, nil
+	}
+
+	examples, err := r.findExamples(ctx, r.db, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range points {
+		points[i].Examples = examples[points[i].ID]
+	}
+
+	return points, total, nil
+}
+
+// FindByID locates a grammar point and its examples.
+func (r *PostgresRepository) FindByID(ctx context.Context, id int64) (*dao.GrammarPoint, error) {
+	return r.findByID(ctx, r.db, id)
+}
+
+// Create inserts a grammar point together with its examples.
+func (r *PostgresRepository) Create(ctx context.Context, point dao.GrammarPoint) (*dao.GrammarPoint, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	const query = `
+INSERT INTO grammar_points (pattern, meaning, formation, jlpt_level, nuance)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id
+`
+
+	var id int64
+	if err := tx.QueryRowContext(
+		ctx,
+		query,
+		point.Pattern,
+		point.Meaning,
+		pq.Array(point.Formation),
+		point.JLPTLevel,
+		point.Nuance,
+	).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	if err := insertExamples(ctx, tx, id, point.Examples); err != nil {
+		return nil, err
+	}
+
+	created, err := r.findByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Update replaces a grammar point and its examples. It returns nil when the record does not exist.
+func (r *PostgresRepository) Update(ctx context.Context, point dao.GrammarPoint) (*dao.GrammarPoint, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	const query = `
+UPDATE grammar_points
+SET pattern = $1,
+    meaning = $2,
+    formation = $3,
+    jlpt_level = $4,
+    nuance = $5,
+    updated_at = NOW()
+WHERE id = $6
+`
+
+	result, err := tx.ExecContext(
+		ctx,
+		query,
+		point.Pattern,
+		point.Meaning,
+		pq.Array(point.Formation),
+		point.JLPTLevel,
+		point.Nuance,
+		point.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM grammar_examples WHERE grammar_point_id = $1", point.ID); err != nil {
+		return nil, err
+	}
+
+	if err := insertExamples(ctx, tx, point.ID, point.Examples); err != nil {
+		return nil, err
+	}
+
+	updated, err := r.findByID(ctx, tx, point.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Delete removes a grammar point; examples are removed by the foreign key cascade.
+// It reports whether a record was deleted.
+func (r *PostgresRepository) Delete(ctx context.Context, id int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM grammar_points WHERE id = $1", id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *PostgresRepository) findByID(ctx context.Context, q queryer, id int64) (*dao.GrammarPoint, error) {
+	query := fmt.Sprintf("SELECT %s FROM grammar_points g WHERE g.id = $1", grammarPointColumns)
+
+	point, err := scanGrammarPoint(q.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	examples, err := r.findExamples(ctx, q, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+	point.Examples = examples[id]
+
+	return point, nil
+}
+
+func (r *PostgresRepository) findExamples(ctx context.Context, q queryer, ids []int64) (map[int64][]dao.Example, error) {
+	const query = `
+SELECT id, grammar_point_id, sentence, reading, translation
+FROM grammar_examples
+WHERE grammar_point_id = ANY($1)
+ORDER BY grammar_point_id, position
+`
+
+	rows, err := q.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	examples := make(map[int64][]dao.Example, len(ids))
+	for rows.Next() {
+		var (
+			pointID int64
+			example dao.Example
+		)
+		if err := rows.Scan(&example.ID, &pointID, &example.Sentence, &example.Reading, &example.Translation); err != nil {
+			return nil, err
+		}
+		examples[pointID] = append(examples[pointID], example)
+	}
+
+	return examples, rows.Err()
+}
+
+func insertExamples(ctx context.Context, tx *sql.Tx, pointID int64, examples []dao.Example) error {
+	const query = `
+INSERT INTO grammar_examples (grammar_point_id, position, sentence, reading, translation)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+	for position, example := range examples {
+		if _, err := tx.ExecContext(ctx, query, pointID, position, example.Sentence, example.Reading, example.Translation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanGrammarPoint(row rowScanner) (*dao.GrammarPoint, error) {
+	var point dao.GrammarPoint
+	if err := row.Scan(
+		&point.ID,
+		&point.Pattern,
+		&point.Meaning,
+		pq.Array(&point.Formation),
+		&point.JLPTLevel,
+		&point.Nuance,
+		&point.CreatedAt,
+		&point.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &point, nil
+}
@@ -8,5 +8,10 @@ import (
 
 // Register mounts bunpo endpoints on the router.
 func Register(router gin.IRoutes, handler *bunpodelivery.Handler) {
-	router.GET("/bunpo/test", handler.Test)
+	router.GET("/api/bunpo", handler.ListGrammarPoints)
+	router.GET("/api/bunpo/search", handler.SearchGrammarPoints)
+	router.GET("/api/bunpo/:id", handler.GetGrammarPoint)
+	router.POST("/api/bunpo", handler.CreateGrammarPoint)
+	router.PUT("/api/bunpo/:id", handler.UpdateGrammarPoint)
+	router.DELETE("/api/bunpo/:id", handler.DeleteGrammarPoint)
 }
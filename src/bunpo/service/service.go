@@ -2,20 +2,161 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 
+	"gobackend/shared/pagination"
+	"gobackend/src/bunpo/dao"
+	"gobackend/src/bunpo/dto"
 	bunpointerfaces "gobackend/src/bunpo/interfaces"
+	"gobackend/src/bunpo/validation"
 )
 
 var _ bunpointerfaces.Service = (*bunpoService)(nil)
 
-type bunpoService struct{}
+// ErrGrammarPointNotFound indicates the requested grammar point does not exist.
+var ErrGrammarPointNotFound = errors.New("grammar point not found")
+
+type bunpoService struct {
+	repo bunpointerfaces.Repository
+}
 
 // NewService constructs a bunpo service implementation.
-func NewService() bunpointerfaces.Service {
-	return &bunpoService{}
+func NewService(repo bunpointerfaces.Repository) bunpointerfaces.Service {
+	return &bunpoService{repo: repo}
+}
+
+// ListGrammarPoints returns grammar points matching the filter.
+func (s *bunpoService) ListGrammarPoints(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dto.GrammarPoint, int64, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	points, total, err := s.repo.FindAll(ctx, params, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]dto.GrammarPoint, 0, len(points))
+	for _, point := range points {
+		result = append(result, toDTO(point))
+	}
+
+	return result, total, nil
+}
+
+// GetGrammarPoint returns a single grammar point with its examples.
+func (s *bunpoService) GetGrammarPoint(ctx context.Context, id int64) (*dto.GrammarPoint, error) {
+	point, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if point == nil {
+		return nil, ErrGrammarPointNotFound
+	}
+
+	result := toDTO(*point)
+	return &result, nil
+}
+
+// CreateGrammarPoint validates and stores a new grammar point.
+func (s *bunpoService) CreateGrammarPoint(ctx context.Context, req dto.GrammarPointRequest) (*dto.GrammarPoint, error) {
+	if err := validation.ValidateGrammarPoint(req); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, toDAO(0, req))
+	if err != nil {
+		return nil, err
+	}
+
+	result := toDTO(*created)
+	return &result, nil
 }
 
-// Test returns a simple success message.
-func (s *bunpoService) Test(ctx context.Context) (string, error) {
-	return "endpoint success", nil
+// UpdateGrammarPoint validates and replaces an existing grammar point.
+func (s *bunpoService) UpdateGrammarPoint(ctx context.Context, id int64, req dto.GrammarPointRequest) (*dto.GrammarPoint, error) {
+	if err := validation.ValidateGrammarPoint(req); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, toDAO(id, req))
+	if err != nil {
+		return nil, err
+	}
+
+	if updated == nil {
+		return nil, ErrGrammarPointNotFound
+	}
+
+	result := toDTO(*updated)
+	return &result, nil
+}
+
+// DeleteGrammarPoint removes a grammar point and its examples.
+func (s *bunpoService) DeleteGrammarPoint(ctx context.Context, id int64) error {
+	deleted, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return ErrGrammarPointNotFound
+	}
+
+	return nil
+}
+
+func toDAO(id int64, req dto.GrammarPointRequest) dao.GrammarPoint {
+	formation := make([]string, 0, len(req.Formation))
+	for _, rule := range req.Formation {
+		if trimmed := strings.TrimSpace(rule); trimmed != "" {
+			formation = append(formation, trimmed)
+		}
+	}
+
+	examples := make([]dao.Example, 0, len(req.Examples))
+	for _, example := range req.Examples {
+		examples = append(examples, dao.Example{
+			Sentence:    strings.TrimSpace(example.Sentence),
+			Reading:     strings.TrimSpace(example.Reading),
+			Translation: strings.TrimSpace(example.Translation),
+		})
+	}
+
+	return dao.GrammarPoint{
+		ID:        id,
+		Pattern:   strings.TrimSpace(req.Pattern),
+		Meaning:   strings.TrimSpace(req.Meaning),
+		Formation: formation,
+		JLPTLevel: req.JLPTLevel,
+		Nuance:    strings.TrimSpace(req.Nuance),
+		Examples:  examples,
+	}
+}
+
+func toDTO(point dao.GrammarPoint) dto.GrammarPoint {
+	formation := point.Formation
+	if formation == nil {
+		formation = []string{}
+	}
+
+	examples := make([]dto.Example, 0, len(point.Examples))
+	for _, example := range point.Examples {
+		examples = append(examples, dto.Example{
+			Sentence:    example.Sentence,
+			Reading:     example.Reading,
+			Translation: example.Translation,
+		})
+	}
+
+	return dto.GrammarPoint{
+		ID:        point.ID,
+		Pattern:   point.Pattern,
+		Meaning:   point.Meaning,
+		Formation: formation,
+		JLPTLevel: point.JLPTLevel,
+		Nuance:    point.Nuance,
+		Examples:  examples,
+		UpdatedAt: point.UpdatedAt,
+	}
 }
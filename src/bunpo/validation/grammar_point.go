@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"gobackend/src/bunpo/dto"
+)
+
+var (
+	// ErrInvalidJLPTLevel indicates the JLPT level is not N1-N5.
+	ErrInvalidJLPTLevel = errors.New("jlpt level must be between 1 and 5 (or N1-N5)")
+	// ErrMissingPattern indicates the grammar pattern is empty.
+	ErrMissingPattern = errors.New("pattern is required")
+	// ErrMissingMeaning indicates the grammar meaning is empty.
+	ErrMissingMeaning = errors.New("meaning is required")
+	// ErrIncompleteExample indicates an example sentence lacks its sentence or translation.
+	ErrIncompleteExample = errors.New("every example requires a sentence and a translation")
+)
+
+// ParseJLPTLevel accepts "3" or "N3" and returns the numeric level.
+func ParseJLPTLevel(raw string) (int, error) {
+	value := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(raw)), "N")
+	level, err := strconv.Atoi(value)
+	if err != nil || !validJLPTLevel(level) {
+		return 0, ErrInvalidJLPTLevel
+	}
+
+	return level, nil
+}
+
+// ValidateGrammarPoint ensures the mandatory fields of a grammar point payload are present.
+func ValidateGrammarPoint(req dto.GrammarPointRequest) error {
+	if strings.TrimSpace(req.Pattern) == "" {
+		return ErrMissingPattern
+	}
+
+	if strings.TrimSpace(req.Meaning) == "" {
+		return ErrMissingMeaning
+	}
+
+	if !validJLPTLevel(req.JLPTLevel) {
+		return ErrInvalidJLPTLevel
+	}
+
+	for _, example := range req.Examples {
+		if strings.TrimSpace(example.Sentence) == "" || strings.TrimSpace(example.Translation) == "" {
+			return ErrIncompleteExample
+		}
+	}
+
+	return nil
+}
+
+func validJLPTLevel(level int) bool {
+	return level >= 1 && level <= 5
+}
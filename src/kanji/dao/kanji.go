@@ -0,0 +1,18 @@
+package dao
+
+import "time"
+
+// Kanji represents a persisted kanji catalog record.
+type Kanji struct {
+	ID            int64     `json:"id"`
+	Character     string    `json:"character"`
+	Onyomi        []string  `json:"onyomi"`
+	Kunyomi       []string  `json:"kunyomi"`
+	Meanings      []string  `json:"meanings"`
+	StrokeCount   int       `json:"stroke_count"`
+	Grade         int       `json:"grade"`
+	JLPTLevel     int       `json:"jlpt_level"`
+	FrequencyRank int       `json:"frequency_rank"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
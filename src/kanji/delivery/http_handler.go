@@ -0,0 +1,80 @@
+package delivery
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/pagination"
+	"gobackend/shared/response"
+	kanjiinterfaces "gobackend/src/kanji/interfaces"
+	kanjiservice "gobackend/src/kanji/service"
+	"gobackend/src/kanji/validation"
+)
+
+// Handler exposes kanji catalog HTTP endpoints.
+type Handler struct {
+	service kanjiinterfaces.Service
+}
+
+// NewHandler builds a Handler instance.
+func NewHandler(service kanjiinterfaces.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ListKanji returns the paginated kanji catalog.
+func (h *Handler) ListKanji(ctx *gin.Context) {
+	params := pagination.FromQuery(ctx)
+
+	items, total, err := h.service.ListKanji(ctx.Request.Context(), params)
+	if err != nil {
+		response.InternalError(ctx, "failed to list kanji", err.Error())
+		return
+	}
+
+	meta := pagination.NewMetadata(total, params)
+	response.Paginated(ctx, "kanji retrieved successfully", items, meta)
+}
+
+// FilterKanji returns paginated kanji narrowed by JLPT level, grade, strokes, reading or meaning.
+func (h *Handler) FilterKanji(ctx *gin.Context) {
+	params := pagination.FromQuery(ctx)
+
+	filter, err := validation.ParseFilter(
+		ctx.Query("jlpt"),
+		ctx.Query("grade"),
+		ctx.Query("min_strokes"),
+		ctx.Query("max_strokes"),
+		ctx.Query("reading"),
+		ctx.Query("meaning"),
+	)
+	if err != nil {
+		response.BadRequest(ctx, "invalid kanji filter", err.Error())
+		return
+	}
+
+	items, total, err := h.service.FilterKanji(ctx.Request.Context(), params, filter)
+	if err != nil {
+		response.InternalError(ctx, "failed to filter kanji", err.Error())
+		return
+	}
+
+	meta := pagination.NewMetadata(total, params)
+	response.Paginated(ctx, "kanji retrieved successfully", items, meta)
+}
+
+// GetKanji returns the detail of a single character.
+func (h *Handler) GetKanji(ctx *gin.Context) {
+	item, err := h.service.GetKanji(ctx.Request.Context(), ctx.Param("character"))
+	if err != nil {
+		if errors.Is(err, kanjiservice.ErrKanjiNotFound) {
+			response.NotFound(ctx, err.Error())
+			return
+		}
+
+		response.InternalError(ctx, "failed to fetch kanji", err.Error())
+		return
+	}
+
+	response.OK(ctx, "kanji retrieved successfully", item)
+}
@@ -0,0 +1,16 @@
+package dto
+
+// Filter narrows kanji listings. Zero values mean the criterion is not applied.
+type Filter struct {
+	JLPTLevel  int
+	Grade      int
+	MinStrokes int
+	MaxStrokes int
+	Reading    string
+	Meaning    string
+}
+
+// IsEmpty reports whether no criterion is set.
+func (f Filter) IsEmpty() bool {
+	return f == Filter{}
+}
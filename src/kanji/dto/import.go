@@ -0,0 +1,15 @@
+package dto
+
+// ImportResult summarises the outcome of a dictionary import run.
+type ImportResult struct {
+	Inserted int `json:"inserted"`
+	Updated  int `json:"updated"`
+	Skipped  int `json:"skipped"`
+}
+
+// Add accumulates the counts of another result.
+func (r *ImportResult) Add(other ImportResult) {
+	r.Inserted += other.Inserted
+	r.Updated += other.Updated
+	r.Skipped += other.Skipped
+}
@@ -0,0 +1,13 @@
+package dto
+
+// Kanji represents the kanji payload returned to API consumers.
+type Kanji struct {
+	Character     string   `json:"character"`
+	Onyomi        []string `json:"onyomi"`
+	Kunyomi       []string `json:"kunyomi"`
+	Meanings      []string `json:"meanings"`
+	StrokeCount   int      `json:"stroke_count"`
+	Grade         int      `json:"grade,omitempty"`
+	JLPTLevel     int      `json:"jlpt_level,omitempty"`
+	FrequencyRank int      `json:"frequency_rank,omitempty"`
+}
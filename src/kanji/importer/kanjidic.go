@@ -0,0 +1,114 @@
+package importer
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gobackend/src/kanji/dao"
+)
+
+const (
+	kanjidicCharacterElement = "character"
+	onyomiReadingType        = "ja_on"
+	kunyomiReadingType       = "ja_kun"
+)
+
+// kanjidicOldJLPT maps the pre-2010 four-level JLPT values used by KANJIDIC2 onto N-levels.
+// The old level 2 covered both N3 and N2; it is mapped to N2 because KANJIDIC2 carries no N3 data.
+var kanjidicOldJLPT = map[int]int{
+	4: 5,
+	3: 4,
+	2: 2,
+	1: 1,
+}
+
+type kanjidicCharacter struct {
+	Literal string `xml:"literal"`
+	Misc    struct {
+		Grade        int   `xml:"grade"`
+		StrokeCounts []int `xml:"stroke_count"`
+		Frequency    int   `xml:"freq"`
+		JLPT         int   `xml:"jlpt"`
+	} `xml:"misc"`
+	Readings []struct {
+		Type  string `xml:"r_type,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"reading_meaning>rmgroup>reading"`
+	Meanings []struct {
+		Language string `xml:"m_lang,attr"`
+		Value    string `xml:",chardata"`
+	} `xml:"reading_meaning>rmgroup>meaning"`
+}
+
+// DecodeKanjidic streams a KANJIDIC2 document and calls fn once per <character> element.
+// Only English meanings and Japanese on/kun readings are kept; the first stroke count is the accepted one.
+func DecodeKanjidic(r io.Reader, fn func(dao.Kanji) error) error {
+	decoder := xml.NewDecoder(r)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read kanjidic token: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != kanjidicCharacterElement {
+			continue
+		}
+
+		var entry kanjidicCharacter
+		if err := decoder.DecodeElement(&entry, &start); err != nil {
+			return fmt.Errorf("decode kanjidic character: %w", err)
+		}
+
+		if err := fn(entry.toKanji()); err != nil {
+			return err
+		}
+	}
+}
+
+func (c kanjidicCharacter) toKanji() dao.Kanji {
+	item := dao.Kanji{
+		Character:     strings.TrimSpace(c.Literal),
+		Onyomi:        []string{},
+		Kunyomi:       []string{},
+		Meanings:      []string{},
+		Grade:         c.Misc.Grade,
+		JLPTLevel:     kanjidicOldJLPT[c.Misc.JLPT],
+		FrequencyRank: c.Misc.Frequency,
+	}
+
+	if len(c.Misc.StrokeCounts) > 0 {
+		item.StrokeCount = c.Misc.StrokeCounts[0]
+	}
+
+	for _, reading := range c.Readings {
+		value := strings.TrimSpace(reading.Value)
+		if value == "" {
+			continue
+		}
+
+		switch reading.Type {
+		case onyomiReadingType:
+			item.Onyomi = append(item.Onyomi, value)
+		case kunyomiReadingType:
+			item.Kunyomi = append(item.Kunyomi, value)
+		}
+	}
+
+	for _, meaning := range c.Meanings {
+		value := strings.TrimSpace(meaning.Value)
+		if value == "" || (meaning.Language != "" && meaning.Language != "en") {
+			continue
+		}
+		item.Meanings = append(item.Meanings, value)
+	}
+
+	return item
+}
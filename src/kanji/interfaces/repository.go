@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/kanji/dao"
+	"gobackend/src/kanji/dto"
+)
+
+// Repository describes persistence operations for the kanji catalog.
+type Repository interface {
+	FindAll(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dao.Kanji, int64, error)
+	FindByCharacter(ctx context.Context, character string) (*dao.Kanji, error)
+	EnsureSchema(ctx context.Context) error
+}
+
+// ImportRepository describes the bulk write operations used by dictionary importers.
+type ImportRepository interface {
+	// UpsertBatch inserts new characters and updates changed ones. Unchanged rows are reported as skipped.
+	// When dryRun is true the changes are rolled back after counting.
+	UpsertBatch(ctx context.Context, items []dao.Kanji, dryRun bool) (dto.ImportResult, error)
+}
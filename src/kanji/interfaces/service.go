@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/kanji/dto"
+)
+
+// Service exposes kanji catalog business logic.
+type Service interface {
+	ListKanji(ctx context.Context, params pagination.Params) ([]dto.Kanji, int64, error)
+	FilterKanji(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dto.Kanji, int64, error)
+	GetKanji(ctx context.Context, character string) (*dto.Kanji, error)
+}
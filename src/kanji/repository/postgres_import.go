@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"gobackend/src/kanji/dao"
+	"gobackend/src/kanji/dto"
+	kanjiinterfaces "gobackend/src/kanji/interfaces"
+)
+
+var _ kanjiinterfaces.ImportRepository = (*PostgresRepository)(nil)
+
+const (
+	upsertColumnCount = 8
+	// maxBindParameters is the most parameters Postgres accepts in one statement.
+	maxBindParameters = 65535
+)
+
+// UpsertBatch inserts or refreshes the given characters in a single transaction.
+// Rows whose values did not change are left untouched and counted as skipped.
+func (r *PostgresRepository) UpsertBatch(ctx context.Context, items []dao.Kanji, dryRun bool) (dto.ImportResult, error) {
+	var result dto.ImportResult
+	if len(items) == 0 {
+		return result, nil
+	}
+
+	if len(items)*upsertColumnCount > maxBindParameters {
+		return result, fmt.Errorf("upsert batch of %d rows exceeds the limit of %d", len(items), maxBindParameters/upsertColumnCount)
+	}
+
+	placeholders := make([]string, 0, len(items))
+	args := make([]interface{}, 0, len(items)*upsertColumnCount)
+	for i, item := range items {
+		base := i * upsertColumnCount
+		placeholders = append(placeholders, fmt.Sprintf(
+			"($%d, $%d::text[], $%d::text[], $%d::text[], $%d, $%d, $%d, $%d)",
+			base+1, base+2, base+3, base+4, base+5, base+6, base+7, base+8,
+		))
+		args = append(args,
+			item.Character,
+			pq.Array(item.Onyomi),
+			pq.Array(item.Kunyomi),
+			pq.Array(item.Meanings),
+			item.StrokeCount,
+			nullableInt(item.Grade),
+			nullableInt(item.JLPTLevel),
+			nullableInt(item.FrequencyRank),
+		)
+	}
+
+	query := `
+INSERT INTO kanji AS k (character, onyomi, kunyomi, meanings, stroke_count, grade, jlpt_level, frequency_rank)
+VALUES ` + strings.Join(placeholders, ",\n") + `
+ON CONFLICT (character) DO UPDATE
+SET onyomi = EXCLUDED.onyomi,
+    kunyomi = EXCLUDED.kunyomi,
+    meanings = EXCLUDED.meanings,
+    stroke_count = EXCLUDED.stroke_count,
+    grade = EXCLUDED.grade,
+    jlpt_level = EXCLUDED.jlpt_level,
+    frequency_rank = EXCLUDED.frequency_rank,
+    updated_at = NOW()
+WHERE (k.onyomi, k.kunyomi, k.meanings, k.stroke_count, k.grade, k.jlpt_level, k.frequency_rank)
+      IS DISTINCT FROM
+      (EXCLUDED.onyomi, EXCLUDED.kunyomi, EXCLUDED.meanings, EXCLUDED.stroke_count, EXCLUDED.grade, EXCLUDED.jlpt_level, EXCLUDED.frequency_rank)
+RETURNING (xmax = 0) AS inserted
+`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	for rows.Next() {
+		var inserted bool
+		if err := rows.Scan(&inserted); err != nil {
+			rows.Close()
+			return result, err
+		}
+		if inserted {
+			result.Inserted++
+		} else {
+			result.Updated++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return result, err
+	}
+	rows.Close()
+
+	result.Skipped = len(items) - result.Inserted - result.Updated
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.ImportResult{}, err
+	}
+
+	return result, nil
+}
+
+func nullableInt(value int) interface{} {
+	if value <= 0 {
+		return nil
+	}
+	return value
+}
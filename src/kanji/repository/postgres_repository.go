@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"gobackend/shared/pagination"
+	"gobackend/shared/utils"
+	"gobackend/src/kanji/dao"
+	"gobackend/src/kanji/dto"
+	kanjiinterfaces "gobackend/src/kanji/interfaces"
+)
+
+var _ kanjiinterfaces.Repository = (*PostgresRepository)(nil)
+
+const kanjiColumns = `k.id,
+       k.character,
+       k.onyomi,
+       k.kunyomi,
+       k.meanings,
+       k.stroke_count,
+       k.grade,
+       k.jlpt_level,
+       k.frequency_rank,
+       k.created_at,
+       k.updated_at`
+
+// PostgresRepository implements kanji catalog queries against Postgres.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new kanji repository.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// EnsureSchema verifies that required tables and indexes exist.
+func (r *PostgresRepository) EnsureSchema(ctx context.Context) error {
+	const kanjiTableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = 'kanji'
+`
+
+	var exists int
+	if err := r.db.QueryRowContext(ctx, kanjiTableQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("kanji table not found; please run database migrations")
+		}
+		return err
+	}
+
+	const characterIdxQuery = `
+SELECT 1
+FROM pg_indexes
+WHERE schemaname = 'public' AND indexname = 'kanji_character_idx'
+`
+
+	if err := r.db.QueryRowContext(ctx, characterIdxQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("index kanji_character_idx not found; please run database migrations")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindAll retrieves kanji matching the filter ordered by frequency, returning the total count.
+func (r *PostgresRepository) FindAll(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dao.Kanji, int64, error) {
+	whereClause, args := buildFilter(filter)
+
+	countQuery := "SELECT COUNT(*) FROM kanji k" + whereClause
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	limitPlaceholder := len(args) + 1
+	offsetPlaceholder := len(args) + 2
+	args = append(args, params.Limit(), params.Offset())
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM kanji k%s ORDER BY k.frequency_rank ASC NULLS LAST, k.stroke_count ASC, k.id ASC LIMIT $%d OFFSET $%d",
+		kanjiColumns,
+		whereClause,
+		limitPlaceholder,
+		offsetPlaceholder,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []dao.Kanji
+	for rows.Next() {
+		item, err := scanKanji(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// FindByCharacter locates a single kanji by its literal character.
+func (r *PostgresRepository) FindByCharacter(ctx context.Context, character string) (*dao.Kanji, error) {
+	query := fmt.Sprintf("SELECT %s FROM kanji k WHERE k.character = $1 LIMIT 1", kanjiColumns)
+
+	item, err := scanKanji(r.db.QueryRowContext(ctx, query, character))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return item, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanKanji(row rowScanner) (*dao.Kanji, error) {
+	var (
+		item          dao.Kanji
+		grade         sql.NullInt64
+		jlptLevel     sql.NullInt64
+		frequencyRank sql.NullInt64
+	)
+
+	if err := row.Scan(
+		&item.ID,
+		&item.Character,
+		pq.Array(&item.Onyomi),
+		pq.Array(&item.Kunyomi),
+		pq.Array(&item.Meanings),
+		&item.StrokeCount,
+		&grade,
+		&jlptLevel,
+		&frequencyRank,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	item.Grade = int(grade.Int64)
+	item.JLPTLevel = int(jlptLevel.Int64)
+	item.FrequencyRank = int(frequencyRank.Int64)
+
+	return &item, nil
+}
+
+func buildFilter(filter dto.Filter) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.JLPTLevel > 0 {
+		add("k.jlpt_level = $%d", filter.JLPTLevel)
+	}
+	if filter.Grade > 0 {
+		add("k.grade = $%d", filter.Grade)
+	}
+	if filter.MinStrokes > 0 {
+		add("k.stroke_count >= $%d", filter.MinStrokes)
+	}
+	if filter.MaxStrokes > 0 {
+		add("k.stroke_count <= $%d", filter.MaxStrokes)
+	}
+	if filter.Reading != "" {
+		add("EXISTS (SELECT 1 FROM unnest(k.onyomi || k.kunyomi) AS reading WHERE replace(replace(reading, '.', ''), '-', '') = $%d)", filter.Reading)
+	}
+	if filter.Meaning != "" {
+		add("EXISTS (SELECT 1 FROM unnest(k.meanings) AS meaning WHERE meaning ILIKE $%d ESCAPE '\\')", "%"+utils.EscapeLike(filter.Meaning)+"%")
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
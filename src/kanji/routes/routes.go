@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	kanjidelivery "gobackend/src/kanji/delivery"
+)
+
+// Register mounts kanji catalog endpoints on the router.
+func Register(router gin.IRoutes, handler *kanjidelivery.Handler) {
+	router.GET("/api/kanji", handler.ListKanji)
+	router.GET("/api/kanji/filter", handler.FilterKanji)
+	router.GET("/api/kanji/:character", handler.GetKanji)
+}
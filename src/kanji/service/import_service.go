@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	"gobackend/src/kanji/dao"
+	"gobackend/src/kanji/dto"
+	"gobackend/src/kanji/importer"
+	kanjiinterfaces "gobackend/src/kanji/interfaces"
+)
+
+const defaultImportBatchSize = 500
+
+// MaxImportBatchSize is the largest batch a single upsert can hold within the Postgres bind parameter limit.
+const MaxImportBatchSize = 8000
+
+// ImportOptions controls a KANJIDIC2 import run.
+type ImportOptions struct {
+	BatchSize int
+	DryRun    bool
+}
+
+// ImportService loads KANJIDIC2 data into the kanji catalog.
+type ImportService struct {
+	repo kanjiinterfaces.ImportRepository
+}
+
+// NewImportService constructs a new ImportService.
+func NewImportService(repo kanjiinterfaces.ImportRepository) *ImportService {
+	return &ImportService{repo: repo}
+}
+
+// ImportKanjidic streams the KANJIDIC2 document and upserts its characters in batches.
+// Characters without a literal or stroke count, and repeated literals, are counted as skipped.
+func (s *ImportService) ImportKanjidic(ctx context.Context, r io.Reader, opts ImportOptions) (dto.ImportResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultImportBatchSize
+	}
+	if opts.BatchSize > MaxImportBatchSize {
+		return dto.ImportResult{}, fmt.Errorf("batch size %d exceeds the maximum of %d", opts.BatchSize, MaxImportBatchSize)
+	}
+
+	var (
+		result dto.ImportResult
+		batch  = make([]dao.Kanji, 0, opts.BatchSize)
+		seen   = make(map[string]struct{})
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		batchResult, err := s.repo.UpsertBatch(ctx, batch, opts.DryRun)
+		if err != nil {
+			return err
+		}
+
+		result.Add(batchResult)
+		log.Printf("kanjidic import progress: inserted=%d updated=%d skipped=%d", result.Inserted, result.Updated, result.Skipped)
+		batch = batch[:0]
+		return nil
+	}
+
+	err := importer.DecodeKanjidic(r, func(item dao.Kanji) error {
+		if item.Character == "" || item.StrokeCount <= 0 {
+			result.Skipped++
+			return nil
+		}
+
+		if _, duplicate := seen[item.Character]; duplicate {
+			result.Skipped++
+			return nil
+		}
+		seen[item.Character] = struct{}{}
+
+		batch = append(batch, item)
+		if len(batch) >= opts.BatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/kanji/dao"
+	"gobackend/src/kanji/dto"
+	kanjiinterfaces "gobackend/src/kanji/interfaces"
+)
+
+var _ kanjiinterfaces.Service = (*KanjiService)(nil)
+
+// ErrKanjiNotFound indicates the requested character is not in the catalog.
+var ErrKanjiNotFound = errors.New("kanji not found")
+
+// KanjiService provides read operations for the kanji catalog.
+type KanjiService struct {
+	repo kanjiinterfaces.Repository
+}
+
+// NewKanjiService constructs a new KanjiService.
+func NewKanjiService(repo kanjiinterfaces.Repository) *KanjiService {
+	return &KanjiService{repo: repo}
+}
+
+// ListKanji returns the catalog ordered by frequency.
+func (s *KanjiService) ListKanji(ctx context.Context, params pagination.Params) ([]dto.Kanji, int64, error) {
+	return s.FilterKanji(ctx, params, dto.Filter{})
+}
+
+// FilterKanji returns the kanji matching the given filter.
+func (s *KanjiService) FilterKanji(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dto.Kanji, int64, error) {
+	items, total, err := s.repo.FindAll(ctx, params, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]dto.Kanji, 0, len(items))
+	for _, item := range items {
+		result = append(result, toDTO(item))
+	}
+
+	return result, total, nil
+}
+
+// GetKanji returns a single kanji by its literal character.
+func (s *KanjiService) GetKanji(ctx context.Context, character string) (*dto.Kanji, error) {
+	character = strings.TrimSpace(character)
+	if character == "" {
+		return nil, ErrKanjiNotFound
+	}
+
+	item, err := s.repo.FindByCharacter(ctx, character)
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil {
+		return nil, ErrKanjiNotFound
+	}
+
+	result := toDTO(*item)
+	return &result, nil
+}
+
+func toDTO(item dao.Kanji) dto.Kanji {
+	return dto.Kanji{
+		Character:     item.Character,
+		Onyomi:        nonNil(item.Onyomi),
+		Kunyomi:       nonNil(item.Kunyomi),
+		Meanings:      nonNil(item.Meanings),
+		StrokeCount:   item.StrokeCount,
+		Grade:         item.Grade,
+		JLPTLevel:     item.JLPTLevel,
+		FrequencyRank: item.FrequencyRank,
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
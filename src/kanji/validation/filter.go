@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gobackend/src/kanji/dto"
+)
+
+const (
+	minJLPTLevel = 1
+	maxJLPTLevel = 5
+	maxGrade     = 10
+)
+
+var (
+	// ErrInvalidJLPTLevel indicates the jlpt query value is not N1-N5.
+	ErrInvalidJLPTLevel = errors.New("jlpt must be between 1 and 5 (or N1-N5)")
+	// ErrInvalidGrade indicates the grade query value is out of range.
+	ErrInvalidGrade = errors.New("grade must be between 1 and 10")
+	// ErrInvalidStrokeRange indicates the stroke bounds are malformed.
+	ErrInvalidStrokeRange = errors.New("stroke bounds must be positive and min_strokes must not exceed max_strokes")
+)
+
+// ParseJLPTLevel accepts "3" or "N3" and returns the numeric level.
+func ParseJLPTLevel(raw string) (int, error) {
+	value := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(raw)), "N")
+	level, err := strconv.Atoi(value)
+	if err != nil || level < minJLPTLevel || level > maxJLPTLevel {
+		return 0, ErrInvalidJLPTLevel
+	}
+
+	return level, nil
+}
+
+// ParseFilter builds a Filter from raw query values, ignoring empty ones.
+func ParseFilter(jlpt, grade, minStrokes, maxStrokes, reading, meaning string) (dto.Filter, error) {
+	filter := dto.Filter{
+		Reading: strings.TrimSpace(reading),
+		Meaning: strings.TrimSpace(meaning),
+	}
+
+	if strings.TrimSpace(jlpt) != "" {
+		level, err := ParseJLPTLevel(jlpt)
+		if err != nil {
+			return dto.Filter{}, err
+		}
+		filter.JLPTLevel = level
+	}
+
+	if strings.TrimSpace(grade) != "" {
+		value, err := strconv.Atoi(strings.TrimSpace(grade))
+		if err != nil || value < 1 || value > maxGrade {
+			return dto.Filter{}, ErrInvalidGrade
+		}
+		filter.Grade = value
+	}
+
+	var err error
+	if filter.MinStrokes, err = parseStrokes(minStrokes); err != nil {
+		return dto.Filter{}, err
+	}
+	if filter.MaxStrokes, err = parseStrokes(maxStrokes); err != nil {
+		return dto.Filter{}, err
+	}
+
+	if filter.MinStrokes > 0 && filter.MaxStrokes > 0 && filter.MinStrokes > filter.MaxStrokes {
+		return dto.Filter{}, ErrInvalidStrokeRange
+	}
+
+	return filter, nil
+}
+
+func parseStrokes(raw string) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidStrokeRange, raw)
+	}
+
+	return value, nil
+}
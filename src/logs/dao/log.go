@@ -4,10 +4,20 @@ import "time"
 
 // Log represents a single audit log entry.
 type Log struct {
-	ID        int64     `json:"id"`
-	UserID    int64     `json:"user_id"`
-	UserName  string    `json:"user_name"`
-	Action    string    `json:"action"`
-	Detail    string    `json:"detail"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	UserName   string    `json:"user_name"`
+	Action     string    `json:"action"`
+	Detail     string    `json:"detail"`
+	Metadata   []byte    `json:"metadata"`
+	ActorID    int64     `json:"actor_id"`
+	ActorName  string    `json:"actor_name"`
+	TargetID   int64     `json:"target_id"`
+	TargetName string    `json:"target_name"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	RequestID  string    `json:"request_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	// MessageID is the envelope ID of an entry written by the ingestion worker; empty for direct writes.
+	MessageID string `json:"message_id,omitempty"`
 }
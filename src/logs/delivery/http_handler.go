@@ -6,7 +6,9 @@ import (
 	"gobackend/shared/identity"
 	"gobackend/shared/pagination"
 	"gobackend/shared/response"
+	"gobackend/src/logs/dto"
 	loginterfaces "gobackend/src/logs/interfaces"
+	"gobackend/src/logs/validation"
 )
 
 // Handler exposes endpoints for user logs.
@@ -20,32 +22,29 @@ func NewHandler(service loginterfaces.Service, refEncoder *identity.UserReferenc
 	return &Handler{service: service, refEncoder: refEncoder}
 }
 
-// ListLogs returns paginated user log entries.
+// ListLogs returns paginated user log entries, optionally narrowed to a user reference, actions, a time range
+// and a detail substring.
 func (h *Handler) ListLogs(ctx *gin.Context) {
 	params := pagination.FromQuery(ctx)
 
-	reference := ctx.Query("reference")
-	var userID *int64
-	if reference != "" {
+	filter, ok := parseFilter(ctx)
+	if !ok {
+		return
+	}
+
+	if reference := ctx.Query("reference"); reference != "" {
 		decoded, err := h.refEncoder.Decode(reference)
 		if err != nil {
 			response.BadRequest(ctx, "invalid user reference", err.Error())
 			return
 		}
-		userID = &decoded
-	}
-
-	logs, total, err := h.service.ListLogs(ctx.Request.Context(), params, userID)
-	if err != nil {
-		response.InternalError(ctx, "failed to fetch user logs", err.Error())
-		return
+		filter.UserID = decoded
 	}
 
-	meta := pagination.NewMetadata(total, params)
-	response.Paginated(ctx, "user logs retrieved successfully", logs, meta)
+	h.respond(ctx, params, filter)
 }
 
-// ListLogsByUser returns paginated log entries for a specific user reference.
+// ListLogsByUser returns paginated log entries for a specific user reference, accepting the ListLogs filters.
 func (h *Handler) ListLogsByUser(ctx *gin.Context) {
 	params := pagination.FromQuery(ctx)
 
@@ -56,7 +55,17 @@ func (h *Handler) ListLogsByUser(ctx *gin.Context) {
 		return
 	}
 
-	logs, total, err := h.service.ListLogs(ctx.Request.Context(), params, &decoded)
+	filter, ok := parseFilter(ctx)
+	if !ok {
+		return
+	}
+	filter.UserID = decoded
+
+	h.respond(ctx, params, filter)
+}
+
+func (h *Handler) respond(ctx *gin.Context, params pagination.Params, filter dto.Filter) {
+	logs, total, err := h.service.ListLogs(ctx.Request.Context(), params, filter)
 	if err != nil {
 		response.InternalError(ctx, "failed to fetch user logs", err.Error())
 		return
@@ -65,3 +74,19 @@ func (h *Handler) ListLogsByUser(ctx *gin.Context) {
 	meta := pagination.NewMetadata(total, params)
 	response.Paginated(ctx, "user logs retrieved successfully", logs, meta)
 }
+
+func parseFilter(ctx *gin.Context) (dto.Filter, bool) {
+	filter, err := validation.ParseFilter(validation.FilterQuery{
+		Actions: ctx.QueryArray("action"),
+		From:    ctx.Query("from"),
+		To:      ctx.Query("to"),
+		Detail:  ctx.Query("detail"),
+		Sort:    ctx.Query("sort"),
+	})
+	if err != nil {
+		response.BadRequest(ctx, "invalid log filter", err.Error())
+		return dto.Filter{}, false
+	}
+
+	return filter, true
+}
@@ -0,0 +1,42 @@
+package dto
+
+// Action identifies the kind of an activity log entry.
+type Action string
+
+// Actions recorded by the features.
+const (
+	ActionLogin             Action = "login"
+	ActionLogout            Action = "logout"
+	ActionRefreshTokenReuse Action = "refresh_token_reuse"
+	ActionSessionsRevoked   Action = "sessions_revoked"
+
+	ActionIdentityLinked   Action = "identity_linked"
+	ActionIdentityUnlinked Action = "identity_unlinked"
+
+	ActionRoleGranted Action = "role_granted"
+	ActionRoleRevoked Action = "role_revoked"
+
+	ActionSignupPolicyUpdated Action = "signup_policy_updated"
+	ActionInvitationCreated   Action = "invitation_created"
+	ActionInvitationRevoked   Action = "invitation_revoked"
+
+	ActionAPIKeyCreated Action = "api_key_created"
+	ActionAPIKeyRevoked Action = "api_key_revoked"
+	ActionAPIKeyUsed    Action = "api_key_used"
+
+	ActionMFAEnrolmentStarted         Action = "mfa_enrolment_started"
+	ActionMFAEnrolled                 Action = "mfa_enrolled"
+	ActionMFAVerified                 Action = "mfa_verified"
+	ActionMFAFailed                   Action = "mfa_failed"
+	ActionMFARecoveryCodesRegenerated Action = "mfa_recovery_codes_regenerated"
+	ActionMFADisabled                 Action = "mfa_disabled"
+
+	ActionProfileUpdated  Action = "profile_updated"
+	ActionUserDeactivated Action = "user_deactivated"
+	ActionUserReactivated Action = "user_reactivated"
+	ActionUserDeleted     Action = "user_deleted"
+	ActionDataExported    Action = "data_exported"
+	ActionAccountErased   Action = "account_erased"
+
+	ActionReviewAnswered Action = "review_answer"
+)
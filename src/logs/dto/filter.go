@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// Filter narrows activity log listings. Zero values mean the criterion is not applied.
+// The time range includes From and excludes To.
+type Filter struct {
+	UserID int64
+	// Actions keeps entries whose action is any of the listed ones.
+	Actions []Action
+	From    time.Time
+	To      time.Time
+	// Detail matches part of the entry detail, ignoring case.
+	Detail string
+	// Ascending lists the oldest entries first; by default the newest come first.
+	Ascending bool
+}
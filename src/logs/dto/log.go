@@ -2,17 +2,34 @@ package dto
 
 import "time"
 
+// Metadata holds structured details of a log entry; it is stored as JSONB.
+type Metadata map[string]interface{}
+
 // Log represents the log information exposed via the API.
 type Log struct {
-	UserName  string    `json:"user_name"`
-	Action    string    `json:"action"`
-	Detail    string    `json:"detail"`
-	CreatedAt time.Time `json:"created_at"`
+	UserName string   `json:"user_name"`
+	Action   Action   `json:"action"`
+	Detail   string   `json:"detail"`
+	Metadata Metadata `json:"metadata"`
+	// ActorName is the user who performed the action; TargetName the user it was performed on, if any.
+	ActorName  string    `json:"actor_name,omitempty"`
+	TargetName string    `json:"target_name,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	RequestID  string    `json:"request_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
-// NewLog describes payload required to create a log entry.
+// NewLog describes payload required to create a log entry. The client IP, user agent and request ID are taken
+// from the request context when the entry is recorded during an HTTP request.
 type NewLog struct {
+	// UserID is the user whose activity log the entry belongs to; zero records a system entry that belongs to none.
 	UserID int64  `json:"user_id"`
-	Action string `json:"action"`
+	Action Action `json:"action"`
 	Detail string `json:"detail"`
+	// ActorID is the user who performed the action; zero means UserID.
+	ActorID int64 `json:"actor_id,omitempty"`
+	// TargetID is the user the action was performed on, if any.
+	TargetID int64    `json:"target_id,omitempty"`
+	Metadata Metadata `json:"metadata,omitempty"`
 }
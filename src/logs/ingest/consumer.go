@@ -0,0 +1,204 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"gobackend/infra/mq"
+	"gobackend/src/logs/dao"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+const (
+	consumerTag = "user-logs-ingest"
+
+	defaultBatchSize     = 100
+	maxBatchSize         = 1000
+	defaultFlushInterval = time.Second
+	insertAttempts       = 3
+)
+
+var insertBackoff = mq.Backoff{Min: 200 * time.Millisecond, Max: 2 * time.Second}
+
+// ConsumerConfig tunes batching of the ingestion worker. Zero values use the defaults.
+type ConsumerConfig struct {
+	// BatchSize is the most entries inserted by one statement; it is also the consumer concurrency.
+	BatchSize int
+	// FlushInterval is the longest a partial batch waits before it is inserted.
+	FlushInterval time.Duration
+}
+
+// Consumer writes queued log entries to the repository in batches.
+type Consumer struct {
+	consumer *mq.Consumer
+	batcher  *batcher
+}
+
+// NewConsumer constructs a Consumer reading from Queue on conn. The caller declares Topology on the connection.
+func NewConsumer(conn *mq.Connection, repo loginterfaces.Repository, cfg ConsumerConfig) (*Consumer, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("log repository is nil")
+	}
+
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	if batchSize > maxBatchSize {
+		batchSize = maxBatchSize
+	}
+
+	flushInterval := cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+
+	b := &batcher{
+		repo:          repo,
+		size:          batchSize,
+		flushInterval: flushInterval,
+		requests:      make(chan pendingEntry),
+	}
+
+	consumer, err := mq.NewConsumer(conn, mq.ConsumerConfig{
+		Queue:       Queue,
+		Tag:         consumerTag,
+		Concurrency: batchSize,
+	}, b.handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Consumer{consumer: consumer, batcher: b}, nil
+}
+
+// Run consumes entries until ctx is done. Each handler waits for the batch holding its entry to be inserted,
+// which happens when the batch is full or FlushInterval has passed. Entries are keyed by their envelope ID, so a
+// message requeued after its entry was inserted is not inserted again. A batch that keeps failing is retried
+// entry by entry; a failed entry is requeued once and then dead-lettered. On shutdown the in-flight entries
+// are flushed before Run returns.
+func (c *Consumer) Run(ctx context.Context) error {
+	batchCtx, stop := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		c.batcher.run(batchCtx)
+	}()
+
+	err := c.consumer.Run(ctx)
+
+	stop()
+	<-stopped
+
+	return err
+}
+
+type pendingEntry struct {
+	entry  dao.Log
+	result chan error
+}
+
+// batcher collects entries from concurrent handlers and inserts them together.
+type batcher struct {
+	repo          loginterfaces.Repository
+	size          int
+	flushInterval time.Duration
+	requests      chan pendingEntry
+}
+
+func (b *batcher) handle(ctx context.Context, delivery amqp.Delivery) error {
+	envelope, err := mq.DecodeEnvelope[message](delivery, MessageType)
+	if err != nil {
+		return err
+	}
+
+	entry := envelope.Payload.toDAO()
+	entry.MessageID = envelope.ID
+
+	pending := pendingEntry{entry: entry, result: make(chan error, 1)}
+
+	select {
+	case b.requests <- pending:
+	case <-ctx.Done():
+		return mq.Requeue(ctx.Err())
+	}
+
+	select {
+	case err := <-pending.result:
+		if err != nil {
+			return mq.Requeue(err)
+		}
+		return nil
+	case <-ctx.Done():
+		return mq.Requeue(ctx.Err())
+	}
+}
+
+// run batches entries until ctx is done. The consumer has drained its handlers by then, so nothing is left
+// to flush.
+func (b *batcher) run(ctx context.Context) {
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]pendingEntry, 0, b.size)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case pending := <-b.requests:
+			batch = append(batch, pending)
+			if len(batch) >= b.size {
+				b.flush(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			b.flush(ctx, batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (b *batcher) flush(ctx context.Context, batch []pendingEntry) {
+	if len(batch) == 0 {
+		return
+	}
+
+	entries := make([]dao.Log, 0, len(batch))
+	for _, pending := range batch {
+		entries = append(entries, pending.entry)
+	}
+
+	err := b.insert(ctx, entries)
+	if err == nil {
+		for _, pending := range batch {
+			pending.result <- nil
+		}
+		return
+	}
+
+	log.Printf("insert batch of %d queued logs: %v; retrying one by one", len(entries), err)
+	for _, pending := range batch {
+		pending.result <- b.repo.Create(ctx, pending.entry)
+	}
+}
+
+func (b *batcher) insert(ctx context.Context, entries []dao.Log) error {
+	var err error
+	for attempt := 0; attempt < insertAttempts; attempt++ {
+		if attempt > 0 {
+			if waitErr := insertBackoff.Wait(ctx, attempt-1); waitErr != nil {
+				return err
+			}
+		}
+
+		if err = b.repo.CreateBatch(ctx, entries); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,56 @@
+package ingest
+
+import (
+	"encoding/json"
+	"time"
+
+	"gobackend/infra/mq"
+	"gobackend/src/logs/dao"
+)
+
+// MessageType is the envelope type of queued log entries.
+const MessageType = "user_log.recorded"
+
+// message is the envelope payload of a queued log entry.
+type message struct {
+	UserID    int64           `json:"user_id"`
+	Action    string          `json:"action"`
+	Detail    string          `json:"detail"`
+	Metadata  json.RawMessage `json:"metadata,omitempty"`
+	ActorID   int64           `json:"actor_id,omitempty"`
+	TargetID  int64           `json:"target_id,omitempty"`
+	IPAddress string          `json:"ip_address,omitempty"`
+	UserAgent string          `json:"user_agent,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func newMessage(entry dao.Log) (mq.Envelope[message], error) {
+	return mq.NewEnvelope(MessageType, message{
+		UserID:    entry.UserID,
+		Action:    entry.Action,
+		Detail:    entry.Detail,
+		Metadata:  json.RawMessage(entry.Metadata),
+		ActorID:   entry.ActorID,
+		TargetID:  entry.TargetID,
+		IPAddress: entry.IPAddress,
+		UserAgent: entry.UserAgent,
+		RequestID: entry.RequestID,
+		CreatedAt: entry.CreatedAt,
+	})
+}
+
+func (msg message) toDAO() dao.Log {
+	return dao.Log{
+		UserID:    msg.UserID,
+		Action:    msg.Action,
+		Detail:    msg.Detail,
+		Metadata:  []byte(msg.Metadata),
+		ActorID:   msg.ActorID,
+		TargetID:  msg.TargetID,
+		IPAddress: msg.IPAddress,
+		UserAgent: msg.UserAgent,
+		RequestID: msg.RequestID,
+		CreatedAt: msg.CreatedAt,
+	}
+}
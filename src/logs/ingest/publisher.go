@@ -0,0 +1,67 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gobackend/infra/mq"
+	"gobackend/src/logs/dao"
+	loginterfaces "gobackend/src/logs/interfaces"
+)
+
+const (
+	publishAttempts = 3
+	publishTimeout  = 5 * time.Second
+)
+
+var publishBackoff = mq.Backoff{Min: 100 * time.Millisecond, Max: time.Second}
+
+var _ loginterfaces.Publisher = (*Publisher)(nil)
+
+// Publisher queues log entries as persistent messages and waits for the broker to confirm them.
+type Publisher struct {
+	publisher *mq.Publisher
+}
+
+// NewPublisher returns a Publisher sending through publisher. The caller declares Topology on the connection.
+func NewPublisher(publisher *mq.Publisher) (*Publisher, error) {
+	if publisher == nil {
+		return nil, fmt.Errorf("rabbitmq publisher is nil")
+	}
+
+	return &Publisher{publisher: publisher}, nil
+}
+
+// Publish queues entry, retrying with backoff when the broker does not confirm it in time.
+func (p *Publisher) Publish(ctx context.Context, entry dao.Log) error {
+	msg, err := newMessage(entry)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = p.publish(ctx, msg)
+		if err == nil || errors.Is(err, mq.ErrPublisherClosed) || attempt+1 == publishAttempts {
+			break
+		}
+
+		if err := publishBackoff.Wait(ctx, attempt); err != nil {
+			return err
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("publish log message: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) publish(ctx context.Context, msg mq.Publishable) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	return p.publisher.PublishMessage(ctx, Exchange, RoutingKey, msg)
+}
@@ -0,0 +1,32 @@
+package ingest
+
+import (
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"gobackend/infra/mq"
+)
+
+// RabbitMQ names used by log ingestion. Entries that cannot be stored are dead-lettered to DeadLetterQueue.
+const (
+	Exchange           = "user_logs"
+	Queue              = "user_logs.ingest"
+	RoutingKey         = "user_log"
+	DeadLetterExchange = "user_logs.dead"
+	DeadLetterQueue    = "user_logs.dead"
+)
+
+// Topology is the set of exchanges and queues used by log ingestion.
+var Topology = mq.Topology{
+	Exchanges: []mq.Exchange{
+		{Name: Exchange, Kind: amqp.ExchangeDirect},
+		{Name: DeadLetterExchange, Kind: amqp.ExchangeFanout},
+	},
+	Queues: []mq.Queue{
+		{Name: Queue, DeadLetterExchange: DeadLetterExchange},
+		{Name: DeadLetterQueue},
+	},
+	Bindings: []mq.Binding{
+		{Queue: Queue, Exchange: Exchange, Key: RoutingKey},
+		{Queue: DeadLetterQueue, Exchange: DeadLetterExchange},
+	},
+}
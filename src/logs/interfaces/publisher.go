@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"gobackend/src/logs/dao"
+)
+
+// Publisher hands log entries to the ingestion queue; the ingestion worker writes them to the repository.
+type Publisher interface {
+	Publish(ctx context.Context, entry dao.Log) error
+}
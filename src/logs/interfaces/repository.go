@@ -5,11 +5,13 @@ import (
 
 	"gobackend/shared/pagination"
 	"gobackend/src/logs/dao"
+	"gobackend/src/logs/dto"
 )
 
 // Repository describes persistence layer for user logs.
 type Repository interface {
-	FindAll(ctx context.Context, params pagination.Params, userID *int64) ([]dao.Log, int64, error)
+	FindAll(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dao.Log, int64, error)
 	Create(ctx context.Context, entry dao.Log) error
+	CreateBatch(ctx context.Context, entries []dao.Log) error
 	EnsureSchema(ctx context.Context) error
 }
@@ -9,6 +9,6 @@ import (
 
 // Service defines operations for user logs.
 type Service interface {
-	ListLogs(ctx context.Context, params pagination.Params, userID *int64) ([]dto.Log, int64, error)
+	ListLogs(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dto.Log, int64, error)
 	Record(ctx context.Context, entry dto.NewLog) error
 }
@@ -5,9 +5,15 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/lib/pq"
+
+	"gobackend/shared/dbtx"
 	"gobackend/shared/pagination"
+	"gobackend/shared/utils"
 	"gobackend/src/logs/dao"
+	"gobackend/src/logs/dto"
 	loginterfaces "gobackend/src/logs/interfaces"
 )
 
@@ -65,41 +71,87 @@ WHERE schemaname = 'public' AND indexname = 'user_logs_created_at_idx'
 		return err
 	}
 
+	const metadataColumnQuery = `
+SELECT 1
+FROM information_schema.columns
+WHERE table_schema = 'public' AND table_name = 'user_logs' AND column_name = 'metadata'
+`
+
+	if err := r.db.QueryRowContext(ctx, metadataColumnQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user_logs.metadata column not found; please run database migrations")
+		}
+		return err
+	}
+
+	const messageIdxQuery = `
+SELECT 1
+FROM pg_indexes
+WHERE schemaname = 'public' AND indexname = 'user_logs_message_id_idx'
+`
+
+	if err := r.db.QueryRowContext(ctx, messageIdxQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("index user_logs_message_id_idx not found; please run database migrations")
+		}
+		return err
+	}
+
+	const nullableUserColumnQuery = `
+SELECT 1
+FROM information_schema.columns
+WHERE table_schema = 'public' AND table_name = 'user_logs' AND column_name = 'user_id' AND is_nullable = 'YES'
+`
+
+	if err := r.db.QueryRowContext(ctx, nullableUserColumnQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user_logs.user_id column is not nullable; please run database migrations")
+		}
+		return err
+	}
+
 	return nil
 }
 
-// FindAll retrieves logs using pagination parameters and optional filters, returning the total count.
-func (r *PostgresRepository) FindAll(ctx context.Context, params pagination.Params, userID *int64) ([]dao.Log, int64, error) {
-	baseQuery := `
-SELECT l.id,
-       l.user_id,
-       COALESCE(u.name, ''),
-       l.action,
-       COALESCE(l.detail, ''),
-       l.created_at
-FROM user_logs l
-LEFT JOIN users u ON u.id = l.user_id`
-
-	countQuery := `SELECT COUNT(*) FROM user_logs`
+// FindAll retrieves logs matching the filter using pagination parameters, returning the total count.
+func (r *PostgresRepository) FindAll(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dao.Log, int64, error) {
+	whereClause, args := buildFilter(filter)
 
-	var (
-		whereClause string
-		args        []interface{}
-		countArgs   []interface{}
-	)
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM user_logs l"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
 
-	if userID != nil {
-		whereClause = " WHERE l.user_id = $1"
-		countQuery += " WHERE user_id = $1"
-		args = append(args, *userID)
-		countArgs = append(countArgs, *userID)
+	direction := "DESC"
+	if filter.Ascending {
+		direction = "ASC"
 	}
 
 	limitPlaceholder := len(args) + 1
 	offsetPlaceholder := len(args) + 2
 	args = append(args, params.Limit(), params.Offset())
 
-	query := fmt.Sprintf("%s%s ORDER BY l.created_at DESC LIMIT $%d OFFSET $%d", baseQuery, whereClause, limitPlaceholder, offsetPlaceholder)
+	query := fmt.Sprintf(`
+SELECT l.id,
+       COALESCE(l.user_id, 0),
+       COALESCE(u.name, ''),
+       l.action,
+       COALESCE(l.detail, ''),
+       l.metadata,
+       COALESCE(l.actor_id, l.user_id, 0),
+       COALESCE(a.name, ''),
+       COALESCE(l.target_id, 0),
+       COALESCE(t.name, ''),
+       COALESCE(l.ip_address, ''),
+       COALESCE(l.user_agent, ''),
+       COALESCE(l.request_id, ''),
+       l.created_at
+FROM user_logs l
+LEFT JOIN users u ON u.id = l.user_id
+LEFT JOIN users a ON a.id = COALESCE(l.actor_id, l.user_id)
+LEFT JOIN users t ON t.id = l.target_id%s
+ORDER BY l.created_at %s, l.id %s
+LIMIT $%d OFFSET $%d`, whereClause, direction, direction, limitPlaceholder, offsetPlaceholder)
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -109,41 +161,136 @@ LEFT JOIN users u ON u.id = l.user_id`
 
 	var logs []dao.Log
 	for rows.Next() {
-		var (
-			log      dao.Log
-			userName string
-		)
-
-		if err := rows.Scan(&log.ID, &log.UserID, &userName, &log.Action, &log.Detail, &log.CreatedAt); err != nil {
+		var log dao.Log
+		if err := rows.Scan(
+			&log.ID,
+			&log.UserID,
+			&log.UserName,
+			&log.Action,
+			&log.Detail,
+			&log.Metadata,
+			&log.ActorID,
+			&log.ActorName,
+			&log.TargetID,
+			&log.TargetName,
+			&log.IPAddress,
+			&log.UserAgent,
+			&log.RequestID,
+			&log.CreatedAt,
+		); err != nil {
 			return nil, 0, err
 		}
-		log.UserName = userName
 		logs = append(logs, log)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, 0, err
 	}
 
-	var total int64
-	if len(countArgs) > 0 {
-		if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
-			return nil, 0, err
-		}
-	} else {
-		if err := r.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
-			return nil, 0, err
-		}
+	return logs, total, nil
+}
+
+func buildFilter(filter dto.Filter) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
 	}
 
-	return logs, total, nil
+	if filter.UserID != 0 {
+		add("l.user_id = $%d", filter.UserID)
+	}
+	if len(filter.Actions) > 0 {
+		add("l.action = ANY($%d)", pq.Array(filter.Actions))
+	}
+	if !filter.From.IsZero() {
+		add("l.created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("l.created_at < $%d", filter.To)
+	}
+	if filter.Detail != "" {
+		add("l.detail ILIKE $%d ESCAPE '\\'", "%"+utils.EscapeLike(filter.Detail)+"%")
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
 }
 
-// Create inserts a new log entry.
+// Create inserts a single log entry.
 func (r *PostgresRepository) Create(ctx context.Context, entry dao.Log) error {
-	const query = `
-INSERT INTO user_logs (user_id, action, detail)
-VALUES ($1, $2, $3)
-`
-	_, err := r.db.ExecContext(ctx, query, entry.UserID, entry.Action, entry.Detail)
+	return r.CreateBatch(ctx, []dao.Log{entry})
+}
+
+// CreateBatch inserts entries with a single statement, inside the transaction carried by ctx when there is one.
+// Entries without CreatedAt are stamped by the database, and entries without UserID belong to no activity log.
+// Entries whose MessageID is already stored are skipped.
+func (r *PostgresRepository) CreateBatch(ctx context.Context, entries []dao.Log) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	const columns = 11
+
+	var query strings.Builder
+	query.WriteString(`
+INSERT INTO user_logs (user_id, action, detail, metadata, actor_id, target_id, ip_address, user_agent, request_id, created_at, message_id)
+VALUES `)
+
+	args := make([]interface{}, 0, len(entries)*columns)
+	for i, entry := range entries {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+
+		n := i * columns
+		fmt.Fprintf(
+			&query,
+			"($%d, $%d, $%d, $%d, $%d, $%d, NULLIF($%d, ''), NULLIF($%d, ''), NULLIF($%d, ''), COALESCE($%d, NOW()), NULLIF($%d, ''))",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10, n+11,
+		)
+
+		metadata := entry.Metadata
+		if len(metadata) == 0 {
+			metadata = []byte("{}")
+		}
+
+		var createdAt interface{}
+		if !entry.CreatedAt.IsZero() {
+			createdAt = entry.CreatedAt
+		}
+
+		args = append(
+			args,
+			nullableUserID(entry.UserID),
+			entry.Action,
+			entry.Detail,
+			string(metadata),
+			nullableUserID(entry.ActorID),
+			nullableUserID(entry.TargetID),
+			entry.IPAddress,
+			entry.UserAgent,
+			entry.RequestID,
+			createdAt,
+			entry.MessageID,
+		)
+	}
+	query.WriteString(" ON CONFLICT (message_id) DO NOTHING")
+
+	_, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, query.String(), args...)
 	return err
 }
+
+func nullableUserID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+
+	return id
+}
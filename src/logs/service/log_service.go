@@ -2,8 +2,14 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
 
+	"gobackend/shared/dbtx"
 	"gobackend/shared/pagination"
+	"gobackend/shared/requestinfo"
 	"gobackend/src/logs/dao"
 	"gobackend/src/logs/dto"
 	loginterfaces "gobackend/src/logs/interfaces"
@@ -11,42 +17,100 @@ import (
 
 var _ loginterfaces.Service = (*LogService)(nil)
 
-// LogService provides read operations for user logs.
+// LogService provides read and write operations for user logs.
 type LogService struct {
-	repo loginterfaces.Repository
+	repo      loginterfaces.Repository
+	publisher loginterfaces.Publisher
+	now       func() time.Time
 }
 
-// NewLogService constructs a new LogService.
-func NewLogService(repo loginterfaces.Repository) *LogService {
-	return &LogService{repo: repo}
+// NewLogService constructs a new LogService. When publisher is not nil, recorded entries are queued for the
+// ingestion worker instead of being written to the repository by the caller.
+func NewLogService(repo loginterfaces.Repository, publisher loginterfaces.Publisher) *LogService {
+	return &LogService{repo: repo, publisher: publisher, now: time.Now}
 }
 
-// ListLogs fetches logs and maps them to DTOs.
-func (s *LogService) ListLogs(ctx context.Context, params pagination.Params, userID *int64) ([]dto.Log, int64, error) {
-	logs, total, err := s.repo.FindAll(ctx, params, userID)
+// ListLogs fetches logs matching the filter and maps them to DTOs.
+func (s *LogService) ListLogs(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dto.Log, int64, error) {
+	logs, total, err := s.repo.FindAll(ctx, params, filter)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	result := make([]dto.Log, 0, len(logs))
 	for _, entry := range logs {
+		metadata := dto.Metadata{}
+		if len(entry.Metadata) > 0 {
+			if err := json.Unmarshal(entry.Metadata, &metadata); err != nil {
+				return nil, 0, fmt.Errorf("decode metadata of log %d: %w", entry.ID, err)
+			}
+		}
+
 		result = append(result, dto.Log{
-			UserName:  entry.UserName,
-			Action:    entry.Action,
-			Detail:    entry.Detail,
-			CreatedAt: entry.CreatedAt,
+			UserName:   entry.UserName,
+			Action:     dto.Action(entry.Action),
+			Detail:     entry.Detail,
+			Metadata:   metadata,
+			ActorName:  entry.ActorName,
+			TargetName: entry.TargetName,
+			IPAddress:  entry.IPAddress,
+			UserAgent:  entry.UserAgent,
+			RequestID:  entry.RequestID,
+			CreatedAt:  entry.CreatedAt,
 		})
 	}
 
 	return result, total, nil
 }
 
-// Record stores a new log entry.
+// Record stores a new log entry, adding the client details of the request in ctx when there is one.
+// Entries recorded inside a transaction are written in it, so they are only kept if it commits. Entries
+// that cannot be queued are written to the repository directly so they are not lost.
 func (s *LogService) Record(ctx context.Context, entry dto.NewLog) error {
+	if entry.Action == "" {
+		return fmt.Errorf("log action is required")
+	}
+
+	var metadata []byte
+	if len(entry.Metadata) > 0 {
+		encoded, err := json.Marshal(entry.Metadata)
+		if err != nil {
+			return fmt.Errorf("encode log metadata: %w", err)
+		}
+		metadata = encoded
+	}
+
+	actorID := entry.ActorID
+	if actorID == 0 {
+		actorID = entry.UserID
+	}
+
 	daoEntry := dao.Log{
-		UserID: entry.UserID,
-		Action: entry.Action,
-		Detail: entry.Detail,
+		UserID:    entry.UserID,
+		Action:    string(entry.Action),
+		Detail:    entry.Detail,
+		Metadata:  metadata,
+		ActorID:   actorID,
+		TargetID:  entry.TargetID,
+		CreatedAt: s.now(),
+	}
+
+	if info, ok := requestinfo.FromContext(ctx); ok {
+		daoEntry.IPAddress = info.IP
+		daoEntry.UserAgent = info.UserAgent
+		daoEntry.RequestID = info.RequestID
+	}
+
+	if _, inTx := dbtx.FromContext(ctx); inTx {
+		return s.repo.Create(ctx, daoEntry)
+	}
+
+	if s.publisher != nil {
+		err := s.publisher.Publish(ctx, daoEntry)
+		if err == nil {
+			return nil
+		}
+		log.Printf("queue %s log for user %d, writing it directly: %v", daoEntry.Action, daoEntry.UserID, err)
 	}
 
 	return s.repo.Create(ctx, daoEntry)
@@ -0,0 +1,121 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gobackend/src/logs/dto"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	maxActions      = 20
+	maxDetailLength = 200
+
+	sortAscending  = "created_at"
+	sortDescending = "-created_at"
+)
+
+var actionPattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+var (
+	// ErrInvalidAction indicates an action is not a lowercase identifier such as role_granted.
+	ErrInvalidAction = errors.New("action must be a lowercase identifier such as role_granted")
+	// ErrTooManyActions indicates more actions were requested than a single query may list.
+	ErrTooManyActions = errors.New("at most 20 actions can be requested")
+	// ErrInvalidDetail indicates the detail search is too long.
+	ErrInvalidDetail = errors.New("detail must not exceed 200 characters")
+	// ErrInvalidTime indicates a time bound is neither YYYY-MM-DD nor RFC 3339.
+	ErrInvalidTime = errors.New("from and to must be YYYY-MM-DD or RFC 3339 timestamps")
+	// ErrInvalidTimeRange indicates the range ends before it starts.
+	ErrInvalidTimeRange = errors.New("to must not be before from")
+	// ErrInvalidSort indicates an unsupported sort order.
+	ErrInvalidSort = errors.New("sort must be created_at or -created_at")
+)
+
+// FilterQuery carries the raw query values of a log listing.
+type FilterQuery struct {
+	// Actions holds every action query value; each may list several actions separated by commas.
+	Actions []string
+	From    string
+	To      string
+	Detail  string
+	Sort    string
+}
+
+// ParseFilter builds a Filter from raw query values, ignoring empty ones. A date without a time covers the whole
+// day in UTC, so to=2024-05-31 includes entries written on May 31.
+func ParseFilter(query FilterQuery) (dto.Filter, error) {
+	filter := dto.Filter{Detail: strings.TrimSpace(query.Detail)}
+	if len(filter.Detail) > maxDetailLength {
+		return dto.Filter{}, ErrInvalidDetail
+	}
+
+	seen := make(map[string]struct{})
+	for _, raw := range query.Actions {
+		for _, action := range strings.Split(raw, ",") {
+			action = strings.TrimSpace(action)
+			if action == "" {
+				continue
+			}
+			if !actionPattern.MatchString(action) {
+				return dto.Filter{}, fmt.Errorf("%w: %q", ErrInvalidAction, action)
+			}
+			if _, ok := seen[action]; ok {
+				continue
+			}
+			seen[action] = struct{}{}
+			filter.Actions = append(filter.Actions, dto.Action(action))
+		}
+	}
+	if len(filter.Actions) > maxActions {
+		return dto.Filter{}, ErrTooManyActions
+	}
+
+	var err error
+	if filter.From, err = parseBound(query.From, false); err != nil {
+		return dto.Filter{}, err
+	}
+	if filter.To, err = parseBound(query.To, true); err != nil {
+		return dto.Filter{}, err
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return dto.Filter{}, ErrInvalidTimeRange
+	}
+
+	switch strings.TrimSpace(query.Sort) {
+	case "", sortDescending:
+	case sortAscending:
+		filter.Ascending = true
+	default:
+		return dto.Filter{}, ErrInvalidSort
+	}
+
+	return filter, nil
+}
+
+// parseBound parses a date or timestamp. When end is set, a date without a time is moved to the next day so the
+// exclusive end still covers it.
+func parseBound(raw string, end bool) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	if day, err := time.Parse(dateLayout, raw); err == nil {
+		if end {
+			return day.AddDate(0, 0, 1), nil
+		}
+		return day, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTime, raw)
+	}
+
+	return value, nil
+}
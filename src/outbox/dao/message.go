@@ -0,0 +1,17 @@
+package dao
+
+import "time"
+
+// Message is a row of the outbox table. Payload holds the encoded message envelope.
+type Message struct {
+	ID            int64     `json:"id"`
+	MessageID     string    `json:"message_id"`
+	EventType     string    `json:"event_type"`
+	RoutingKey    string    `json:"routing_key"`
+	Payload       []byte    `json:"payload"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	SentAt        time.Time `json:"sent_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// Domain event types. They are also the routing keys on the domain events exchange.
+const (
+	EventUserCreated    = "user.created"
+	EventUserLoggedIn   = "user.logged_in"
+	EventReviewAnswered = "review.answered"
+)
+
+// Event is a domain event to publish once the transaction recording it commits.
+type Event struct {
+	Type string
+	// Payload is encoded as JSON in the message envelope.
+	Payload interface{}
+}
+
+// UserCreated is the payload of EventUserCreated.
+type UserCreated struct {
+	UserID    int64     `json:"user_id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Provider  string    `json:"provider"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserLoggedIn is the payload of EventUserLoggedIn.
+type UserLoggedIn struct {
+	UserID     int64     `json:"user_id"`
+	Provider   string    `json:"provider"`
+	LoggedInAt time.Time `json:"logged_in_at"`
+}
+
+// ReviewAnswered is the payload of EventReviewAnswered.
+type ReviewAnswered struct {
+	UserID       int64     `json:"user_id"`
+	ItemType     string    `json:"item_type"`
+	ItemKey      string    `json:"item_key"`
+	Rating       string    `json:"rating"`
+	Scheduler    string    `json:"scheduler"`
+	IntervalDays float64   `json:"interval_days"`
+	DueAt        time.Time `json:"due_at"`
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"gobackend/src/outbox/dto"
+)
+
+// Outbox records domain events for publishing. Call Add inside the transaction of the change the event
+// describes so the event is stored if and only if the change commits.
+type Outbox interface {
+	Add(ctx context.Context, event dto.Event) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"gobackend/src/outbox/dao"
+)
+
+// Repository describes persistence of outbox messages. Every method joins the transaction carried by ctx.
+type Repository interface {
+	Add(ctx context.Context, message dao.Message) error
+	// ClaimPending leases up to limit unsent messages that are due at now by moving their next attempt to
+	// leaseUntil, so concurrent relays skip them until the lease runs out.
+	ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]dao.Message, error)
+	MarkSent(ctx context.Context, id int64, sentAt time.Time) error
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	// DeleteSentBefore removes messages sent before the cutoff and returns how many were removed.
+	DeleteSentBefore(ctx context.Context, before time.Time) (int64, error)
+	EnsureSchema(ctx context.Context) error
+}
@@ -0,0 +1,190 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"gobackend/infra/mq"
+	"gobackend/src/outbox/dao"
+	outboxinterfaces "gobackend/src/outbox/interfaces"
+)
+
+// Exchange is the topic exchange domain events are published to, with their type as routing key.
+const Exchange = "domain_events"
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+	defaultRetention    = 7 * 24 * time.Hour
+	batchTimeout        = 30 * time.Second
+	publishTimeout      = 5 * time.Second
+	// claimLease is how long claimed messages are hidden from other relays. It outlasts a batch, so a message
+	// is only claimed again when the relay that claimed it stopped before marking it.
+	claimLease    = 2 * batchTimeout
+	purgeInterval = time.Hour
+)
+
+var defaultBackoff = mq.Backoff{Min: time.Second, Max: 5 * time.Minute}
+
+// Topology declares the domain events exchange. Consumers declare and bind their own queues.
+var Topology = mq.Topology{
+	Exchanges: []mq.Exchange{
+		{Name: Exchange, Kind: amqp.ExchangeTopic},
+	},
+}
+
+// Config tunes a Relay. Zero values use the defaults.
+type Config struct {
+	// BatchSize is the most messages claimed per transaction.
+	BatchSize int
+	// PollInterval is how often the outbox is checked once it has been emptied.
+	PollInterval time.Duration
+	// Backoff spaces out retries of a message, by the number of attempts it has had.
+	Backoff mq.Backoff
+	// Retention is how long sent messages are kept before they are purged.
+	Retention time.Duration
+}
+
+// Relay publishes pending outbox messages and marks them sent. Delivery is at least once: a message can be
+// published again if the relay stops between publishing it and marking it sent.
+type Relay struct {
+	repo      outboxinterfaces.Repository
+	publisher *mq.Publisher
+	cfg       Config
+	now       func() time.Time
+}
+
+// NewRelay constructs a Relay. The caller declares Topology on the publisher's connection.
+func NewRelay(repo outboxinterfaces.Repository, publisher *mq.Publisher, cfg Config) (*Relay, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("outbox repository is nil")
+	}
+
+	if publisher == nil {
+		return nil, fmt.Errorf("rabbitmq publisher is nil")
+	}
+
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.Backoff == (mq.Backoff{}) {
+		cfg.Backoff = defaultBackoff
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaultRetention
+	}
+
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		cfg:       cfg,
+		now:       time.Now,
+	}, nil
+}
+
+// Run relays batches until ctx is done. Full batches are followed immediately by the next one; otherwise the
+// relay waits PollInterval. Sent messages older than Retention are purged at start and then hourly. A batch in
+// progress at shutdown is finished before Run returns.
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	purgeTicker := time.NewTicker(purgeInterval)
+	defer purgeTicker.Stop()
+
+	r.purgeSent(ctx)
+
+	for {
+		relayed, err := r.relayBatch(ctx)
+		if err != nil {
+			log.Printf("relay outbox messages: %v", err)
+		}
+
+		if err == nil && relayed == r.cfg.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-purgeTicker.C:
+			r.purgeSent(ctx)
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayBatch claims one batch and publishes it message by message, marking each message as soon as the broker
+// has answered, so no transaction or row lock is held while publishing. It stops at the first message that
+// cannot be published, since the broker is likely unavailable, or when the batch runs out of time; claimed
+// messages it did not reach are retried once their lease runs out. It returns how many messages were sent.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), batchTimeout)
+	defer cancel()
+
+	now := r.now()
+	messages, err := r.repo.ClaimPending(ctx, now, now.Add(claimLease), r.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim pending messages: %w", err)
+	}
+
+	sent := 0
+	for _, message := range messages {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if err := r.publish(ctx, message); err != nil {
+			next := r.now().Add(r.cfg.Backoff.Delay(message.Attempts))
+			log.Printf("publish outbox message %d (%s, attempt %d): %v", message.ID, message.EventType, message.Attempts+1, err)
+
+			if err := r.repo.MarkFailed(ctx, message.ID, err.Error(), next); err != nil {
+				return sent, fmt.Errorf("mark message %d failed: %w", message.ID, err)
+			}
+			return sent, nil
+		}
+
+		if err := r.repo.MarkSent(ctx, message.ID, r.now()); err != nil {
+			return sent, fmt.Errorf("mark message %d sent: %w", message.ID, err)
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// purgeSent deletes sent messages older than Retention. A failure is logged and retried at the next purge.
+func (r *Relay) purgeSent(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), batchTimeout)
+	defer cancel()
+
+	purged, err := r.repo.DeleteSentBefore(ctx, r.now().Add(-r.cfg.Retention))
+	if err != nil {
+		log.Printf("purge sent outbox messages: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("purged %d sent outbox messages", purged)
+	}
+}
+
+func (r *Relay) publish(ctx context.Context, message dao.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	return r.publisher.Publish(ctx, Exchange, message.RoutingKey, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    message.MessageID,
+		Type:         message.EventType,
+		Timestamp:    message.CreatedAt,
+		Body:         message.Payload,
+	})
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"gobackend/shared/dbtx"
+	"gobackend/src/outbox/dao"
+	outboxinterfaces "gobackend/src/outbox/interfaces"
+)
+
+var _ outboxinterfaces.Repository = (*PostgresRepository)(nil)
+
+// PostgresRepository persists outbox messages in Postgres.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new outbox repository.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// EnsureSchema verifies that required tables and indexes exist.
+func (r *PostgresRepository) EnsureSchema(ctx context.Context) error {
+	const tableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = 'outbox'
+`
+
+	var exists int
+	if err := r.db.QueryRowContext(ctx, tableQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("outbox table not found; please run database migrations")
+		}
+		return err
+	}
+
+	const indexQuery = `
+SELECT 1
+FROM pg_indexes
+WHERE schemaname = 'public' AND tablename = 'outbox' AND indexname = 'outbox_pending_idx'
+`
+
+	if err := r.db.QueryRowContext(ctx, indexQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("index outbox_pending_idx not found; please run database migrations")
+		}
+		return err
+	}
+
+	const sentIndexQuery = `
+SELECT 1
+FROM pg_indexes
+WHERE schemaname = 'public' AND tablename = 'outbox' AND indexname = 'outbox_sent_at_idx'
+`
+
+	if err := r.db.QueryRowContext(ctx, sentIndexQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("index outbox_sent_at_idx not found; please run database migrations")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Add inserts a message, inside the transaction carried by ctx when there is one.
+func (r *PostgresRepository) Add(ctx context.Context, message dao.Message) error {
+	const query = `
+INSERT INTO outbox (message_id, event_type, routing_key, payload)
+VALUES ($1, $2, $3, $4)
+`
+
+	_, err := dbtx.Conn(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		message.MessageID,
+		message.EventType,
+		message.RoutingKey,
+		string(message.Payload),
+	)
+	return err
+}
+
+// ClaimPending leases up to limit unsent messages due at now, oldest first, by moving their next attempt to
+// leaseUntil in one statement. Rows being claimed by another relay are skipped. The messages are returned in ID
+// order.
+func (r *PostgresRepository) ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]dao.Message, error) {
+	const query = `
+WITH due AS (
+    SELECT id
+    FROM outbox
+    WHERE sent_at IS NULL AND next_attempt_at <= $1
+    ORDER BY next_attempt_at, id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+), claimed AS (
+    UPDATE outbox
+    SET next_attempt_at = $3
+    FROM due
+    WHERE outbox.id = due.id
+    RETURNING outbox.id, outbox.message_id, outbox.event_type, outbox.routing_key, outbox.payload,
+              outbox.attempts, COALESCE(outbox.last_error, '') AS last_error, outbox.next_attempt_at, outbox.created_at
+)
+SELECT id, message_id, event_type, routing_key, payload, attempts, last_error, next_attempt_at, created_at
+FROM claimed
+ORDER BY id
+`
+
+	rows, err := dbtx.Conn(ctx, r.db).QueryContext(ctx, query, now, limit, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []dao.Message
+	for rows.Next() {
+		var message dao.Message
+		if err := rows.Scan(
+			&message.ID,
+			&message.MessageID,
+			&message.EventType,
+			&message.RoutingKey,
+			&message.Payload,
+			&message.Attempts,
+			&message.LastError,
+			&message.NextAttemptAt,
+			&message.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// MarkSent records that the message was published.
+func (r *PostgresRepository) MarkSent(ctx context.Context, id int64, sentAt time.Time) error {
+	const query = `
+UPDATE outbox
+SET sent_at = $2, attempts = attempts + 1, last_error = NULL
+WHERE id = $1
+`
+
+	_, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, query, id, sentAt)
+	return err
+}
+
+// DeleteSentBefore removes messages that were sent before the cutoff.
+func (r *PostgresRepository) DeleteSentBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, "DELETE FROM outbox WHERE sent_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// MarkFailed records a failed publish and when to try again.
+func (r *PostgresRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	const query = `
+UPDATE outbox
+SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+WHERE id = $1
+`
+
+	_, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, query, id, lastError, nextAttemptAt)
+	return err
+}
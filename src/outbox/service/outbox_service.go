@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gobackend/infra/mq"
+	"gobackend/src/outbox/dao"
+	"gobackend/src/outbox/dto"
+	outboxinterfaces "gobackend/src/outbox/interfaces"
+)
+
+var _ outboxinterfaces.Outbox = (*OutboxService)(nil)
+
+// OutboxService stores domain events as message envelopes for the relay to publish.
+type OutboxService struct {
+	repo outboxinterfaces.Repository
+}
+
+// NewOutboxService constructs a new OutboxService.
+func NewOutboxService(repo outboxinterfaces.Repository) *OutboxService {
+	return &OutboxService{repo: repo}
+}
+
+// Add wraps event in an envelope and stores it. It joins the transaction carried by ctx, so the event is
+// only published if that transaction commits.
+func (s *OutboxService) Add(ctx context.Context, event dto.Event) error {
+	if event.Type == "" {
+		return fmt.Errorf("event type is required")
+	}
+
+	envelope, err := mq.NewEnvelope(event.Type, event.Payload)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", event.Type, err)
+	}
+
+	if err := s.repo.Add(ctx, dao.Message{
+		MessageID:  envelope.ID,
+		EventType:  event.Type,
+		RoutingKey: event.Type,
+		Payload:    payload,
+	}); err != nil {
+		return fmt.Errorf("store %s event: %w", event.Type, err)
+	}
+
+	return nil
+}
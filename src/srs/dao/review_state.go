@@ -0,0 +1,27 @@
+package dao
+
+import "time"
+
+// ReviewState represents the persisted spaced-repetition state of one study item for one user.
+type ReviewState struct {
+	ID             int64     `json:"id"`
+	UserID         int64     `json:"user_id"`
+	ItemType       string    `json:"item_type"`
+	ItemKey        string    `json:"item_key"`
+	Scheduler      string    `json:"scheduler"`
+	Repetitions    int       `json:"repetitions"`
+	Lapses         int       `json:"lapses"`
+	IntervalDays   float64   `json:"interval_days"`
+	EaseFactor     float64   `json:"ease_factor"`
+	Stability      float64   `json:"stability"`
+	Difficulty     float64   `json:"difficulty"`
+	DueAt          time.Time `json:"due_at"`
+	LastReviewedAt time.Time `json:"last_reviewed_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// IsNew reports whether the item has never been reviewed.
+func (s ReviewState) IsNew() bool {
+	return s.LastReviewedAt.IsZero()
+}
@@ -0,0 +1,88 @@
+package delivery
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/pagination"
+	"gobackend/shared/response"
+	"gobackend/src/auth/middleware"
+	"gobackend/src/srs/dto"
+	srsinterfaces "gobackend/src/srs/interfaces"
+	srsservice "gobackend/src/srs/service"
+	"gobackend/src/srs/validation"
+)
+
+// Handler exposes review scheduling HTTP endpoints.
+type Handler struct {
+	service srsinterfaces.Service
+}
+
+// NewHandler builds a Handler instance.
+func NewHandler(service srsinterfaces.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ListDue returns the authenticated user's due reviews, optionally narrowed by the type query parameter.
+func (h *Handler) ListDue(ctx *gin.Context) {
+	userID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	params := pagination.FromQuery(ctx)
+
+	reviews, total, err := h.service.ListDue(ctx.Request.Context(), params, userID, ctx.Query("type"))
+	if err != nil {
+		if errors.Is(err, validation.ErrInvalidItemType) {
+			response.BadRequest(ctx, err.Error(), nil)
+			return
+		}
+
+		response.InternalError(ctx, "failed to list due reviews", err.Error())
+		return
+	}
+
+	meta := pagination.NewMetadata(total, params)
+	response.Paginated(ctx, "due reviews retrieved successfully", reviews, meta)
+}
+
+// Answer records the rating for a reviewed item and returns its next schedule.
+func (h *Handler) Answer(ctx *gin.Context) {
+	userID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	item, err := validation.ParseItem(ctx.Param("item"))
+	if err != nil {
+		response.BadRequest(ctx, err.Error(), nil)
+		return
+	}
+
+	var req dto.AnswerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	review, err := h.service.Answer(ctx.Request.Context(), userID, item, req.Rating)
+	if err != nil {
+		if errors.Is(err, validation.ErrInvalidRating) || errors.Is(err, validation.ErrInvalidItemType) {
+			response.BadRequest(ctx, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, srsservice.ErrItemNotFound) {
+			response.NotFound(ctx, err.Error())
+			return
+		}
+
+		response.InternalError(ctx, "failed to record review answer", err.Error())
+		return
+	}
+
+	response.OK(ctx, "review answer recorded", review)
+}
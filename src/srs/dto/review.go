@@ -0,0 +1,64 @@
+package dto
+
+import "time"
+
+// Item types that can be scheduled for review.
+const (
+	ItemTypeKanji      = "kanji"
+	ItemTypeVocabulary = "vocabulary"
+	ItemTypeGrammar    = "grammar"
+)
+
+// Rating is the learner's self-assessment of a review, using the FSRS four-button scale.
+type Rating int
+
+// Supported ratings.
+const (
+	RatingAgain Rating = 1
+	RatingHard  Rating = 2
+	RatingGood  Rating = 3
+	RatingEasy  Rating = 4
+)
+
+// String returns the lowercase button label of the rating.
+func (r Rating) String() string {
+	switch r {
+	case RatingAgain:
+		return "again"
+	case RatingHard:
+		return "hard"
+	case RatingGood:
+		return "good"
+	case RatingEasy:
+		return "easy"
+	default:
+		return "unknown"
+	}
+}
+
+// Item identifies a study item, e.g. kanji:日, vocabulary:1358280 or grammar:12.
+type Item struct {
+	Type string `json:"type"`
+	Key  string `json:"key"`
+}
+
+// String returns the item in its type:key form.
+func (i Item) String() string {
+	return i.Type + ":" + i.Key
+}
+
+// AnswerRequest is the payload submitted after reviewing an item.
+type AnswerRequest struct {
+	Rating Rating `json:"rating"`
+}
+
+// Review represents the review state exposed via the API.
+type Review struct {
+	Item           Item      `json:"item"`
+	Scheduler      string    `json:"scheduler"`
+	Repetitions    int       `json:"repetitions"`
+	Lapses         int       `json:"lapses"`
+	IntervalDays   float64   `json:"interval_days"`
+	DueAt          time.Time `json:"due_at"`
+	LastReviewedAt time.Time `json:"last_reviewed_at"`
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/srs/dao"
+)
+
+// Repository describes persistence operations for review states.
+type Repository interface {
+	FindByItem(ctx context.Context, userID int64, itemType, itemKey string) (*dao.ReviewState, error)
+	// FindByItemForUpdate is FindByItem that also locks the row until the transaction carried by ctx ends.
+	FindByItemForUpdate(ctx context.Context, userID int64, itemType, itemKey string) (*dao.ReviewState, error)
+	// ItemExists reports whether the item is in its catalog: a kanji character, a JMdict sequence number or a
+	// grammar point ID.
+	ItemExists(ctx context.Context, itemType, itemKey string) (bool, error)
+	FindDue(ctx context.Context, params pagination.Params, userID int64, itemType string, now time.Time) ([]dao.ReviewState, int64, error)
+	Save(ctx context.Context, state dao.ReviewState) (*dao.ReviewState, error)
+	EnsureSchema(ctx context.Context) error
+}
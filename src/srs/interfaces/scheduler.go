@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"time"
+
+	"gobackend/src/srs/dao"
+	"gobackend/src/srs/dto"
+)
+
+// Scheduler computes the next review state of an item from the learner's rating.
+type Scheduler interface {
+	// Name identifies the algorithm; it is stored with each review state.
+	Name() string
+	// Schedule returns the state after a review answered at now. The input state is not modified.
+	Schedule(state dao.ReviewState, rating dto.Rating, now time.Time) dao.ReviewState
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/srs/dto"
+)
+
+// Service exposes spaced-repetition review logic.
+type Service interface {
+	ListDue(ctx context.Context, params pagination.Params, userID int64, itemType string) ([]dto.Review, int64, error)
+	Answer(ctx context.Context, userID int64, item dto.Item, rating dto.Rating) (*dto.Review, error)
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gobackend/shared/dbtx"
+	"gobackend/shared/pagination"
+	"gobackend/src/srs/dao"
+	"gobackend/src/srs/dto"
+	srsinterfaces "gobackend/src/srs/interfaces"
+)
+
+var _ srsinterfaces.Repository = (*PostgresRepository)(nil)
+
+const reviewStateColumns = `id,
+       user_id,
+       item_type,
+       item_key,
+       scheduler,
+       repetitions,
+       lapses,
+       interval_days,
+       ease_factor,
+       stability,
+       difficulty,
+       due_at,
+       last_reviewed_at,
+       created_at,
+       updated_at`
+
+// PostgresRepository persists review states in Postgres.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new review state repository.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// EnsureSchema verifies that required tables and indexes exist.
+func (r *PostgresRepository) EnsureSchema(ctx context.Context) error {
+	const tableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = 'review_states'
+`
+
+	var exists int
+	if err := r.db.QueryRowContext(ctx, tableQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("review_states table not found; please run database migrations")
+		}
+		return err
+	}
+
+	const itemIdxQuery = `
+SELECT 1
+FROM pg_indexes
+WHERE schemaname = 'public' AND indexname = 'review_states_user_item_idx'
+`
+
+	if err := r.db.QueryRowContext(ctx, itemIdxQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("index review_states_user_item_idx not found; please run database migrations")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// catalogQueries check that an item key exists in the catalog of its type.
+var catalogQueries = map[string]string{
+	dto.ItemTypeKanji:      "SELECT 1 FROM kanji WHERE character = $1",
+	dto.ItemTypeVocabulary: "SELECT 1 FROM vocabulary_entries WHERE ent_seq = $1",
+	dto.ItemTypeGrammar:    "SELECT 1 FROM grammar_points WHERE id = $1",
+}
+
+// FindByItem returns the review state of an item for a user, or nil if it was never reviewed.
+func (r *PostgresRepository) FindByItem(ctx context.Context, userID int64, itemType, itemKey string) (*dao.ReviewState, error) {
+	return r.findByItem(ctx, userID, itemType, itemKey, "")
+}
+
+// FindByItemForUpdate returns the review state like FindByItem and locks its row, inside the transaction carried
+// by ctx, until that transaction ends.
+func (r *PostgresRepository) FindByItemForUpdate(ctx context.Context, userID int64, itemType, itemKey string) (*dao.ReviewState, error) {
+	return r.findByItem(ctx, userID, itemType, itemKey, " FOR UPDATE")
+}
+
+func (r *PostgresRepository) findByItem(ctx context.Context, userID int64, itemType, itemKey, lock string) (*dao.ReviewState, error) {
+	query := fmt.Sprintf(`
+SELECT %s
+FROM review_states
+WHERE user_id = $1 AND item_type = $2 AND item_key = $3%s
+`, reviewStateColumns, lock)
+
+	state, err := scanReviewState(dbtx.Conn(ctx, r.db).QueryRowContext(ctx, query, userID, itemType, itemKey))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// ItemExists reports whether the item is in its catalog. Vocabulary and grammar keys that are not numbers do
+// not exist.
+func (r *PostgresRepository) ItemExists(ctx context.Context, itemType, itemKey string) (bool, error) {
+	query, ok := catalogQueries[itemType]
+	if !ok {
+		return false, nil
+	}
+
+	var key interface{} = itemKey
+	if itemType != dto.ItemTypeKanji {
+		id, err := strconv.ParseInt(itemKey, 10, 64)
+		if err != nil {
+			return false, nil
+		}
+		key = id
+	}
+
+	var exists int
+	if err := r.db.QueryRowContext(ctx, query, key).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// FindDue returns the user's items due at or before now, oldest first, with the total count.
+// An empty itemType matches every item type.
+func (r *PostgresRepository) FindDue(ctx context.Context, params pagination.Params, userID int64, itemType string, now time.Time) ([]dao.ReviewState, int64, error) {
+	whereClause := " WHERE user_id = $1 AND due_at <= $2"
+	args := []interface{}{userID, now}
+
+	if itemType != "" {
+		args = append(args, itemType)
+		whereClause += fmt.Sprintf(" AND item_type = $%d", len(args))
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM review_states"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM review_states%s ORDER BY due_at ASC, id ASC LIMIT $%d OFFSET $%d",
+		reviewStateColumns,
+		whereClause,
+		len(args)+1,
+		len(args)+2,
+	)
+	args = append(args, params.Limit(), params.Offset())
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var states []dao.ReviewState
+	for rows.Next() {
+		state, err := scanReviewState(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		states = append(states, *state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return states, total, nil
+}
+
+// Save inserts or replaces the review state of an item for a user, inside the transaction carried by ctx when
+// there is one.
+func (r *PostgresRepository) Save(ctx context.Context, state dao.ReviewState) (*dao.ReviewState, error) {
+	query := fmt.Sprintf(`
+INSERT INTO review_states (
+    user_id, item_type, item_key, scheduler, repetitions, lapses,
+    interval_days, ease_factor, stability, difficulty, due_at, last_reviewed_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (user_id, item_type, item_key) DO UPDATE
+SET scheduler = EXCLUDED.scheduler,
+    repetitions = EXCLUDED.repetitions,
+    lapses = EXCLUDED.lapses,
+    interval_days = EXCLUDED.interval_days,
+    ease_factor = EXCLUDED.ease_factor,
+    stability = EXCLUDED.stability,
+    difficulty = EXCLUDED.difficulty,
+    due_at = EXCLUDED.due_at,
+    last_reviewed_at = EXCLUDED.last_reviewed_at,
+    updated_at = NOW()
+RETURNING %s
+`, reviewStateColumns)
+
+	return scanReviewState(dbtx.Conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		state.UserID,
+		state.ItemType,
+		state.ItemKey,
+		state.Scheduler,
+		state.Repetitions,
+		state.Lapses,
+		state.IntervalDays,
+		state.EaseFactor,
+		state.Stability,
+		state.Difficulty,
+		state.DueAt,
+		state.LastReviewedAt,
+	))
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReviewState(row rowScanner) (*dao.ReviewState, error) {
+	var (
+		state        dao.ReviewState
+		lastReviewed sql.NullTime
+	)
+
+	if err := row.Scan(
+		&state.ID,
+		&state.UserID,
+		&state.ItemType,
+		&state.ItemKey,
+		&state.Scheduler,
+		&state.Repetitions,
+		&state.Lapses,
+		&state.IntervalDays,
+		&state.EaseFactor,
+		&state.Stability,
+		&state.Difficulty,
+		&state.DueAt,
+		&lastReviewed,
+		&state.CreatedAt,
+		&state.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if lastReviewed.Valid {
+		state.LastReviewedAt = lastReviewed.Time
+	}
+
+	return &state, nil
+}
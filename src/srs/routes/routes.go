@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	srsdelivery "gobackend/src/srs/delivery"
+)
+
+// Register mounts review scheduling endpoints on the router.
+func Register(router gin.IRoutes, handler *srsdelivery.Handler) {
+	router.GET("/api/reviews/due", handler.ListDue)
+	router.POST("/api/reviews/:item/answer", handler.Answer)
+}
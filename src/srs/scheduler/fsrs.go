@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"math"
+	"time"
+
+	"gobackend/src/srs/dao"
+	"gobackend/src/srs/dto"
+	srsinterfaces "gobackend/src/srs/interfaces"
+)
+
+// FSRSName identifies the FSRS scheduler.
+const FSRSName = "fsrs"
+
+const (
+	fsrsDecay            = -0.5
+	fsrsFactor           = 19.0 / 81.0
+	fsrsMinDifficulty    = 1.0
+	fsrsMaxDifficulty    = 10.0
+	fsrsMinInterval      = 1.0
+	fsrsMaxInterval      = 36500.0
+	fsrsDefaultRetention = 0.9
+)
+
+// fsrsDefaultWeights are the published FSRS-4.5 default parameters.
+var fsrsDefaultWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206,
+	5.1618, 1.2298, 0.8975, 0.031,
+	1.6474, 0.1367, 1.0461, 2.1072,
+	0.0793, 0.3246, 1.587, 0.2272,
+	2.8755,
+}
+
+var _ srsinterfaces.Scheduler = (*FSRS)(nil)
+
+// FSRS implements the Free Spaced Repetition Scheduler (v4.5) with day-level intervals.
+type FSRS struct {
+	weights          [17]float64
+	desiredRetention float64
+}
+
+// NewFSRS constructs an FSRS scheduler with the default weights.
+// A desiredRetention outside (0, 1) falls back to 0.9.
+func NewFSRS(desiredRetention float64) *FSRS {
+	if desiredRetention <= 0 || desiredRetention >= 1 {
+		desiredRetention = fsrsDefaultRetention
+	}
+
+	return &FSRS{
+		weights:          fsrsDefaultWeights,
+		desiredRetention: desiredRetention,
+	}
+}
+
+// Name returns the scheduler identifier.
+func (f *FSRS) Name() string {
+	return FSRSName
+}
+
+// Schedule applies one FSRS step.
+func (f *FSRS) Schedule(state dao.ReviewState, rating dto.Rating, now time.Time) dao.ReviewState {
+	next := state
+	next.Scheduler = FSRSName
+
+	grade := float64(rating)
+
+	if state.IsNew() || state.Stability <= 0 {
+		next.Stability = f.initialStability(rating)
+		next.Difficulty = f.initialDifficulty(grade)
+	} else {
+		elapsed := math.Max(0, now.Sub(state.LastReviewedAt).Hours()/24)
+		retrievability := math.Pow(1+fsrsFactor*elapsed/state.Stability, fsrsDecay)
+
+		if rating == dto.RatingAgain {
+			next.Stability = f.forgetStability(state.Difficulty, state.Stability, retrievability)
+			next.Lapses++
+		} else {
+			next.Stability = f.recallStability(state.Difficulty, state.Stability, retrievability, rating)
+		}
+		next.Difficulty = f.nextDifficulty(state.Difficulty, grade)
+	}
+
+	if rating == dto.RatingAgain {
+		next.Repetitions = 0
+	} else {
+		next.Repetitions++
+	}
+
+	next.IntervalDays = f.interval(next.Stability)
+	next.LastReviewedAt = now
+	next.DueAt = now.Add(days(next.IntervalDays))
+
+	return next
+}
+
+func (f *FSRS) initialStability(rating dto.Rating) float64 {
+	return math.Max(f.weights[rating-1], 0.1)
+}
+
+func (f *FSRS) initialDifficulty(grade float64) float64 {
+	return clampDifficulty(f.weights[4] - (grade-3)*f.weights[5])
+}
+
+func (f *FSRS) nextDifficulty(difficulty, grade float64) float64 {
+	updated := difficulty - f.weights[6]*(grade-3)
+	reverted := f.weights[7]*f.initialDifficulty(float64(dto.RatingGood)) + (1-f.weights[7])*updated
+	return clampDifficulty(reverted)
+}
+
+func (f *FSRS) recallStability(difficulty, stability, retrievability float64, rating dto.Rating) float64 {
+	hardPenalty := 1.0
+	if rating == dto.RatingHard {
+		hardPenalty = f.weights[15]
+	}
+
+	easyBonus := 1.0
+	if rating == dto.RatingEasy {
+		easyBonus = f.weights[16]
+	}
+
+	growth := math.Exp(f.weights[8]) *
+		(11 - difficulty) *
+		math.Pow(stability, -f.weights[9]) *
+		(math.Exp(f.weights[10]*(1-retrievability)) - 1) *
+		hardPenalty *
+		easyBonus
+
+	return stability * (growth + 1)
+}
+
+func (f *FSRS) forgetStability(difficulty, stability, retrievability float64) float64 {
+	return f.weights[11] *
+		math.Pow(difficulty, -f.weights[12]) *
+		(math.Pow(stability+1, f.weights[13]) - 1) *
+		math.Exp(f.weights[14]*(1-retrievability))
+}
+
+func (f *FSRS) interval(stability float64) float64 {
+	raw := stability / fsrsFactor * (math.Pow(f.desiredRetention, 1/fsrsDecay) - 1)
+	return math.Min(math.Max(math.Round(raw), fsrsMinInterval), fsrsMaxInterval)
+}
+
+func clampDifficulty(value float64) float64 {
+	return math.Min(math.Max(value, fsrsMinDifficulty), fsrsMaxDifficulty)
+}
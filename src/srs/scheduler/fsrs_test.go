@@ -0,0 +1,182 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"gobackend/src/srs/dao"
+	"gobackend/src/srs/dto"
+	"gobackend/src/srs/scheduler"
+)
+
+func TestFSRSFirstReview(t *testing.T) {
+	tests := []struct {
+		name            string
+		rating          dto.Rating
+		wantStability   float64
+		wantDifficulty  float64
+		wantInterval    float64
+		wantRepetitions int
+	}{
+		{name: "again", rating: dto.RatingAgain, wantStability: 0.4872, wantDifficulty: 7.6214, wantInterval: 1},
+		{name: "hard", rating: dto.RatingHard, wantStability: 1.4003, wantDifficulty: 6.3916, wantInterval: 1, wantRepetitions: 1},
+		{name: "good", rating: dto.RatingGood, wantStability: 3.7145, wantDifficulty: 5.1618, wantInterval: 4, wantRepetitions: 1},
+		{name: "easy", rating: dto.RatingEasy, wantStability: 13.8206, wantDifficulty: 3.932, wantInterval: 14, wantRepetitions: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := scheduler.NewFSRS(0.9).Schedule(dao.ReviewState{}, tt.rating, testNow)
+
+			if next.Scheduler != scheduler.FSRSName {
+				t.Errorf("scheduler = %q, want %q", next.Scheduler, scheduler.FSRSName)
+			}
+			if !approxEqual(next.Stability, tt.wantStability) {
+				t.Errorf("stability = %v, want %v", next.Stability, tt.wantStability)
+			}
+			if !approxEqual(next.Difficulty, tt.wantDifficulty) {
+				t.Errorf("difficulty = %v, want %v", next.Difficulty, tt.wantDifficulty)
+			}
+			if next.IntervalDays != tt.wantInterval {
+				t.Errorf("interval = %v, want %v", next.IntervalDays, tt.wantInterval)
+			}
+			if next.Repetitions != tt.wantRepetitions || next.Lapses != 0 {
+				t.Errorf("repetitions/lapses = %d/%d, want %d/0", next.Repetitions, next.Lapses, tt.wantRepetitions)
+			}
+			if want := testNow.Add(time.Duration(tt.wantInterval) * 24 * time.Hour); !next.DueAt.Equal(want) {
+				t.Errorf("due = %v, want %v", next.DueAt, want)
+			}
+		})
+	}
+}
+
+func TestFSRSLaterReview(t *testing.T) {
+	reviewed := dao.ReviewState{
+		Repetitions:    3,
+		Lapses:         1,
+		Stability:      10,
+		Difficulty:     5,
+		LastReviewedAt: testNow.Add(-10 * 24 * time.Hour),
+	}
+
+	tests := []struct {
+		name            string
+		rating          dto.Rating
+		wantRepetitions int
+		wantLapses      int
+		wantGrowth      bool
+	}{
+		{name: "lapse", rating: dto.RatingAgain, wantRepetitions: 0, wantLapses: 2},
+		{name: "hard recall", rating: dto.RatingHard, wantRepetitions: 4, wantLapses: 1, wantGrowth: true},
+		{name: "good recall", rating: dto.RatingGood, wantRepetitions: 4, wantLapses: 1, wantGrowth: true},
+		{name: "easy recall", rating: dto.RatingEasy, wantRepetitions: 4, wantLapses: 1, wantGrowth: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := scheduler.NewFSRS(0.9).Schedule(reviewed, tt.rating, testNow)
+
+			if next.Repetitions != tt.wantRepetitions || next.Lapses != tt.wantLapses {
+				t.Errorf("repetitions/lapses = %d/%d, want %d/%d", next.Repetitions, next.Lapses, tt.wantRepetitions, tt.wantLapses)
+			}
+			if grew := next.Stability > reviewed.Stability; grew != tt.wantGrowth {
+				t.Errorf("stability = %v from %v, want growth %t", next.Stability, reviewed.Stability, tt.wantGrowth)
+			}
+			if next.Stability <= 0 {
+				t.Errorf("stability = %v, want a positive value", next.Stability)
+			}
+			if next.IntervalDays < 1 {
+				t.Errorf("interval = %v, want at least one day", next.IntervalDays)
+			}
+		})
+	}
+}
+
+func TestFSRSLaterReviewOrdersRatings(t *testing.T) {
+	reviewed := dao.ReviewState{
+		Repetitions:    2,
+		Stability:      5,
+		Difficulty:     5,
+		LastReviewedAt: testNow.Add(-5 * 24 * time.Hour),
+	}
+
+	fsrs := scheduler.NewFSRS(0.9)
+	hard := fsrs.Schedule(reviewed, dto.RatingHard, testNow)
+	good := fsrs.Schedule(reviewed, dto.RatingGood, testNow)
+	easy := fsrs.Schedule(reviewed, dto.RatingEasy, testNow)
+
+	if !(hard.Stability < good.Stability && good.Stability < easy.Stability) {
+		t.Errorf("stability hard/good/easy = %v/%v/%v, want increasing", hard.Stability, good.Stability, easy.Stability)
+	}
+	if !(hard.Difficulty > good.Difficulty && good.Difficulty > easy.Difficulty) {
+		t.Errorf("difficulty hard/good/easy = %v/%v/%v, want decreasing", hard.Difficulty, good.Difficulty, easy.Difficulty)
+	}
+}
+
+func TestFSRSBounds(t *testing.T) {
+	lastReviewed := testNow.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		state          dao.ReviewState
+		rating         dto.Rating
+		wantDifficulty float64
+		wantInterval   float64
+	}{
+		{
+			name:           "difficulty capped at ten",
+			state:          dao.ReviewState{Stability: 2, Difficulty: 10, LastReviewedAt: lastReviewed},
+			rating:         dto.RatingAgain,
+			wantDifficulty: 10,
+		},
+		{
+			name:           "difficulty floored at one",
+			state:          dao.ReviewState{Stability: 2, Difficulty: 1, LastReviewedAt: lastReviewed},
+			rating:         dto.RatingEasy,
+			wantDifficulty: 1,
+		},
+		{
+			name:           "interval capped at one hundred years",
+			state:          dao.ReviewState{Stability: 1e6, Difficulty: 5, LastReviewedAt: lastReviewed},
+			rating:         dto.RatingGood,
+			wantInterval:   36500,
+			wantDifficulty: -1,
+		},
+		{
+			name:           "interval floored at one day",
+			state:          dao.ReviewState{Stability: 0.2, Difficulty: 9, LastReviewedAt: lastReviewed},
+			rating:         dto.RatingAgain,
+			wantInterval:   1,
+			wantDifficulty: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := scheduler.NewFSRS(0.9).Schedule(tt.state, tt.rating, testNow)
+
+			if tt.wantDifficulty >= 0 && !approxEqual(next.Difficulty, tt.wantDifficulty) {
+				t.Errorf("difficulty = %v, want %v", next.Difficulty, tt.wantDifficulty)
+			}
+			if tt.wantInterval > 0 && next.IntervalDays != tt.wantInterval {
+				t.Errorf("interval = %v, want %v", next.IntervalDays, tt.wantInterval)
+			}
+		})
+	}
+}
+
+func TestNewFSRSRetentionFallback(t *testing.T) {
+	want := scheduler.NewFSRS(0.9).Schedule(dao.ReviewState{}, dto.RatingEasy, testNow)
+
+	for _, retention := range []float64{0, -0.5, 1, 1.5} {
+		got := scheduler.NewFSRS(retention).Schedule(dao.ReviewState{}, dto.RatingEasy, testNow)
+		if got.IntervalDays != want.IntervalDays {
+			t.Errorf("NewFSRS(%v) interval = %v, want the 0.9 default %v", retention, got.IntervalDays, want.IntervalDays)
+		}
+	}
+
+	lower := scheduler.NewFSRS(0.8).Schedule(dao.ReviewState{}, dto.RatingEasy, testNow)
+	if lower.IntervalDays <= want.IntervalDays {
+		t.Errorf("retention 0.8 interval = %v, want longer than %v", lower.IntervalDays, want.IntervalDays)
+	}
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"math"
+	"time"
+
+	"gobackend/src/srs/dao"
+	"gobackend/src/srs/dto"
+	srsinterfaces "gobackend/src/srs/interfaces"
+)
+
+// SM2Name identifies the SM-2 scheduler.
+const SM2Name = "sm2"
+
+const (
+	sm2InitialEase    = 2.5
+	sm2MinimumEase    = 1.3
+	sm2FirstInterval  = 1
+	sm2SecondInterval = 6
+)
+
+var _ srsinterfaces.Scheduler = (*SM2)(nil)
+
+// SM2 implements the SuperMemo-2 algorithm.
+// Ratings map onto SM-2 quality grades as again=1, hard=3, good=4 and easy=5.
+type SM2 struct{}
+
+// NewSM2 constructs an SM-2 scheduler.
+func NewSM2() *SM2 {
+	return &SM2{}
+}
+
+// Name returns the scheduler identifier.
+func (s *SM2) Name() string {
+	return SM2Name
+}
+
+// Schedule applies one SM-2 step.
+func (s *SM2) Schedule(state dao.ReviewState, rating dto.Rating, now time.Time) dao.ReviewState {
+	next := state
+	next.Scheduler = SM2Name
+
+	if next.EaseFactor <= 0 {
+		next.EaseFactor = sm2InitialEase
+	}
+
+	quality := sm2Quality(rating)
+	if quality < 3 {
+		if !state.IsNew() {
+			next.Lapses++
+		}
+		next.Repetitions = 0
+		next.IntervalDays = sm2FirstInterval
+	} else {
+		switch next.Repetitions {
+		case 0:
+			next.IntervalDays = sm2FirstInterval
+		case 1:
+			next.IntervalDays = sm2SecondInterval
+		default:
+			next.IntervalDays = math.Round(next.IntervalDays * next.EaseFactor)
+		}
+		next.Repetitions++
+	}
+
+	delta := float64(5 - quality)
+	next.EaseFactor = math.Max(sm2MinimumEase, next.EaseFactor+(0.1-delta*(0.08+delta*0.02)))
+
+	next.LastReviewedAt = now
+	next.DueAt = now.Add(days(next.IntervalDays))
+
+	return next
+}
+
+func sm2Quality(rating dto.Rating) int {
+	switch rating {
+	case dto.RatingAgain:
+		return 1
+	case dto.RatingHard:
+		return 3
+	case dto.RatingEasy:
+		return 5
+	default:
+		return 4
+	}
+}
+
+func days(value float64) time.Duration {
+	return time.Duration(value * float64(24*time.Hour))
+}
@@ -0,0 +1,121 @@
+package scheduler_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gobackend/src/srs/dao"
+	"gobackend/src/srs/dto"
+	"gobackend/src/srs/scheduler"
+)
+
+var testNow = time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+func approxEqual(got, want float64) bool {
+	return math.Abs(got-want) < 1e-9
+}
+
+func TestSM2Schedule(t *testing.T) {
+	reviewed := testNow.Add(-6 * 24 * time.Hour)
+
+	tests := []struct {
+		name            string
+		state           dao.ReviewState
+		rating          dto.Rating
+		wantRepetitions int
+		wantLapses      int
+		wantInterval    float64
+		wantEase        float64
+	}{
+		{
+			name:            "first review good",
+			rating:          dto.RatingGood,
+			wantRepetitions: 1,
+			wantInterval:    1,
+			wantEase:        2.5,
+		},
+		{
+			name:            "first review again is not a lapse",
+			rating:          dto.RatingAgain,
+			wantRepetitions: 0,
+			wantInterval:    1,
+			wantEase:        1.96,
+		},
+		{
+			name:            "second review good",
+			state:           dao.ReviewState{Repetitions: 1, IntervalDays: 1, EaseFactor: 2.5, LastReviewedAt: reviewed},
+			rating:          dto.RatingGood,
+			wantRepetitions: 2,
+			wantInterval:    6,
+			wantEase:        2.5,
+		},
+		{
+			name:            "third review multiplies by the ease",
+			state:           dao.ReviewState{Repetitions: 2, IntervalDays: 6, EaseFactor: 2.5, LastReviewedAt: reviewed},
+			rating:          dto.RatingGood,
+			wantRepetitions: 3,
+			wantInterval:    15,
+			wantEase:        2.5,
+		},
+		{
+			name:            "easy raises the ease",
+			state:           dao.ReviewState{Repetitions: 2, IntervalDays: 6, EaseFactor: 2.5, LastReviewedAt: reviewed},
+			rating:          dto.RatingEasy,
+			wantRepetitions: 3,
+			wantInterval:    15,
+			wantEase:        2.6,
+		},
+		{
+			name:            "hard lowers the ease",
+			state:           dao.ReviewState{Repetitions: 2, IntervalDays: 6, EaseFactor: 2.5, LastReviewedAt: reviewed},
+			rating:          dto.RatingHard,
+			wantRepetitions: 3,
+			wantInterval:    15,
+			wantEase:        2.36,
+		},
+		{
+			name:            "lapse resets the repetitions",
+			state:           dao.ReviewState{Repetitions: 4, Lapses: 1, IntervalDays: 30, EaseFactor: 2.5, LastReviewedAt: reviewed},
+			rating:          dto.RatingAgain,
+			wantRepetitions: 0,
+			wantLapses:      2,
+			wantInterval:    1,
+			wantEase:        1.96,
+		},
+		{
+			name:            "ease never drops below the minimum",
+			state:           dao.ReviewState{Repetitions: 3, IntervalDays: 10, EaseFactor: 1.4, LastReviewedAt: reviewed},
+			rating:          dto.RatingAgain,
+			wantRepetitions: 0,
+			wantLapses:      1,
+			wantInterval:    1,
+			wantEase:        1.3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := scheduler.NewSM2().Schedule(tt.state, tt.rating, testNow)
+
+			if next.Scheduler != scheduler.SM2Name {
+				t.Errorf("scheduler = %q, want %q", next.Scheduler, scheduler.SM2Name)
+			}
+			if next.Repetitions != tt.wantRepetitions || next.Lapses != tt.wantLapses {
+				t.Errorf("repetitions/lapses = %d/%d, want %d/%d", next.Repetitions, next.Lapses, tt.wantRepetitions, tt.wantLapses)
+			}
+			if !approxEqual(next.IntervalDays, tt.wantInterval) {
+				t.Errorf("interval = %v, want %v", next.IntervalDays, tt.wantInterval)
+			}
+			if !approxEqual(next.EaseFactor, tt.wantEase) {
+				t.Errorf("ease = %v, want %v", next.EaseFactor, tt.wantEase)
+			}
+			if !next.LastReviewedAt.Equal(testNow) {
+				t.Errorf("last reviewed = %v, want %v", next.LastReviewedAt, testNow)
+			}
+			if want := testNow.Add(time.Duration(tt.wantInterval) * 24 * time.Hour); !next.DueAt.Equal(want) {
+				t.Errorf("due = %v, want %v", next.DueAt, want)
+			}
+		})
+	}
+}
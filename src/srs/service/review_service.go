@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gobackend/shared/dbtx"
+	"gobackend/shared/pagination"
+	logdto "gobackend/src/logs/dto"
+	loginterfaces "gobackend/src/logs/interfaces"
+	outboxdto "gobackend/src/outbox/dto"
+	outboxinterfaces "gobackend/src/outbox/interfaces"
+	"gobackend/src/srs/dao"
+	"gobackend/src/srs/dto"
+	srsinterfaces "gobackend/src/srs/interfaces"
+	"gobackend/src/srs/validation"
+)
+
+var _ srsinterfaces.Service = (*ReviewService)(nil)
+
+var (
+	// ErrUnknownScheduler indicates the configured scheduler is not registered.
+	ErrUnknownScheduler = errors.New("unknown review scheduler")
+	// ErrItemNotFound indicates the item is not in the kanji, vocabulary or grammar catalog.
+	ErrItemNotFound = errors.New("review item not found")
+)
+
+// ReviewService schedules reviews with a pluggable Scheduler.
+type ReviewService struct {
+	repo             srsinterfaces.Repository
+	schedulers       map[string]srsinterfaces.Scheduler
+	defaultScheduler srsinterfaces.Scheduler
+	logService       loginterfaces.Service
+	transactor       dbtx.Transactor
+	outbox           outboxinterfaces.Outbox
+	nowProvider      func() time.Time
+}
+
+// NewReviewService constructs a ReviewService. New items are scheduled with defaultScheduler;
+// items already under review keep the scheduler recorded in their state as long as it is registered.
+// Each answer is saved together with a review.answered event in outbox.
+func NewReviewService(
+	repo srsinterfaces.Repository,
+	logService loginterfaces.Service,
+	transactor dbtx.Transactor,
+	outbox outboxinterfaces.Outbox,
+	defaultScheduler string,
+	schedulers ...srsinterfaces.Scheduler,
+) (*ReviewService, error) {
+	registry := make(map[string]srsinterfaces.Scheduler, len(schedulers))
+	for _, scheduler := range schedulers {
+		registry[scheduler.Name()] = scheduler
+	}
+
+	selected, ok := registry[defaultScheduler]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScheduler, defaultScheduler)
+	}
+
+	return &ReviewService{
+		repo:             repo,
+		schedulers:       registry,
+		defaultScheduler: selected,
+		logService:       logService,
+		transactor:       transactor,
+		outbox:           outbox,
+		nowProvider:      time.Now,
+	}, nil
+}
+
+// ListDue returns the user's items whose review is due.
+func (s *ReviewService) ListDue(ctx context.Context, params pagination.Params, userID int64, itemType string) ([]dto.Review, int64, error) {
+	if itemType != "" {
+		if err := validation.ValidateItemType(itemType); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	states, total, err := s.repo.FindDue(ctx, params, userID, itemType, s.nowProvider())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]dto.Review, 0, len(states))
+	for _, state := range states {
+		result = append(result, toDTO(state))
+	}
+
+	return result, total, nil
+}
+
+// Answer records a review of the item and schedules the next one. The item must exist in its catalog. The
+// current state is read and replaced in one transaction with its row locked, so concurrent answers for the
+// same item are applied one after the other.
+func (s *ReviewService) Answer(ctx context.Context, userID int64, item dto.Item, rating dto.Rating) (*dto.Review, error) {
+	if err := validation.ValidateItemType(item.Type); err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateRating(rating); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.ItemExists(ctx, item.Type, item.Key)
+	if err != nil {
+		return nil, fmt.Errorf("find review item: %w", err)
+	}
+	if !exists {
+		return nil, ErrItemNotFound
+	}
+
+	var saved *dao.ReviewState
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		current, err := s.repo.FindByItemForUpdate(ctx, userID, item.Type, item.Key)
+		if err != nil {
+			return fmt.Errorf("find review state: %w", err)
+		}
+
+		if current == nil {
+			current = &dao.ReviewState{
+				UserID:   userID,
+				ItemType: item.Type,
+				ItemKey:  item.Key,
+			}
+		}
+
+		next := s.schedulerFor(*current).Schedule(*current, rating, s.nowProvider())
+		if saved, err = s.repo.Save(ctx, next); err != nil {
+			return fmt.Errorf("save review state: %w", err)
+		}
+
+		return s.outbox.Add(ctx, outboxdto.Event{
+			Type: outboxdto.EventReviewAnswered,
+			Payload: outboxdto.ReviewAnswered{
+				UserID:       userID,
+				ItemType:     saved.ItemType,
+				ItemKey:      saved.ItemKey,
+				Rating:       rating.String(),
+				Scheduler:    saved.Scheduler,
+				IntervalDays: saved.IntervalDays,
+				DueAt:        saved.DueAt,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID: userID,
+		Action: logdto.ActionReviewAnswered,
+		Detail: fmt.Sprintf("%s rated %s; next review in %.0f day(s) (%s)", item, rating, saved.IntervalDays, saved.Scheduler),
+		Metadata: logdto.Metadata{
+			"item_type":     item.Type,
+			"item_key":      item.Key,
+			"rating":        rating.String(),
+			"interval_days": saved.IntervalDays,
+			"scheduler":     saved.Scheduler,
+		},
+	}); err != nil {
+		log.Printf("record review answer log for user %d: %v", userID, err)
+	}
+
+	result := toDTO(*saved)
+	return &result, nil
+}
+
+func (s *ReviewService) schedulerFor(state dao.ReviewState) srsinterfaces.Scheduler {
+	if state.Scheduler != "" {
+		if scheduler, ok := s.schedulers[state.Scheduler]; ok {
+			return scheduler
+		}
+	}
+
+	return s.defaultScheduler
+}
+
+func toDTO(state dao.ReviewState) dto.Review {
+	return dto.Review{
+		Item: dto.Item{
+			Type: state.ItemType,
+			Key:  state.ItemKey,
+		},
+		Scheduler:      state.Scheduler,
+		Repetitions:    state.Repetitions,
+		Lapses:         state.Lapses,
+		IntervalDays:   state.IntervalDays,
+		DueAt:          state.DueAt,
+		LastReviewedAt: state.LastReviewedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"gobackend/src/srs/dto"
+)
+
+const maxItemKeyLength = 128
+
+var (
+	// ErrInvalidItem indicates the item identifier is not in type:key form.
+	ErrInvalidItem = errors.New("item must look like kanji:<character>, vocabulary:<sequence> or grammar:<id>")
+	// ErrInvalidItemType indicates an unsupported item type.
+	ErrInvalidItemType = errors.New("item type must be kanji, vocabulary or grammar")
+	// ErrInvalidRating indicates the rating is outside 1 (again) to 4 (easy).
+	ErrInvalidRating = errors.New("rating must be 1 (again), 2 (hard), 3 (good) or 4 (easy)")
+)
+
+// ParseItem splits a type:key identifier into an Item.
+func ParseItem(raw string) (dto.Item, error) {
+	itemType, key, found := strings.Cut(strings.TrimSpace(raw), ":")
+	key = strings.TrimSpace(key)
+	if !found || key == "" || utf8.RuneCountInString(key) > maxItemKeyLength {
+		return dto.Item{}, ErrInvalidItem
+	}
+
+	if err := ValidateItemType(itemType); err != nil {
+		return dto.Item{}, err
+	}
+
+	return dto.Item{Type: itemType, Key: key}, nil
+}
+
+// ValidateItemType ensures the item type is supported.
+func ValidateItemType(itemType string) error {
+	switch itemType {
+	case dto.ItemTypeKanji, dto.ItemTypeVocabulary, dto.ItemTypeGrammar:
+		return nil
+	default:
+		return ErrInvalidItemType
+	}
+}
+
+// ValidateRating ensures the rating is on the four-button scale.
+func ValidateRating(rating dto.Rating) error {
+	if rating < dto.RatingAgain || rating > dto.RatingEasy {
+		return ErrInvalidRating
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package dao
+
+import "time"
+
+// Identity is a provider identity linked to a user.
+type Identity struct {
+	Provider      string    `json:"provider"`
+	ProviderID    string    `json:"provider_id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Activity is an entry of a user's own activity log.
+type Activity struct {
+	Action    string    `json:"action"`
+	Detail    string    `json:"detail"`
+	Metadata  []byte    `json:"metadata"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	RequestID string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReviewState is the spaced-repetition state of an item studied by a user.
+type ReviewState struct {
+	ItemType     string    `json:"item_type"`
+	ItemKey      string    `json:"item_key"`
+	Scheduler    string    `json:"scheduler"`
+	Repetitions  int       `json:"repetitions"`
+	Lapses       int       `json:"lapses"`
+	IntervalDays float64   `json:"interval_days"`
+	EaseFactor   float64   `json:"ease_factor"`
+	Stability    float64   `json:"stability"`
+	Difficulty   float64   `json:"difficulty"`
+	DueAt        time.Time `json:"due_at"`
+	// LastReviewedAt is zero for items that were never reviewed.
+	LastReviewedAt time.Time `json:"last_reviewed_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,15 @@
+package dao
+
+import "time"
+
+// Preferences represents the stored study and display preferences of a user.
+type Preferences struct {
+	UserID          int64     `json:"user_id"`
+	UILanguage      string    `json:"ui_language"`
+	ShowRomaji      bool      `json:"show_romaji"`
+	ShowFurigana    bool      `json:"show_furigana"`
+	TargetJLPTLevel int       `json:"target_jlpt_level"`
+	DailyReviewGoal int       `json:"daily_review_goal"`
+	Timezone        string    `json:"timezone"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -12,4 +12,6 @@ type User struct {
 	PictureURL  string    `json:"picture_url"`
 	CreatedAt   time.Time `json:"created_at"`
 	LastLoginAt time.Time `json:"last_login_at"`
+	// DeactivatedAt is zero for active accounts.
+	DeactivatedAt time.Time `json:"deactivated_at"`
 }
@@ -0,0 +1,165 @@
+package delivery
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gobackend/src/users/dto"
+)
+
+// writeExportArchive writes export as a zip archive holding export.json and one CSV file per section.
+func writeExportArchive(w io.Writer, export dto.Export) error {
+	archive := zip.NewWriter(w)
+
+	file, err := archive.Create("export.json")
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return err
+	}
+
+	account := export.Account
+	deactivatedAt := ""
+	if account.DeactivatedAt != nil {
+		deactivatedAt = formatTime(*account.DeactivatedAt)
+	}
+	prefs := export.Preferences
+
+	sections := []struct {
+		name    string
+		header  []string
+		records [][]string
+	}{
+		{
+			name: "account.csv",
+			header: []string{
+				"reference", "email", "name", "picture_url", "provider", "provider_id", "created_at", "last_login_at",
+				"deactivated_at", "roles", "ui_language", "show_romaji", "show_furigana", "target_jlpt_level",
+				"daily_review_goal", "timezone",
+			},
+			records: [][]string{{
+				account.Reference, account.Email, account.Name, account.PictureURL, account.Provider, account.ProviderID,
+				formatTime(account.CreatedAt), formatTime(account.LastLoginAt), deactivatedAt,
+				strings.Join(export.Roles, " "), prefs.UILanguage, strconv.FormatBool(prefs.ShowRomaji),
+				strconv.FormatBool(prefs.ShowFurigana), strconv.Itoa(prefs.TargetJLPTLevel),
+				strconv.Itoa(prefs.DailyReviewGoal), prefs.Timezone,
+			}},
+		},
+		{
+			name:    "identities.csv",
+			header:  []string{"provider", "provider_id", "email", "email_verified", "linked_at"},
+			records: identityRecords(export.Identities),
+		},
+		{
+			name:    "activity.csv",
+			header:  []string{"created_at", "action", "detail", "metadata", "ip_address", "user_agent", "request_id"},
+			records: activityRecords(export.Activity),
+		},
+		{
+			name: "reviews.csv",
+			header: []string{
+				"item_type", "item_key", "scheduler", "repetitions", "lapses", "interval_days", "ease_factor",
+				"stability", "difficulty", "due_at", "last_reviewed_at", "created_at", "updated_at",
+			},
+			records: reviewRecords(export.Reviews),
+		},
+	}
+
+	for _, section := range sections {
+		file, err := archive.Create(section.name)
+		if err != nil {
+			return err
+		}
+
+		writer := csv.NewWriter(file)
+		if err := writer.Write(section.header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(section.records); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func identityRecords(identities []dto.ExportIdentity) [][]string {
+	records := make([][]string, 0, len(identities))
+	for _, identity := range identities {
+		records = append(records, []string{
+			identity.Provider,
+			identity.ProviderID,
+			identity.Email,
+			strconv.FormatBool(identity.EmailVerified),
+			formatTime(identity.LinkedAt),
+		})
+	}
+
+	return records
+}
+
+func activityRecords(activity []dto.ExportActivity) [][]string {
+	records := make([][]string, 0, len(activity))
+	for _, entry := range activity {
+		records = append(records, []string{
+			formatTime(entry.CreatedAt),
+			entry.Action,
+			entry.Detail,
+			string(entry.Metadata),
+			entry.IPAddress,
+			entry.UserAgent,
+			entry.RequestID,
+		})
+	}
+
+	return records
+}
+
+func reviewRecords(reviews []dto.ExportReview) [][]string {
+	records := make([][]string, 0, len(reviews))
+	for _, review := range reviews {
+		lastReviewedAt := ""
+		if review.LastReviewedAt != nil {
+			lastReviewedAt = formatTime(*review.LastReviewedAt)
+		}
+
+		records = append(records, []string{
+			review.ItemType,
+			review.ItemKey,
+			review.Scheduler,
+			strconv.Itoa(review.Repetitions),
+			strconv.Itoa(review.Lapses),
+			formatFloat(review.IntervalDays),
+			formatFloat(review.EaseFactor),
+			formatFloat(review.Stability),
+			formatFloat(review.Difficulty),
+			formatTime(review.DueAt),
+			lastReviewedAt,
+			formatTime(review.CreatedAt),
+			formatTime(review.UpdatedAt),
+		})
+	}
+
+	return records
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+
+	return value.UTC().Format(time.RFC3339)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
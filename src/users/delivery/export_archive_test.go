@@ -0,0 +1,82 @@
+package delivery
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gobackend/src/users/dto"
+)
+
+func TestWriteExportArchive(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	export := dto.Export{
+		GeneratedAt: createdAt,
+		Account:     dto.ExportAccount{Reference: "abc", Email: "a@example.com", Name: "A, \"quoted\"", CreatedAt: createdAt},
+		Roles:       []string{"learner"},
+		Identities:  []dto.ExportIdentity{{Provider: "github", ProviderID: "1", EmailVerified: true, LinkedAt: createdAt}},
+		Activity:    []dto.ExportActivity{{Action: "login", Metadata: json.RawMessage(`{"provider":"github"}`), CreatedAt: createdAt}},
+		Reviews:     []dto.ExportReview{},
+	}
+
+	var buf bytes.Buffer
+	if err := writeExportArchive(&buf, export); err != nil {
+		t.Fatalf("writeExportArchive: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+
+	wantRows := map[string]int{"account.csv": 2, "identities.csv": 2, "activity.csv": 2, "reviews.csv": 1}
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	jsonFile, ok := files["export.json"]
+	if !ok {
+		t.Fatal("export.json missing")
+	}
+	reader, err := jsonFile.Open()
+	if err != nil {
+		t.Fatalf("open export.json: %v", err)
+	}
+	var decoded dto.Export
+	if err := json.NewDecoder(reader).Decode(&decoded); err != nil {
+		t.Fatalf("decode export.json: %v", err)
+	}
+	reader.Close()
+	if decoded.Account.Name != export.Account.Name {
+		t.Errorf("export.json name = %q, want %q", decoded.Account.Name, export.Account.Name)
+	}
+
+	for name, want := range wantRows {
+		t.Run(name, func(t *testing.T) {
+			file, ok := files[name]
+			if !ok {
+				t.Fatalf("%s missing", name)
+			}
+			reader, err := file.Open()
+			if err != nil {
+				t.Fatalf("open: %v", err)
+			}
+			defer reader.Close()
+
+			records, err := csv.NewReader(reader).ReadAll()
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if len(records) != want {
+				t.Fatalf("rows = %d, want %d", len(records), want)
+			}
+			if name == "account.csv" && records[1][2] != export.Account.Name {
+				t.Errorf("name = %q, want %q", records[1][2], export.Account.Name)
+			}
+		})
+	}
+}
@@ -1,32 +1,309 @@
 package delivery
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 
+	"gobackend/shared/identity"
+	"gobackend/shared/pagination"
 	"gobackend/shared/response"
+	authdto "gobackend/src/auth/dto"
+	"gobackend/src/auth/middleware"
+	authservice "gobackend/src/auth/service"
+	"gobackend/src/users/dto"
 	userinterfaces "gobackend/src/users/interfaces"
+	userservice "gobackend/src/users/service"
+	"gobackend/src/users/validation"
 )
 
 // Handler exposes HTTP handlers for the user feature.
 type Handler struct {
-	service userinterfaces.UserService
+	service    userinterfaces.UserService
+	refEncoder *identity.UserReferenceEncoder
 }
 
 // NewHandler builds a new Handler.
-func NewHandler(service userinterfaces.UserService) *Handler {
-	return &Handler{service: service}
+func NewHandler(service userinterfaces.UserService, refEncoder *identity.UserReferenceEncoder) *Handler {
+	return &Handler{service: service, refEncoder: refEncoder}
 }
 
-// ListUsers returns all registered users.
+// ListUsers returns a page of users, optionally searched by name or email, filtered by provider and
+// created or last-login date, and sorted by a whitelisted field.
 func (h *Handler) ListUsers(ctx *gin.Context) {
-	users, err := h.service.ListUsers(ctx.Request.Context())
+	params := pagination.FromQuery(ctx)
+
+	filter, err := validation.ParseFilter(validation.FilterQuery{
+		Search:        ctx.Query("q"),
+		Provider:      ctx.Query("provider"),
+		CreatedFrom:   ctx.Query("created_from"),
+		CreatedTo:     ctx.Query("created_to"),
+		LastLoginFrom: ctx.Query("last_login_from"),
+		LastLoginTo:   ctx.Query("last_login_to"),
+		Sort:          ctx.Query("sort"),
+	})
+	if err != nil {
+		response.BadRequest(ctx, "invalid user filter", err.Error())
+		return
+	}
+
+	users, total, err := h.service.ListUsers(ctx.Request.Context(), params, filter)
 	if err != nil {
 		response.InternalError(ctx, "failed to list users", err.Error())
 		return
 	}
 
-	response.OK(ctx, "users retrieved successfully", gin.H{
-		"users": users,
-		"count": len(users),
+	meta := pagination.NewMetadata(total, params)
+	response.Paginated(ctx, "users retrieved successfully", users, meta)
+}
+
+// GetUser returns the referenced user with its roles and linked providers.
+func (h *Handler) GetUser(ctx *gin.Context) {
+	userID, ok := h.decodeReference(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.GetUser(ctx.Request.Context(), userID)
+	if err != nil {
+		h.handleAdminError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "user retrieved successfully", user)
+}
+
+// DeactivateUser refuses further logins of the referenced user and ends its sessions.
+func (h *Handler) DeactivateUser(ctx *gin.Context) {
+	actorID, userID, ok := h.adminTarget(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.DeactivateUser(ctx.Request.Context(), actorID, userID)
+	if err != nil {
+		h.handleAdminError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "user deactivated", user)
+}
+
+// ReactivateUser lets the referenced user sign in again.
+func (h *Handler) ReactivateUser(ctx *gin.Context) {
+	actorID, userID, ok := h.adminTarget(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.ReactivateUser(ctx.Request.Context(), actorID, userID)
+	if err != nil {
+		h.handleAdminError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "user reactivated", user)
+}
+
+// DeleteUser permanently removes the referenced user.
+func (h *Handler) DeleteUser(ctx *gin.Context) {
+	actorID, userID, ok := h.adminTarget(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteUser(ctx.Request.Context(), actorID, userID); err != nil {
+		h.handleAdminError(ctx, err)
+		return
+	}
+
+	response.NoContent(ctx)
+}
+
+// adminTarget returns the acting administrator and the referenced user.
+func (h *Handler) adminTarget(ctx *gin.Context) (int64, int64, bool) {
+	actorID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return 0, 0, false
+	}
+
+	userID, ok := h.decodeReference(ctx)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return actorID, userID, true
+}
+
+func (h *Handler) decodeReference(ctx *gin.Context) (int64, bool) {
+	userID, err := h.refEncoder.Decode(ctx.Param("reference"))
+	if err != nil {
+		response.BadRequest(ctx, "invalid user reference", err.Error())
+		return 0, false
+	}
+
+	return userID, true
+}
+
+func (h *Handler) handleAdminError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, userservice.ErrUserNotFound):
+		response.NotFound(ctx, err.Error())
+	case errors.Is(err, userservice.ErrUserDeactivated),
+		errors.Is(err, userservice.ErrUserActive),
+		errors.Is(err, userservice.ErrSelfAdministration):
+		response.BadRequest(ctx, err.Error(), nil)
+	default:
+		response.InternalError(ctx, "failed to manage user", err.Error())
+	}
+}
+
+// GetProfile returns the authenticated user's profile and preferences.
+func (h *Handler) GetProfile(ctx *gin.Context) {
+	userID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	profile, err := h.service.GetProfile(ctx.Request.Context(), userID)
+	if err != nil {
+		h.handleProfileError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "profile retrieved successfully", profile)
+}
+
+// UpdateProfile changes the name and preferences sent in the payload and returns the updated profile.
+func (h *Handler) UpdateProfile(ctx *gin.Context) {
+	userID, ok := middleware.CurrentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return
+	}
+
+	var req dto.ProfileUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "invalid payload", err.Error())
+		return
+	}
+
+	if err := validation.ValidateProfileUpdate(&req); err != nil {
+		response.BadRequest(ctx, err.Error(), nil)
+		return
+	}
+
+	profile, err := h.service.UpdateProfile(ctx.Request.Context(), userID, req)
+	if err != nil {
+		h.handleProfileError(ctx, err)
+		return
+	}
+
+	response.OK(ctx, "profile updated", profile)
+}
+
+func (h *Handler) handleProfileError(ctx *gin.Context, err error) {
+	if errors.Is(err, userservice.ErrUserNotFound) {
+		response.NotFound(ctx, err.Error())
+		return
+	}
+
+	response.InternalError(ctx, "failed to load profile", err.Error())
+}
+
+// personalDataAPIKeyForbidden is returned when an API key calls the personal data routes.
+const personalDataAPIKeyForbidden = "api keys cannot export or erase personal data"
+
+// ExportData returns the authenticated user's personal data as a zip archive of JSON and CSV files.
+func (h *Handler) ExportData(ctx *gin.Context) {
+	userID, ok := sessionUserID(ctx, personalDataAPIKeyForbidden)
+	if !ok {
+		return
+	}
+
+	export, err := h.service.ExportData(ctx.Request.Context(), userID)
+	if err != nil {
+		h.handleProfileError(ctx, err)
+		return
+	}
+
+	var archive bytes.Buffer
+	if err := writeExportArchive(&archive, *export); err != nil {
+		response.InternalError(ctx, "failed to build export", err.Error())
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"personal-data-%s.zip\"", export.Account.Reference))
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/zip", archive.Bytes())
+}
+
+// EraseAccount permanently removes the authenticated user's account and personal data. The optional payload
+// carries the MFA code that confirms the erasure.
+func (h *Handler) EraseAccount(ctx *gin.Context) {
+	claims, ok := sessionClaims(ctx, personalDataAPIKeyForbidden)
+	if !ok {
+		return
+	}
+
+	var req dto.ErasureRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(ctx, "invalid payload", err.Error())
+			return
+		}
+	}
+
+	err := h.service.EraseAccount(ctx.Request.Context(), claims.UserID, dto.ErasureConfirmation{
+		MFACode:  req.MFACode,
+		IssuedAt: claims.IssuedAt,
 	})
+	if err != nil {
+		switch {
+		case errors.Is(err, userservice.ErrErasureNotConfirmed):
+			response.Forbidden(ctx, err.Error())
+		case errors.Is(err, authservice.ErrInvalidMFACode):
+			response.Unauthorized(ctx, err.Error())
+		case errors.Is(err, authservice.ErrMFALocked):
+			response.JSON(ctx, http.StatusTooManyRequests, err.Error(), nil, nil)
+		case errors.Is(err, userservice.ErrLastAdmin):
+			response.JSON(ctx, http.StatusConflict, err.Error(), nil, nil)
+		default:
+			h.handleProfileError(ctx, err)
+		}
+		return
+	}
+
+	response.NoContent(ctx)
+}
+
+// sessionUserID returns the authenticated user, answering 403 with forbidden when the caller used an API key.
+func sessionUserID(ctx *gin.Context, forbidden string) (int64, bool) {
+	claims, ok := sessionClaims(ctx, forbidden)
+	if !ok {
+		return 0, false
+	}
+
+	return claims.UserID, true
+}
+
+// sessionClaims returns the access token claims, answering 403 with forbidden when the caller used an API key.
+func sessionClaims(ctx *gin.Context, forbidden string) (*authdto.Claims, bool) {
+	claims, ok := middleware.CurrentClaims(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "authentication required")
+		return nil, false
+	}
+
+	if claims.APIKeyID != 0 {
+		response.Forbidden(ctx, forbidden)
+		return nil, false
+	}
+
+	return claims, true
 }
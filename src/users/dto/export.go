@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ErasureRequest is the optional payload of DELETE /api/me.
+type ErasureRequest struct {
+	// MFACode is a TOTP or recovery code; it is required when the user has enabled multi-factor authentication.
+	MFACode string `json:"mfa_code"`
+}
+
+// ErasureConfirmation is what the user presented to confirm an account erasure.
+type ErasureConfirmation struct {
+	MFACode string
+	// IssuedAt is when the access token of the request was issued.
+	IssuedAt time.Time
+}
+
+// Export is the personal data held about a user, returned by GET /api/me/export.
+type Export struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Account     ExportAccount    `json:"account"`
+	Preferences Preferences      `json:"preferences"`
+	Roles       []string         `json:"roles"`
+	Identities  []ExportIdentity `json:"identities"`
+	Activity    []ExportActivity `json:"activity"`
+	Reviews     []ExportReview   `json:"reviews"`
+}
+
+// ExportAccount is the user's row in the users table.
+type ExportAccount struct {
+	Reference   string    `json:"reference"`
+	Email       string    `json:"email"`
+	Name        string    `json:"name"`
+	PictureURL  string    `json:"picture_url"`
+	Provider    string    `json:"provider"`
+	ProviderID  string    `json:"provider_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+	// DeactivatedAt is only set for deactivated accounts.
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+}
+
+// ExportIdentity is a provider identity linked to the account.
+type ExportIdentity struct {
+	Provider      string    `json:"provider"`
+	ProviderID    string    `json:"provider_id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	LinkedAt      time.Time `json:"linked_at"`
+}
+
+// ExportActivity is an entry of the user's activity log.
+type ExportActivity struct {
+	Action    string          `json:"action"`
+	Detail    string          `json:"detail"`
+	Metadata  json.RawMessage `json:"metadata"`
+	IPAddress string          `json:"ip_address,omitempty"`
+	UserAgent string          `json:"user_agent,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ExportReview is the review state of an item the user studies.
+type ExportReview struct {
+	ItemType     string    `json:"item_type"`
+	ItemKey      string    `json:"item_key"`
+	Scheduler    string    `json:"scheduler"`
+	Repetitions  int       `json:"repetitions"`
+	Lapses       int       `json:"lapses"`
+	IntervalDays float64   `json:"interval_days"`
+	EaseFactor   float64   `json:"ease_factor,omitempty"`
+	Stability    float64   `json:"stability,omitempty"`
+	Difficulty   float64   `json:"difficulty,omitempty"`
+	DueAt        time.Time `json:"due_at"`
+	// LastReviewedAt is omitted for items that were never reviewed.
+	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
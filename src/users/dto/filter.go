@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// Sort fields accepted by the user directory.
+const (
+	SortName        = "name"
+	SortEmail       = "email"
+	SortCreatedAt   = "created_at"
+	SortLastLoginAt = "last_login_at"
+)
+
+// Filter narrows and orders the user directory. Zero values mean the criterion is not applied.
+// Date ranges include their start and exclude their end.
+type Filter struct {
+	// Search matches part of the name or email, ignoring case.
+	Search string
+	// Provider keeps users with an identity linked at that provider.
+	Provider      string
+	CreatedFrom   time.Time
+	CreatedTo     time.Time
+	LastLoginFrom time.Time
+	LastLoginTo   time.Time
+	// Sort is one of the Sort fields; an empty Sort lists the newest accounts first.
+	Sort       string
+	Descending bool
+}
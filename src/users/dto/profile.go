@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+const (
+	// DefaultUILanguage is used until the user picks a language.
+	DefaultUILanguage = "en"
+	// DefaultDailyReviewGoal is the number of reviews per day suggested to new users.
+	DefaultDailyReviewGoal = 20
+	// DefaultTimezone is used until the user picks a timezone.
+	DefaultTimezone = "UTC"
+)
+
+// Profile is the authenticated user's own account, returned with the unmasked email.
+type Profile struct {
+	Reference   string      `json:"reference"`
+	Email       string      `json:"email"`
+	Name        string      `json:"name"`
+	PictureURL  string      `json:"picture_url,omitempty"`
+	Provider    string      `json:"provider"`
+	CreatedAt   time.Time   `json:"created_at"`
+	LastLoginAt time.Time   `json:"last_login_at"`
+	Preferences Preferences `json:"preferences"`
+}
+
+// Preferences holds the study and display settings of a user.
+type Preferences struct {
+	UILanguage   string `json:"ui_language"`
+	ShowRomaji   bool   `json:"show_romaji"`
+	ShowFurigana bool   `json:"show_furigana"`
+	// TargetJLPTLevel uses 1–5 for N1–N5 and is omitted when the user has not picked a level.
+	TargetJLPTLevel int    `json:"target_jlpt_level,omitempty"`
+	DailyReviewGoal int    `json:"daily_review_goal"`
+	Timezone        string `json:"timezone"`
+}
+
+// DefaultPreferences returns the preferences of a user who never changed them.
+func DefaultPreferences() Preferences {
+	return Preferences{
+		UILanguage:      DefaultUILanguage,
+		ShowRomaji:      true,
+		ShowFurigana:    true,
+		DailyReviewGoal: DefaultDailyReviewGoal,
+		Timezone:        DefaultTimezone,
+	}
+}
+
+// ProfileUpdateRequest changes the fields that are set and leaves the others untouched.
+// A TargetJLPTLevel of 0 clears the target level.
+type ProfileUpdateRequest struct {
+	Name            *string `json:"name"`
+	UILanguage      *string `json:"ui_language"`
+	ShowRomaji      *bool   `json:"show_romaji"`
+	ShowFurigana    *bool   `json:"show_furigana"`
+	TargetJLPTLevel *int    `json:"target_jlpt_level"`
+	DailyReviewGoal *int    `json:"daily_review_goal"`
+	Timezone        *string `json:"timezone"`
+}
@@ -11,4 +11,14 @@ type User struct {
 	LastLoginAt time.Time `json:"last_login_at"`
 	CreatedAt   time.Time `json:"created_at"`
 	Provider    string    `json:"provider"`
+	// DeactivatedAt is only set for deactivated accounts.
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+}
+
+// UserDetail is returned to administrators for a single account.
+type UserDetail struct {
+	User
+	Roles []string `json:"roles"`
+	// Providers lists the providers the user linked an identity at.
+	Providers []string `json:"providers"`
 }
@@ -2,11 +2,41 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
+	"gobackend/shared/pagination"
 	"gobackend/src/users/dao"
+	"gobackend/src/users/dto"
 )
 
 // UserRepository describes persistence operations used by the user service.
 type UserRepository interface {
-	FindAll(ctx context.Context) ([]dao.User, error)
+	FindAll(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dao.User, int64, error)
+	// FindByID returns nil when the user does not exist.
+	FindByID(ctx context.Context, id int64) (*dao.User, error)
+	// FindPreferences returns nil when the user never changed the default preferences.
+	FindPreferences(ctx context.Context, userID int64) (*dao.Preferences, error)
+	// SaveProfile updates the user's name and stores its preferences atomically.
+	SaveProfile(ctx context.Context, userID int64, name string, prefs dao.Preferences) error
+	FindRoles(ctx context.Context, userID int64) ([]string, error)
+	FindProviders(ctx context.Context, userID int64) ([]string, error)
+	// Deactivate marks an active user as deactivated, inside the transaction carried by ctx when there is one; false
+	// when it is not active.
+	Deactivate(ctx context.Context, userID int64, now time.Time) (bool, error)
+	// Reactivate clears a deactivation; false when the user is not deactivated.
+	Reactivate(ctx context.Context, userID int64) (bool, error)
+	// Delete removes the user, inside the transaction carried by ctx when there is one; false when it does not exist.
+	Delete(ctx context.Context, userID int64) (bool, error)
+	FindIdentities(ctx context.Context, userID int64) ([]dao.Identity, error)
+	// FindActivity returns the user's own activity log, oldest first.
+	FindActivity(ctx context.Context, userID int64) ([]dao.Activity, error)
+	FindReviewStates(ctx context.Context, userID int64) ([]dao.ReviewState, error)
+	// DeleteActivity removes the user's own activity log, inside the transaction carried by ctx when there is one.
+	DeleteActivity(ctx context.Context, userID int64) (int64, error)
+	// LockActiveAdmins returns the active users holding role, locking their role rows until the transaction carried
+	// by ctx ends.
+	LockActiveAdmins(ctx context.Context, role string) ([]int64, error)
+	// DeleteOutboxEvents removes the domain events about the user, inside the transaction carried by ctx when there is one.
+	DeleteOutboxEvents(ctx context.Context, userID int64) (int64, error)
+	EnsureSchema(ctx context.Context) error
 }
@@ -3,10 +3,38 @@ package interfaces
 import (
 	"context"
 
+	"gobackend/shared/pagination"
 	"gobackend/src/users/dto"
 )
 
 // UserService exposes user-related business logic.
 type UserService interface {
-	ListUsers(ctx context.Context) ([]dto.User, error)
+	ListUsers(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dto.User, int64, error)
+	// GetUser returns the account of a user together with its roles and linked providers.
+	GetUser(ctx context.Context, userID int64) (*dto.UserDetail, error)
+	// DeactivateUser refuses further logins, tokens and API keys of the user; actorID is the administrator.
+	DeactivateUser(ctx context.Context, actorID, userID int64) (*dto.UserDetail, error)
+	ReactivateUser(ctx context.Context, actorID, userID int64) (*dto.UserDetail, error)
+	DeleteUser(ctx context.Context, actorID, userID int64) error
+	// GetProfile returns the user's own account together with its preferences.
+	GetProfile(ctx context.Context, userID int64) (*dto.Profile, error)
+	// UpdateProfile applies the fields set in req and returns the updated profile.
+	UpdateProfile(ctx context.Context, userID int64, req dto.ProfileUpdateRequest) (*dto.Profile, error)
+	// ExportData collects the personal data held about the user.
+	ExportData(ctx context.Context, userID int64) (*dto.Export, error)
+	// EraseAccount removes the user with its activity log and study data at the user's own request, once confirmed.
+	EraseAccount(ctx context.Context, userID int64, confirmation dto.ErasureConfirmation) error
+}
+
+// SessionRevoker ends the sessions of a user; the auth session service implements it and joins the transaction
+// carried by ctx.
+type SessionRevoker interface {
+	SignOutEverywhere(ctx context.Context, actorID, userID int64) error
+}
+
+// SecondFactor verifies the second factor of a user before a sensitive change; the auth MFA service implements it.
+type SecondFactor interface {
+	IsEnabled(ctx context.Context, userID int64) (bool, error)
+	// VerifyCode accepts a TOTP code or an unused recovery code of the user.
+	VerifyCode(ctx context.Context, userID int64, code string) error
 }
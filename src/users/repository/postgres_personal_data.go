@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"gobackend/shared/dbtx"
+	"gobackend/src/users/dao"
+)
+
+// FindIdentities returns the provider identities linked to the user, oldest first.
+func (r *PostgresUserRepository) FindIdentities(ctx context.Context, userID int64) ([]dao.Identity, error) {
+	const query = `
+SELECT provider, provider_id, email, email_verified, created_at
+FROM user_identities
+WHERE user_id = $1
+ORDER BY created_at, id
+`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := []dao.Identity{}
+	for rows.Next() {
+		var identity dao.Identity
+		if err := rows.Scan(
+			&identity.Provider,
+			&identity.ProviderID,
+			&identity.Email,
+			&identity.EmailVerified,
+			&identity.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+// FindActivity returns the user's own activity log, oldest first.
+func (r *PostgresUserRepository) FindActivity(ctx context.Context, userID int64) ([]dao.Activity, error) {
+	const query = `
+SELECT action,
+       COALESCE(detail, ''),
+       metadata,
+       COALESCE(ip_address, ''),
+       COALESCE(user_agent, ''),
+       COALESCE(request_id, ''),
+       created_at
+FROM user_logs
+WHERE user_id = $1
+ORDER BY created_at, id
+`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activity := []dao.Activity{}
+	for rows.Next() {
+		var entry dao.Activity
+		if err := rows.Scan(
+			&entry.Action,
+			&entry.Detail,
+			&entry.Metadata,
+			&entry.IPAddress,
+			&entry.UserAgent,
+			&entry.RequestID,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		activity = append(activity, entry)
+	}
+
+	return activity, rows.Err()
+}
+
+// FindReviewStates returns the review state of every item the user studies.
+func (r *PostgresUserRepository) FindReviewStates(ctx context.Context, userID int64) ([]dao.ReviewState, error) {
+	const query = `
+SELECT item_type, item_key, scheduler, repetitions, lapses, interval_days, ease_factor, stability, difficulty,
+       due_at, last_reviewed_at, created_at, updated_at
+FROM review_states
+WHERE user_id = $1
+ORDER BY item_type, item_key
+`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	states := []dao.ReviewState{}
+	for rows.Next() {
+		var (
+			state        dao.ReviewState
+			lastReviewed sql.NullTime
+		)
+		if err := rows.Scan(
+			&state.ItemType,
+			&state.ItemKey,
+			&state.Scheduler,
+			&state.Repetitions,
+			&state.Lapses,
+			&state.IntervalDays,
+			&state.EaseFactor,
+			&state.Stability,
+			&state.Difficulty,
+			&state.DueAt,
+			&lastReviewed,
+			&state.CreatedAt,
+			&state.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if lastReviewed.Valid {
+			state.LastReviewedAt = lastReviewed.Time
+		}
+		states = append(states, state)
+	}
+
+	return states, rows.Err()
+}
+
+// DeleteActivity removes the user's own activity log inside the transaction carried by ctx when there is one,
+// and returns how many entries were removed. Entries of other users that name the user as actor or target
+// are kept; they stop resolving to a name once the user is deleted.
+func (r *PostgresUserRepository) DeleteActivity(ctx context.Context, userID int64) (int64, error) {
+	result, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, "DELETE FROM user_logs WHERE user_id = $1", userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// LockActiveAdmins returns the users that hold role and are not deactivated, and locks their role rows inside
+// the transaction carried by ctx, so two administrators erasing their accounts at once cannot both pass a
+// last-administrator check.
+func (r *PostgresUserRepository) LockActiveAdmins(ctx context.Context, role string) ([]int64, error) {
+	const query = `
+SELECT r.user_id
+FROM user_roles r
+JOIN users u ON u.id = r.user_id
+WHERE r.role = $1 AND u.deactivated_at IS NULL
+ORDER BY r.user_id
+FOR UPDATE OF r
+`
+
+	rows, err := dbtx.Conn(ctx, r.db).QueryContext(ctx, query, role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// DeleteOutboxEvents removes the domain events about the user from the outbox inside the transaction carried by
+// ctx when there is one, sent or not, and returns how many were removed. Every event payload names its user in
+// user_id, and user.created payloads also carry the email and name.
+func (r *PostgresUserRepository) DeleteOutboxEvents(ctx context.Context, userID int64) (int64, error) {
+	const query = `
+DELETE FROM outbox
+WHERE payload -> 'payload' ->> 'user_id' = $1::TEXT
+`
+
+	result, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
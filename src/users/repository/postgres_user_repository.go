@@ -3,13 +3,35 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"gobackend/shared/dbtx"
+	"gobackend/shared/pagination"
+	"gobackend/shared/utils"
 	"gobackend/src/users/dao"
+	"gobackend/src/users/dto"
 	userinterfaces "gobackend/src/users/interfaces"
 )
 
 var _ userinterfaces.UserRepository = (*PostgresUserRepository)(nil)
 
+// lastLoginColumn treats accounts that never signed in again as last seen when they were created.
+const lastLoginColumn = "COALESCE(u.last_login_at, u.created_at)"
+
+const userColumns = "u.id, u.email, u.name, u.provider, u.provider_id, u.picture_url, u.created_at, " +
+	lastLoginColumn + ", u.deactivated_at"
+
+// sortColumns maps the whitelisted sort fields to their SQL expressions.
+var sortColumns = map[string]string{
+	dto.SortName:        "LOWER(u.name)",
+	dto.SortEmail:       "LOWER(u.email)",
+	dto.SortCreatedAt:   "u.created_at",
+	dto.SortLastLoginAt: lastLoginColumn,
+}
+
 // PostgresUserRepository reads user records from Postgres.
 type PostgresUserRepository struct {
 	db *sql.DB
@@ -20,41 +42,299 @@ func NewPostgresUserRepository(db *sql.DB) *PostgresUserRepository {
 	return &PostgresUserRepository{db: db}
 }
 
-// FindAll returns all users ordered by creation date descending.
-func (r *PostgresUserRepository) FindAll(ctx context.Context) ([]dao.User, error) {
+// FindAll returns the users matching the filter in the requested order, together with the total count.
+func (r *PostgresUserRepository) FindAll(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dao.User, int64, error) {
+	whereClause, args := buildFilter(filter)
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users u"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	limitPlaceholder := len(args) + 1
+	offsetPlaceholder := len(args) + 2
+	args = append(args, params.Limit(), params.Offset())
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM users u%s ORDER BY %s LIMIT $%d OFFSET $%d",
+		userColumns,
+		whereClause,
+		orderBy(filter),
+		limitPlaceholder,
+		offsetPlaceholder,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []dao.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, *user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// EnsureSchema verifies that the user_preferences table exists.
+func (r *PostgresUserRepository) EnsureSchema(ctx context.Context) error {
 	const query = `
-SELECT id, email, name, provider, provider_id, picture_url, created_at, COALESCE(last_login_at, created_at)
-FROM users
-ORDER BY created_at DESC
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = 'user_preferences'
 `
-	rows, err := r.db.QueryContext(ctx, query)
+
+	var exists int
+	if err := r.db.QueryRowContext(ctx, query).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user_preferences table not found; please run database migrations")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindByID returns a user, or nil when it does not exist.
+func (r *PostgresUserRepository) FindByID(ctx context.Context, id int64) (*dao.User, error) {
+	query := "SELECT " + userColumns + " FROM users u WHERE u.id = $1"
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, err
 	}
-	defer rows.Close()
 
-	var users []dao.User
+	return user, nil
+}
 
+// FindRoles returns the roles held by the user.
+func (r *PostgresUserRepository) FindRoles(ctx context.Context, userID int64) ([]string, error) {
+	return r.findStrings(ctx, "SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role", userID)
+}
+
+// FindProviders returns the providers the user linked an identity at.
+func (r *PostgresUserRepository) FindProviders(ctx context.Context, userID int64) ([]string, error) {
+	return r.findStrings(ctx, "SELECT provider FROM user_identities WHERE user_id = $1 ORDER BY created_at, id", userID)
+}
+
+func (r *PostgresUserRepository) findStrings(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
 	for rows.Next() {
-		var user dao.User
-		if err := rows.Scan(
-			&user.ID,
-			&user.Email,
-			&user.Name,
-			&user.Provider,
-			&user.ProviderID,
-			&user.PictureURL,
-			&user.CreatedAt,
-			&user.LastLoginAt,
-		); err != nil {
+		var value string
+		if err := rows.Scan(&value); err != nil {
 			return nil, err
 		}
-		users = append(users, user)
+		values = append(values, value)
 	}
 
-	if err := rows.Err(); err != nil {
+	return values, rows.Err()
+}
+
+// Deactivate marks an active user as deactivated, inside the transaction carried by ctx when there is one. It
+// reports false when the user does not exist or is already deactivated.
+func (r *PostgresUserRepository) Deactivate(ctx context.Context, userID int64, now time.Time) (bool, error) {
+	result, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, "UPDATE users SET deactivated_at = $2 WHERE id = $1 AND deactivated_at IS NULL", userID, now)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// Reactivate clears the deactivation of a user. It reports false when the user does not exist or is active.
+func (r *PostgresUserRepository) Reactivate(ctx context.Context, userID int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "UPDATE users SET deactivated_at = NULL WHERE id = $1 AND deactivated_at IS NOT NULL", userID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// Delete removes a user; its identities, roles, sessions, keys and study data are removed with it.
+// It reports false when the user does not exist.
+func (r *PostgresUserRepository) Delete(ctx context.Context, userID int64) (bool, error) {
+	result, err := dbtx.Conn(ctx, r.db).ExecContext(ctx, "DELETE FROM users WHERE id = $1", userID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// FindPreferences returns the stored preferences of a user, or nil when the user never changed them.
+func (r *PostgresUserRepository) FindPreferences(ctx context.Context, userID int64) (*dao.Preferences, error) {
+	const query = `
+SELECT user_id, ui_language, show_romaji, show_furigana, COALESCE(target_jlpt_level, 0), daily_review_goal, timezone, updated_at
+FROM user_preferences
+WHERE user_id = $1
+`
+
+	var prefs dao.Preferences
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&prefs.UserID,
+		&prefs.UILanguage,
+		&prefs.ShowRomaji,
+		&prefs.ShowFurigana,
+		&prefs.TargetJLPTLevel,
+		&prefs.DailyReviewGoal,
+		&prefs.Timezone,
+		&prefs.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+// SaveProfile renames the user and stores its preferences in one transaction.
+func (r *PostgresUserRepository) SaveProfile(ctx context.Context, userID int64, name string, prefs dao.Preferences) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET name = $1 WHERE id = $2`, name, userID); err != nil {
+		return err
+	}
+
+	const query = `
+INSERT INTO user_preferences (
+    user_id, ui_language, show_romaji, show_furigana, target_jlpt_level, daily_review_goal, timezone, updated_at
+)
+VALUES ($1, $2, $3, $4, NULLIF($5, 0), $6, $7, NOW())
+ON CONFLICT (user_id) DO UPDATE
+SET ui_language       = EXCLUDED.ui_language,
+    show_romaji       = EXCLUDED.show_romaji,
+    show_furigana     = EXCLUDED.show_furigana,
+    target_jlpt_level = EXCLUDED.target_jlpt_level,
+    daily_review_goal = EXCLUDED.daily_review_goal,
+    timezone          = EXCLUDED.timezone,
+    updated_at        = EXCLUDED.updated_at
+`
+
+	if _, err := tx.ExecContext(
+		ctx,
+		query,
+		userID,
+		prefs.UILanguage,
+		prefs.ShowRomaji,
+		prefs.ShowFurigana,
+		prefs.TargetJLPTLevel,
+		prefs.DailyReviewGoal,
+		prefs.Timezone,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row rowScanner) (*dao.User, error) {
+	var (
+		user        dao.User
+		deactivated sql.NullTime
+	)
+
+	if err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.Name,
+		&user.Provider,
+		&user.ProviderID,
+		&user.PictureURL,
+		&user.CreatedAt,
+		&user.LastLoginAt,
+		&deactivated,
+	); err != nil {
 		return nil, err
 	}
 
-	return users, nil
+	if deactivated.Valid {
+		user.DeactivatedAt = deactivated.Time
+	}
+
+	return &user, nil
+}
+
+func buildFilter(filter dto.Filter) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Search != "" {
+		add("(u.name ILIKE $%[1]d ESCAPE '\\' OR u.email ILIKE $%[1]d ESCAPE '\\')", "%"+utils.EscapeLike(filter.Search)+"%")
+	}
+	if filter.Provider != "" {
+		add("EXISTS (SELECT 1 FROM user_identities i WHERE i.user_id = u.id AND i.provider = $%d)", filter.Provider)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		add("u.created_at >= $%d", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		add("u.created_at < $%d", filter.CreatedTo)
+	}
+	if !filter.LastLoginFrom.IsZero() {
+		add(lastLoginColumn+" >= $%d", filter.LastLoginFrom)
+	}
+	if !filter.LastLoginTo.IsZero() {
+		add(lastLoginColumn+" < $%d", filter.LastLoginTo)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// orderBy returns the ORDER BY expression for the filter, breaking ties by ID so pages stay stable.
+func orderBy(filter dto.Filter) string {
+	column, ok := sortColumns[filter.Sort]
+	if !ok {
+		return "u.created_at DESC, u.id DESC"
+	}
+
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+
+	return column + " " + direction + ", u.id " + direction
 }
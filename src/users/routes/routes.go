@@ -8,4 +8,12 @@ import (
 
 func Register(router gin.IRoutes, handler *delivery.Handler) {
 	router.GET("/api/users", handler.ListUsers)
+	router.GET("/api/users/:reference", handler.GetUser)
+	router.POST("/api/users/:reference/deactivate", handler.DeactivateUser)
+	router.POST("/api/users/:reference/reactivate", handler.ReactivateUser)
+	router.DELETE("/api/users/:reference", handler.DeleteUser)
+	router.GET("/api/me", handler.GetProfile)
+	router.PATCH("/api/me", handler.UpdateProfile)
+	router.DELETE("/api/me", handler.EraseAccount)
+	router.GET("/api/me/export", handler.ExportData)
 }
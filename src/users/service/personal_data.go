@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gobackend/shared/requestinfo"
+	"gobackend/src/auth/rbac"
+	logdto "gobackend/src/logs/dto"
+	"gobackend/src/users/dao"
+	"gobackend/src/users/dto"
+)
+
+// erasureLoginWindow is how recently the access token of an erasure request must have been issued when the user
+// has no second factor to confirm it with.
+const erasureLoginWindow = 5 * time.Minute
+
+// ExportData collects the user's account, preferences, roles, identities, activity log and review states for a
+// data subject access request. The export itself is written to the activity log.
+func (s *UserServiceImpl) ExportData(ctx context.Context, userID int64) (*dto.Export, error) {
+	user, prefs, err := s.loadProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.toProfile(*user, prefs)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.repo.FindRoles(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user roles: %w", err)
+	}
+
+	identities, err := s.repo.FindIdentities(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user identities: %w", err)
+	}
+
+	activity, err := s.repo.FindActivity(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user activity: %w", err)
+	}
+
+	states, err := s.repo.FindReviewStates(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find review states: %w", err)
+	}
+
+	export := &dto.Export{
+		GeneratedAt: time.Now().UTC(),
+		Account: dto.ExportAccount{
+			Reference:   profile.Reference,
+			Email:       user.Email,
+			Name:        user.Name,
+			PictureURL:  user.PictureURL,
+			Provider:    user.Provider,
+			ProviderID:  user.ProviderID,
+			CreatedAt:   user.CreatedAt,
+			LastLoginAt: user.LastLoginAt,
+		},
+		Preferences: profile.Preferences,
+		Roles:       roles,
+		Identities:  make([]dto.ExportIdentity, 0, len(identities)),
+		Activity:    make([]dto.ExportActivity, 0, len(activity)),
+		Reviews:     make([]dto.ExportReview, 0, len(states)),
+	}
+	if !user.DeactivatedAt.IsZero() {
+		deactivatedAt := user.DeactivatedAt
+		export.Account.DeactivatedAt = &deactivatedAt
+	}
+
+	for _, identity := range identities {
+		export.Identities = append(export.Identities, dto.ExportIdentity{
+			Provider:      identity.Provider,
+			ProviderID:    identity.ProviderID,
+			Email:         identity.Email,
+			EmailVerified: identity.EmailVerified,
+			LinkedAt:      identity.CreatedAt,
+		})
+	}
+
+	for _, entry := range activity {
+		export.Activity = append(export.Activity, toExportActivity(entry))
+	}
+
+	for _, state := range states {
+		export.Reviews = append(export.Reviews, toExportReview(state))
+	}
+
+	if err := s.audit(ctx, userID, 0, logdto.ActionDataExported, "exported personal data", logdto.Metadata{
+		"activity_entries": len(export.Activity),
+		"review_states":    len(export.Reviews),
+	}); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// EraseAccount removes the user, its activity log, the domain events about it that are still in the outbox and,
+// through the database cascades, its identities, roles, sessions, API keys, preferences and review states in one
+// transaction. A system tombstone targeting only the
+// erased ID is left in the audit trail; the client IP and user agent of the request are not kept with it.
+// Users with MFA confirm the erasure with a code, others with an access token issued in the last five minutes.
+// The last active administrator cannot erase their account.
+func (s *UserServiceImpl) EraseAccount(ctx context.Context, userID int64, confirmation dto.ErasureConfirmation) error {
+	if err := s.confirmErasure(ctx, userID, confirmation); err != nil {
+		return err
+	}
+
+	return s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		admins, err := s.repo.LockActiveAdmins(ctx, rbac.RoleAdmin)
+		if err != nil {
+			return fmt.Errorf("find administrators: %w", err)
+		}
+		if len(admins) == 1 && admins[0] == userID {
+			return ErrLastAdmin
+		}
+
+		removed, err := s.repo.DeleteActivity(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("delete user activity: %w", err)
+		}
+
+		events, err := s.repo.DeleteOutboxEvents(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("delete user outbox events: %w", err)
+		}
+
+		deleted, err := s.repo.Delete(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if !deleted {
+			return ErrUserNotFound
+		}
+
+		tombstoneCtx := ctx
+		if info, ok := requestinfo.FromContext(ctx); ok {
+			tombstoneCtx = requestinfo.WithInfo(ctx, requestinfo.Info{RequestID: info.RequestID})
+		}
+
+		return s.audit(tombstoneCtx, 0, userID, logdto.ActionAccountErased, fmt.Sprintf("erased account %d at the user's request", userID), logdto.Metadata{
+			"activity_entries_removed": removed,
+			"outbox_events_removed":    events,
+		})
+	})
+}
+
+func (s *UserServiceImpl) confirmErasure(ctx context.Context, userID int64, confirmation dto.ErasureConfirmation) error {
+	enabled, err := s.mfa.IsEnabled(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("check mfa: %w", err)
+	}
+
+	if enabled {
+		if confirmation.MFACode == "" {
+			return ErrErasureNotConfirmed
+		}
+		return s.mfa.VerifyCode(ctx, userID, confirmation.MFACode)
+	}
+
+	if confirmation.IssuedAt.IsZero() || time.Since(confirmation.IssuedAt) > erasureLoginWindow {
+		return ErrErasureNotConfirmed
+	}
+
+	return nil
+}
+
+func toExportActivity(entry dao.Activity) dto.ExportActivity {
+	metadata := json.RawMessage(entry.Metadata)
+	if len(metadata) == 0 {
+		metadata = json.RawMessage("{}")
+	}
+
+	return dto.ExportActivity{
+		Action:    entry.Action,
+		Detail:    entry.Detail,
+		Metadata:  metadata,
+		IPAddress: entry.IPAddress,
+		UserAgent: entry.UserAgent,
+		RequestID: entry.RequestID,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+func toExportReview(state dao.ReviewState) dto.ExportReview {
+	review := dto.ExportReview{
+		ItemType:     state.ItemType,
+		ItemKey:      state.ItemKey,
+		Scheduler:    state.Scheduler,
+		Repetitions:  state.Repetitions,
+		Lapses:       state.Lapses,
+		IntervalDays: state.IntervalDays,
+		EaseFactor:   state.EaseFactor,
+		Stability:    state.Stability,
+		Difficulty:   state.Difficulty,
+		DueAt:        state.DueAt,
+		CreatedAt:    state.CreatedAt,
+		UpdatedAt:    state.UpdatedAt,
+	}
+	if !state.LastReviewedAt.IsZero() {
+		lastReviewedAt := state.LastReviewedAt
+		review.LastReviewedAt = &lastReviewedAt
+	}
+
+	return review
+}
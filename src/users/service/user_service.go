@@ -2,56 +2,373 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
+	"gobackend/shared/dbtx"
 	"gobackend/shared/identity"
+	"gobackend/shared/pagination"
+	logdto "gobackend/src/logs/dto"
+	loginterfaces "gobackend/src/logs/interfaces"
+	"gobackend/src/users/dao"
 	"gobackend/src/users/dto"
 	userinterfaces "gobackend/src/users/interfaces"
 )
 
 var _ userinterfaces.UserService = (*UserServiceImpl)(nil)
 
-// UserServiceImpl provides user read operations.
+var (
+	// ErrUserNotFound indicates the user does not exist.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrUserDeactivated indicates the user is already deactivated.
+	ErrUserDeactivated = errors.New("user is already deactivated")
+	// ErrUserActive indicates the user is not deactivated.
+	ErrUserActive = errors.New("user is not deactivated")
+	// ErrSelfAdministration prevents administrators from deactivating or deleting their own account.
+	ErrSelfAdministration = errors.New("administrators cannot deactivate or delete their own account")
+	// ErrLastAdmin prevents the only active administrator from erasing their account.
+	ErrLastAdmin = errors.New("the last administrator cannot erase their account")
+	// ErrErasureNotConfirmed indicates the erasure was neither confirmed with an MFA code nor requested right after
+	// signing in.
+	ErrErasureNotConfirmed = errors.New("confirm the erasure with mfa_code, or sign in again and retry within five minutes")
+)
+
+// UserServiceImpl lists and administers users and lets users edit their own profile.
 type UserServiceImpl struct {
 	repo       userinterfaces.UserRepository
 	refEncoder *identity.UserReferenceEncoder
+	logService loginterfaces.Service
+	transactor dbtx.Transactor
+	mfa        userinterfaces.SecondFactor
+	sessions   userinterfaces.SessionRevoker
 }
 
 // NewUserService creates a new UserServiceImpl instance.
-func NewUserService(repo userinterfaces.UserRepository, refEncoder *identity.UserReferenceEncoder) *UserServiceImpl {
-	return &UserServiceImpl{repo: repo, refEncoder: refEncoder}
+func NewUserService(
+	repo userinterfaces.UserRepository,
+	refEncoder *identity.UserReferenceEncoder,
+	logService loginterfaces.Service,
+	transactor dbtx.Transactor,
+	mfa userinterfaces.SecondFactor,
+	sessions userinterfaces.SessionRevoker,
+) *UserServiceImpl {
+	return &UserServiceImpl{
+		repo:       repo,
+		refEncoder: refEncoder,
+		logService: logService,
+		transactor: transactor,
+		mfa:        mfa,
+		sessions:   sessions,
+	}
 }
 
-// ListUsers retrieves all users and maps them into DTOs.
-func (s *UserServiceImpl) ListUsers(ctx context.Context) ([]dto.User, error) {
-	users, err := s.repo.FindAll(ctx)
+// ListUsers retrieves a page of users matching the filter and maps them into DTOs.
+func (s *UserServiceImpl) ListUsers(ctx context.Context, params pagination.Params, filter dto.Filter) ([]dto.User, int64, error) {
+	users, total, err := s.repo.FindAll(ctx, params, filter)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	result := make([]dto.User, 0, len(users))
 	for _, user := range users {
-		reference, refErr := s.refEncoder.Encode(user.ID)
-		if refErr != nil {
-			return nil, refErr
+		item, err := s.toUser(user)
+		if err != nil {
+			return nil, 0, err
 		}
+		result = append(result, *item)
+	}
+
+	return result, total, nil
+}
+
+// GetUser returns the account of a user together with its roles and linked providers.
+func (s *UserServiceImpl) GetUser(ctx context.Context, userID int64) (*dto.UserDetail, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toUserDetail(ctx, *user)
+}
 
-		maskedEmail := maskEmail(user.Email)
+// DeactivateUser deactivates a user on behalf of actorID and ends its sessions in the same transaction: refresh
+// tokens are revoked and access tokens issued so far stop validating even after a reactivation.
+func (s *UserServiceImpl) DeactivateUser(ctx context.Context, actorID, userID int64) (*dto.UserDetail, error) {
+	if actorID == userID {
+		return nil, ErrSelfAdministration
+	}
 
-		result = append(result, dto.User{
-			Reference:   reference,
-			Email:       maskedEmail,
-			Name:        user.Name,
-			PictureURL:  user.PictureURL,
-			LastLoginAt: user.LastLoginAt,
-			CreatedAt:   user.CreatedAt,
-			Provider:    user.Provider,
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.DeactivatedAt.IsZero() {
+		return nil, ErrUserDeactivated
+	}
+
+	now := time.Now()
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		deactivated, err := s.repo.Deactivate(ctx, userID, now)
+		if err != nil {
+			return fmt.Errorf("deactivate user: %w", err)
+		}
+		if !deactivated {
+			return ErrUserDeactivated
+		}
+
+		if err := s.sessions.SignOutEverywhere(ctx, actorID, userID); err != nil {
+			return fmt.Errorf("end user sessions: %w", err)
+		}
+
+		return s.audit(ctx, actorID, userID, logdto.ActionUserDeactivated, fmt.Sprintf("deactivated user %d", userID), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	user.DeactivatedAt = now
+
+	return s.toUserDetail(ctx, *user)
+}
+
+// ReactivateUser lets a deactivated user sign in again on behalf of actorID.
+func (s *UserServiceImpl) ReactivateUser(ctx context.Context, actorID, userID int64) (*dto.UserDetail, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeactivatedAt.IsZero() {
+		return nil, ErrUserActive
+	}
+
+	reactivated, err := s.repo.Reactivate(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("reactivate user: %w", err)
+	}
+	if !reactivated {
+		return nil, ErrUserActive
+	}
+	user.DeactivatedAt = time.Time{}
+
+	if err := s.audit(ctx, actorID, userID, logdto.ActionUserReactivated, fmt.Sprintf("reactivated user %d", userID), nil); err != nil {
+		return nil, err
+	}
+
+	return s.toUserDetail(ctx, *user)
+}
+
+// DeleteUser permanently removes a user on behalf of actorID. The audit entry is written in the same
+// transaction and keeps the user's email and name, since its target no longer resolves to an account.
+func (s *UserServiceImpl) DeleteUser(ctx context.Context, actorID, userID int64) error {
+	if actorID == userID {
+		return ErrSelfAdministration
+	}
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		deleted, err := s.repo.Delete(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if !deleted {
+			return ErrUserNotFound
+		}
+
+		return s.audit(ctx, actorID, userID, logdto.ActionUserDeleted, fmt.Sprintf("deleted user %d", userID), logdto.Metadata{
+			"email": user.Email,
+			"name":  user.Name,
 		})
+	})
+}
+
+func (s *UserServiceImpl) findUser(ctx context.Context, userID int64) (*dao.User, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (s *UserServiceImpl) toUser(user dao.User) (*dto.User, error) {
+	reference, err := s.refEncoder.Encode(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.User{
+		Reference:   reference,
+		Email:       maskEmail(user.Email),
+		Name:        user.Name,
+		PictureURL:  user.PictureURL,
+		LastLoginAt: user.LastLoginAt,
+		CreatedAt:   user.CreatedAt,
+		Provider:    user.Provider,
+	}
+	if !user.DeactivatedAt.IsZero() {
+		deactivatedAt := user.DeactivatedAt
+		result.DeactivatedAt = &deactivatedAt
 	}
 
 	return result, nil
 }
 
+func (s *UserServiceImpl) toUserDetail(ctx context.Context, user dao.User) (*dto.UserDetail, error) {
+	item, err := s.toUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.repo.FindRoles(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("find user roles: %w", err)
+	}
+
+	providers, err := s.repo.FindProviders(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("find user providers: %w", err)
+	}
+
+	return &dto.UserDetail{User: *item, Roles: roles, Providers: providers}, nil
+}
+
+func (s *UserServiceImpl) audit(ctx context.Context, actorID, targetID int64, action logdto.Action, detail string, metadata logdto.Metadata) error {
+	if err := s.logService.Record(ctx, logdto.NewLog{
+		UserID:   actorID,
+		Action:   action,
+		Detail:   detail,
+		TargetID: targetID,
+		Metadata: metadata,
+	}); err != nil {
+		return fmt.Errorf("record %s log: %w", action, err)
+	}
+
+	return nil
+}
+
+// GetProfile returns the user's own account together with its preferences.
+func (s *UserServiceImpl) GetProfile(ctx context.Context, userID int64) (*dto.Profile, error) {
+	user, prefs, err := s.loadProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toProfile(*user, prefs)
+}
+
+// UpdateProfile applies the fields set in req, which must have passed validation.ValidateProfileUpdate.
+func (s *UserServiceImpl) UpdateProfile(ctx context.Context, userID int64, req dto.ProfileUpdateRequest) (*dto.Profile, error) {
+	user, prefs, err := s.loadProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	if req.Name != nil && *req.Name != user.Name {
+		user.Name = *req.Name
+		changed = append(changed, "name")
+	}
+	if req.UILanguage != nil && *req.UILanguage != prefs.UILanguage {
+		prefs.UILanguage = *req.UILanguage
+		changed = append(changed, "ui_language")
+	}
+	if req.ShowRomaji != nil && *req.ShowRomaji != prefs.ShowRomaji {
+		prefs.ShowRomaji = *req.ShowRomaji
+		changed = append(changed, "show_romaji")
+	}
+	if req.ShowFurigana != nil && *req.ShowFurigana != prefs.ShowFurigana {
+		prefs.ShowFurigana = *req.ShowFurigana
+		changed = append(changed, "show_furigana")
+	}
+	if req.TargetJLPTLevel != nil && *req.TargetJLPTLevel != prefs.TargetJLPTLevel {
+		prefs.TargetJLPTLevel = *req.TargetJLPTLevel
+		changed = append(changed, "target_jlpt_level")
+	}
+	if req.DailyReviewGoal != nil && *req.DailyReviewGoal != prefs.DailyReviewGoal {
+		prefs.DailyReviewGoal = *req.DailyReviewGoal
+		changed = append(changed, "daily_review_goal")
+	}
+	if req.Timezone != nil && *req.Timezone != prefs.Timezone {
+		prefs.Timezone = *req.Timezone
+		changed = append(changed, "timezone")
+	}
+
+	if len(changed) > 0 {
+		if err := s.repo.SaveProfile(ctx, userID, user.Name, prefs); err != nil {
+			return nil, fmt.Errorf("save profile: %w", err)
+		}
+
+		if err := s.audit(ctx, userID, userID, logdto.ActionProfileUpdated, "updated "+strings.Join(changed, ", "), logdto.Metadata{"fields": changed}); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.toProfile(*user, prefs)
+}
+
+// loadProfile returns the user and its preferences, falling back to the defaults when none are stored.
+func (s *UserServiceImpl) loadProfile(ctx context.Context, userID int64) (*dao.User, dao.Preferences, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, dao.Preferences{}, fmt.Errorf("find user: %w", err)
+	}
+	if user == nil {
+		return nil, dao.Preferences{}, ErrUserNotFound
+	}
+
+	stored, err := s.repo.FindPreferences(ctx, userID)
+	if err != nil {
+		return nil, dao.Preferences{}, fmt.Errorf("find preferences: %w", err)
+	}
+	if stored != nil {
+		return user, *stored, nil
+	}
+
+	defaults := dto.DefaultPreferences()
+	return user, dao.Preferences{
+		UserID:          userID,
+		UILanguage:      defaults.UILanguage,
+		ShowRomaji:      defaults.ShowRomaji,
+		ShowFurigana:    defaults.ShowFurigana,
+		TargetJLPTLevel: defaults.TargetJLPTLevel,
+		DailyReviewGoal: defaults.DailyReviewGoal,
+		Timezone:        defaults.Timezone,
+	}, nil
+}
+
+func (s *UserServiceImpl) toProfile(user dao.User, prefs dao.Preferences) (*dto.Profile, error) {
+	reference, err := s.refEncoder.Encode(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.Profile{
+		Reference:   reference,
+		Email:       user.Email,
+		Name:        user.Name,
+		PictureURL:  user.PictureURL,
+		Provider:    user.Provider,
+		CreatedAt:   user.CreatedAt,
+		LastLoginAt: user.LastLoginAt,
+		Preferences: dto.Preferences{
+			UILanguage:      prefs.UILanguage,
+			ShowRomaji:      prefs.ShowRomaji,
+			ShowFurigana:    prefs.ShowFurigana,
+			TargetJLPTLevel: prefs.TargetJLPTLevel,
+			DailyReviewGoal: prefs.DailyReviewGoal,
+			Timezone:        prefs.Timezone,
+		},
+	}, nil
+}
+
 func maskEmail(email string) string {
 	const maskedSegment = "*****"
 
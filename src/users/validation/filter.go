@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gobackend/src/users/dto"
+)
+
+const (
+	dateLayout        = "2006-01-02"
+	maxSearchLength   = 100
+	maxProviderLength = 64
+)
+
+var sortFields = map[string]struct{}{
+	dto.SortName:        {},
+	dto.SortEmail:       {},
+	dto.SortCreatedAt:   {},
+	dto.SortLastLoginAt: {},
+}
+
+var (
+	// ErrInvalidSearch indicates the search term is too long.
+	ErrInvalidSearch = errors.New("q must not exceed 100 characters")
+	// ErrInvalidProvider indicates the provider filter is too long.
+	ErrInvalidProvider = errors.New("provider must not exceed 64 characters")
+	// ErrInvalidDate indicates a date bound is neither YYYY-MM-DD nor RFC 3339.
+	ErrInvalidDate = errors.New("dates must be YYYY-MM-DD or RFC 3339 timestamps")
+	// ErrInvalidDateRange indicates a range ends before it starts.
+	ErrInvalidDateRange = errors.New("date ranges must not end before they start")
+	// ErrInvalidSort indicates the sort field is not whitelisted.
+	ErrInvalidSort = errors.New("sort must be name, email, created_at or last_login_at, optionally prefixed with - for descending order")
+)
+
+// FilterQuery carries the raw query values of the user directory.
+type FilterQuery struct {
+	Search        string
+	Provider      string
+	CreatedFrom   string
+	CreatedTo     string
+	LastLoginFrom string
+	LastLoginTo   string
+	Sort          string
+}
+
+// ParseFilter builds a Filter from raw query values, ignoring empty ones. A date without a time covers the whole
+// day in UTC, so created_to=2024-05-31 includes accounts created on May 31.
+func ParseFilter(query FilterQuery) (dto.Filter, error) {
+	filter := dto.Filter{
+		Search:   strings.TrimSpace(query.Search),
+		Provider: strings.ToLower(strings.TrimSpace(query.Provider)),
+	}
+
+	if len(filter.Search) > maxSearchLength {
+		return dto.Filter{}, ErrInvalidSearch
+	}
+	if len(filter.Provider) > maxProviderLength {
+		return dto.Filter{}, ErrInvalidProvider
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseBound(query.CreatedFrom, false); err != nil {
+		return dto.Filter{}, err
+	}
+	if filter.CreatedTo, err = parseBound(query.CreatedTo, true); err != nil {
+		return dto.Filter{}, err
+	}
+	if filter.LastLoginFrom, err = parseBound(query.LastLoginFrom, false); err != nil {
+		return dto.Filter{}, err
+	}
+	if filter.LastLoginTo, err = parseBound(query.LastLoginTo, true); err != nil {
+		return dto.Filter{}, err
+	}
+
+	if isReversed(filter.CreatedFrom, filter.CreatedTo) || isReversed(filter.LastLoginFrom, filter.LastLoginTo) {
+		return dto.Filter{}, ErrInvalidDateRange
+	}
+
+	sort := strings.TrimSpace(query.Sort)
+	if sort != "" {
+		filter.Descending = strings.HasPrefix(sort, "-")
+		filter.Sort = strings.TrimPrefix(sort, "-")
+		if _, ok := sortFields[filter.Sort]; !ok {
+			return dto.Filter{}, ErrInvalidSort
+		}
+	}
+
+	return filter, nil
+}
+
+// parseBound parses a date or timestamp. When end is set, a date without a time is moved to the next day so the
+// exclusive end still covers it.
+func parseBound(raw string, end bool) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	if day, err := time.Parse(dateLayout, raw); err == nil {
+		if end {
+			return day.AddDate(0, 0, 1), nil
+		}
+		return day, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, raw)
+	}
+
+	return value, nil
+}
+
+func isReversed(from, to time.Time) bool {
+	return !from.IsZero() && !to.IsZero() && to.Before(from)
+}
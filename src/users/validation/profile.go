@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"time"
+	// Embedded so timezones validate on hosts without a zoneinfo database.
+	_ "time/tzdata"
+	"unicode/utf8"
+
+	"gobackend/src/users/dto"
+)
+
+const (
+	maxNameLength      = 100
+	maxDailyReviewGoal = 1000
+)
+
+// uiLanguages lists the languages the web client is translated into.
+var uiLanguages = map[string]struct{}{
+	"en": {},
+	"ja": {},
+}
+
+var (
+	// ErrInvalidName indicates the display name is empty or too long.
+	ErrInvalidName = errors.New("name must contain 1 to 100 characters")
+	// ErrInvalidUILanguage indicates the UI language is not supported.
+	ErrInvalidUILanguage = errors.New("ui_language must be en or ja")
+	// ErrInvalidTargetJLPTLevel indicates the target level is outside 1 (N1) to 5 (N5).
+	ErrInvalidTargetJLPTLevel = errors.New("target_jlpt_level must be between 1 (N1) and 5 (N5), or 0 to clear it")
+	// ErrInvalidDailyReviewGoal indicates the daily goal is not a positive number within bounds.
+	ErrInvalidDailyReviewGoal = errors.New("daily_review_goal must be between 1 and 1000")
+	// ErrInvalidTimezone indicates the timezone is not an IANA name such as Asia/Tokyo.
+	ErrInvalidTimezone = errors.New("timezone must be an IANA timezone such as Asia/Tokyo")
+)
+
+// ValidateProfileUpdate checks every field set in the request and trims the text fields in place.
+func ValidateProfileUpdate(req *dto.ProfileUpdateRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+			return ErrInvalidName
+		}
+		req.Name = &name
+	}
+
+	if req.UILanguage != nil {
+		language := strings.ToLower(strings.TrimSpace(*req.UILanguage))
+		if _, ok := uiLanguages[language]; !ok {
+			return ErrInvalidUILanguage
+		}
+		req.UILanguage = &language
+	}
+
+	if req.TargetJLPTLevel != nil && (*req.TargetJLPTLevel < 0 || *req.TargetJLPTLevel > 5) {
+		return ErrInvalidTargetJLPTLevel
+	}
+
+	if req.DailyReviewGoal != nil && (*req.DailyReviewGoal < 1 || *req.DailyReviewGoal > maxDailyReviewGoal) {
+		return ErrInvalidDailyReviewGoal
+	}
+
+	if req.Timezone != nil {
+		timezone := strings.TrimSpace(*req.Timezone)
+		// LoadLocation also accepts "" and "Local", which only make sense on the server.
+		if timezone == "" || timezone == "Local" {
+			return ErrInvalidTimezone
+		}
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return ErrInvalidTimezone
+		}
+		req.Timezone = &timezone
+	}
+
+	return nil
+}
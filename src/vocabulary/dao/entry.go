@@ -0,0 +1,42 @@
+package dao
+
+import "time"
+
+// Entry represents a persisted JMdict entry with its forms and senses.
+type Entry struct {
+	ID          int64       `json:"id"`
+	Sequence    int64       `json:"sequence"`
+	IsCommon    bool        `json:"is_common"`
+	ContentHash string      `json:"content_hash"`
+	KanjiForms  []KanjiForm `json:"kanji_forms"`
+	KanaForms   []KanaForm  `json:"kana_forms"`
+	Senses      []Sense     `json:"senses"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// KanjiForm represents a <k_ele> element of an entry.
+type KanjiForm struct {
+	Text       string   `json:"text"`
+	Info       []string `json:"info"`
+	Priorities []string `json:"priorities"`
+}
+
+// KanaForm represents a <r_ele> element of an entry.
+type KanaForm struct {
+	Text         string   `json:"text"`
+	Romaji       string   `json:"romaji"`
+	NoKanji      bool     `json:"no_kanji"`
+	Restrictions []string `json:"restrictions"`
+	Info         []string `json:"info"`
+	Priorities   []string `json:"priorities"`
+}
+
+// Sense represents a <sense> element of an entry.
+type Sense struct {
+	PartsOfSpeech []string `json:"parts_of_speech"`
+	Glosses       []string `json:"glosses"`
+	Misc          []string `json:"misc"`
+	Fields        []string `json:"fields"`
+	Info          []string `json:"info"`
+}
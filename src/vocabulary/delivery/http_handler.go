@@ -0,0 +1,41 @@
+package delivery
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"gobackend/shared/pagination"
+	"gobackend/shared/response"
+	vocabularyinterfaces "gobackend/src/vocabulary/interfaces"
+	vocabularyservice "gobackend/src/vocabulary/service"
+)
+
+// Handler exposes vocabulary HTTP endpoints.
+type Handler struct {
+	service vocabularyinterfaces.Service
+}
+
+// NewHandler builds a Handler instance.
+func NewHandler(service vocabularyinterfaces.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// Search returns paginated dictionary entries matching the q query parameter.
+func (h *Handler) Search(ctx *gin.Context) {
+	params := pagination.FromQuery(ctx)
+
+	entries, total, err := h.service.Search(ctx.Request.Context(), params, ctx.Query("q"))
+	if err != nil {
+		if errors.Is(err, vocabularyservice.ErrEmptyQuery) {
+			response.BadRequest(ctx, err.Error(), nil)
+			return
+		}
+
+		response.InternalError(ctx, "failed to search vocabulary", err.Error())
+		return
+	}
+
+	meta := pagination.NewMetadata(total, params)
+	response.Paginated(ctx, "vocabulary retrieved successfully", entries, meta)
+}
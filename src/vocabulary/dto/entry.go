@@ -0,0 +1,36 @@
+package dto
+
+// Entry represents a dictionary entry returned to API consumers.
+type Entry struct {
+	Sequence   int64       `json:"sequence"`
+	IsCommon   bool        `json:"is_common"`
+	KanjiForms []KanjiForm `json:"kanji_forms"`
+	KanaForms  []KanaForm  `json:"kana_forms"`
+	Senses     []Sense     `json:"senses"`
+}
+
+// KanjiForm is a written form of an entry.
+type KanjiForm struct {
+	Text       string   `json:"text"`
+	Info       []string `json:"info,omitempty"`
+	Priorities []string `json:"priorities,omitempty"`
+}
+
+// KanaForm is a reading of an entry.
+type KanaForm struct {
+	Text         string   `json:"text"`
+	Romaji       string   `json:"romaji"`
+	NoKanji      bool     `json:"no_kanji,omitempty"`
+	Restrictions []string `json:"restrictions,omitempty"`
+	Info         []string `json:"info,omitempty"`
+	Priorities   []string `json:"priorities,omitempty"`
+}
+
+// Sense is one meaning of an entry.
+type Sense struct {
+	PartsOfSpeech []string `json:"parts_of_speech"`
+	Glosses       []string `json:"glosses"`
+	Misc          []string `json:"misc,omitempty"`
+	Fields        []string `json:"fields,omitempty"`
+	Info          []string `json:"info,omitempty"`
+}
@@ -0,0 +1,202 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gobackend/shared/utils"
+	"gobackend/src/vocabulary/dao"
+)
+
+const (
+	jmdictEntryElement = "entry"
+	englishGlossLang   = "eng"
+)
+
+// entityDeclaration matches <!ENTITY name "description"> lines of the JMdict DTD.
+var entityDeclaration = regexp.MustCompile(`<!ENTITY\s+(\S+)\s+"[^"]*">`)
+
+// commonPriorities are the priority tags JMdict uses to flag an entry as common.
+var commonPriorities = map[string]struct{}{
+	"news1": {},
+	"ichi1": {},
+	"spec1": {},
+	"spec2": {},
+	"gai1":  {},
+}
+
+type jmdictEntry struct {
+	Sequence   int64 `xml:"ent_seq"`
+	KanjiForms []struct {
+		Text       string   `xml:"keb"`
+		Info       []string `xml:"ke_inf"`
+		Priorities []string `xml:"ke_pri"`
+	} `xml:"k_ele"`
+	KanaForms []struct {
+		Text         string    `xml:"reb"`
+		NoKanji      *struct{} `xml:"re_nokanji"`
+		Restrictions []string  `xml:"re_restr"`
+		Info         []string  `xml:"re_inf"`
+		Priorities   []string  `xml:"re_pri"`
+	} `xml:"r_ele"`
+	Senses []struct {
+		PartsOfSpeech []string `xml:"pos"`
+		Fields        []string `xml:"field"`
+		Misc          []string `xml:"misc"`
+		Info          []string `xml:"s_inf"`
+		Glosses       []struct {
+			Language string `xml:"lang,attr"`
+			Value    string `xml:",chardata"`
+		} `xml:"gloss"`
+	} `xml:"sense"`
+}
+
+// DecodeJMdict streams a JMdict document and calls fn once per <entry> element.
+// Entity references such as &n; or &v1; are kept as their short codes instead of being expanded.
+// Only English glosses are kept. JMdict leaves <pos> empty when it repeats the previous sense, so those are carried over.
+func DecodeJMdict(r io.Reader, fn func(dao.Entry) error) error {
+	decoder := xml.NewDecoder(r)
+	decoder.Entity = map[string]string{}
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read jmdict token: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.Directive:
+			for _, match := range entityDeclaration.FindAllSubmatch(element, -1) {
+				name := string(match[1])
+				decoder.Entity[name] = name
+			}
+		case xml.StartElement:
+			if element.Name.Local != jmdictEntryElement {
+				continue
+			}
+
+			var entry jmdictEntry
+			if err := decoder.DecodeElement(&entry, &element); err != nil {
+				return fmt.Errorf("decode jmdict entry: %w", err)
+			}
+
+			mapped, err := entry.toEntry()
+			if err != nil {
+				return err
+			}
+
+			if err := fn(mapped); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (e jmdictEntry) toEntry() (dao.Entry, error) {
+	entry := dao.Entry{
+		Sequence:   e.Sequence,
+		KanjiForms: make([]dao.KanjiForm, 0, len(e.KanjiForms)),
+		KanaForms:  make([]dao.KanaForm, 0, len(e.KanaForms)),
+		Senses:     make([]dao.Sense, 0, len(e.Senses)),
+	}
+
+	for _, form := range e.KanjiForms {
+		entry.KanjiForms = append(entry.KanjiForms, dao.KanjiForm{
+			Text:       strings.TrimSpace(form.Text),
+			Info:       nonNil(form.Info),
+			Priorities: nonNil(form.Priorities),
+		})
+		entry.IsCommon = entry.IsCommon || hasCommonPriority(form.Priorities)
+	}
+
+	for _, form := range e.KanaForms {
+		text := strings.TrimSpace(form.Text)
+		entry.KanaForms = append(entry.KanaForms, dao.KanaForm{
+			Text:         text,
+			Romaji:       utils.KanaToRomaji(text),
+			NoKanji:      form.NoKanji != nil,
+			Restrictions: nonNil(form.Restrictions),
+			Info:         nonNil(form.Info),
+			Priorities:   nonNil(form.Priorities),
+		})
+		entry.IsCommon = entry.IsCommon || hasCommonPriority(form.Priorities)
+	}
+
+	var previousPOS []string
+	for _, sense := range e.Senses {
+		glosses := make([]string, 0, len(sense.Glosses))
+		for _, gloss := range sense.Glosses {
+			value := strings.TrimSpace(gloss.Value)
+			if value == "" || (gloss.Language != "" && gloss.Language != englishGlossLang) {
+				continue
+			}
+			glosses = append(glosses, value)
+		}
+		if len(glosses) == 0 {
+			continue
+		}
+
+		pos := sense.PartsOfSpeech
+		if len(pos) == 0 {
+			pos = previousPOS
+		}
+		previousPOS = pos
+
+		entry.Senses = append(entry.Senses, dao.Sense{
+			PartsOfSpeech: nonNil(pos),
+			Glosses:       glosses,
+			Misc:          nonNil(sense.Misc),
+			Fields:        nonNil(sense.Fields),
+			Info:          nonNil(sense.Info),
+		})
+	}
+
+	hash, err := contentHash(entry)
+	if err != nil {
+		return dao.Entry{}, err
+	}
+	entry.ContentHash = hash
+
+	return entry, nil
+}
+
+func contentHash(entry dao.Entry) (string, error) {
+	payload, err := json.Marshal(struct {
+		IsCommon   bool
+		KanjiForms []dao.KanjiForm
+		KanaForms  []dao.KanaForm
+		Senses     []dao.Sense
+	}{entry.IsCommon, entry.KanjiForms, entry.KanaForms, entry.Senses})
+	if err != nil {
+		return "", fmt.Errorf("hash jmdict entry %d: %w", entry.Sequence, err)
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func hasCommonPriority(priorities []string) bool {
+	for _, priority := range priorities {
+		if _, ok := commonPriorities[priority]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/vocabulary/dao"
+	"gobackend/src/vocabulary/dto"
+)
+
+// Repository describes persistence operations for the vocabulary dictionary.
+type Repository interface {
+	Search(ctx context.Context, params pagination.Params, query string) ([]dao.Entry, int64, error)
+	EnsureSchema(ctx context.Context) error
+}
+
+// ImportRepository describes the bulk write operations used by dictionary importers.
+type ImportRepository interface {
+	// UpsertBatch inserts new entries and replaces changed ones. Entries whose content hash is unchanged are reported as skipped.
+	// When dryRun is true the changes are rolled back after counting.
+	UpsertBatch(ctx context.Context, entries []dao.Entry, dryRun bool) (dto.ImportResult, error)
+}
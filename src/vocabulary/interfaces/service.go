@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/vocabulary/dto"
+)
+
+// Service exposes vocabulary dictionary business logic.
+type Service interface {
+	Search(ctx context.Context, params pagination.Params, query string) ([]dto.Entry, int64, error)
+}
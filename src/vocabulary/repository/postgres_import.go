@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"gobackend/src/vocabulary/dao"
+	"gobackend/src/vocabulary/dto"
+	vocabularyinterfaces "gobackend/src/vocabulary/interfaces"
+)
+
+var _ vocabularyinterfaces.ImportRepository = (*PostgresRepository)(nil)
+
+// UpsertBatch writes the given entries in a single transaction.
+// An entry is only rewritten when its content hash differs from the stored one; its forms and senses are then replaced.
+func (r *PostgresRepository) UpsertBatch(ctx context.Context, entries []dao.Entry, dryRun bool) (dto.ImportResult, error) {
+	var result dto.ImportResult
+	if len(entries) == 0 {
+		return result, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	const upsertEntryQuery = `
+INSERT INTO vocabulary_entries AS e (ent_seq, is_common, content_hash)
+VALUES ($1, $2, $3)
+ON CONFLICT (ent_seq) DO UPDATE
+SET is_common = EXCLUDED.is_common,
+    content_hash = EXCLUDED.content_hash,
+    updated_at = NOW()
+WHERE e.content_hash IS DISTINCT FROM EXCLUDED.content_hash
+RETURNING id, (xmax = 0) AS inserted
+`
+
+	for _, entry := range entries {
+		var (
+			entryID  int64
+			inserted bool
+		)
+
+		err := tx.QueryRowContext(ctx, upsertEntryQuery, entry.Sequence, entry.IsCommon, entry.ContentHash).Scan(&entryID, &inserted)
+		if errors.Is(err, sql.ErrNoRows) {
+			result.Skipped++
+			continue
+		}
+		if err != nil {
+			return dto.ImportResult{}, err
+		}
+
+		if inserted {
+			result.Inserted++
+		} else {
+			result.Updated++
+			if err := deleteChildren(ctx, tx, entryID); err != nil {
+				return dto.ImportResult{}, err
+			}
+		}
+
+		if err := insertChildren(ctx, tx, entryID, entry); err != nil {
+			return dto.ImportResult{}, err
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dto.ImportResult{}, err
+	}
+
+	return result, nil
+}
+
+func deleteChildren(ctx context.Context, tx *sql.Tx, entryID int64) error {
+	queries := []string{
+		"DELETE FROM vocabulary_kanji_forms WHERE entry_id = $1",
+		"DELETE FROM vocabulary_kana_forms WHERE entry_id = $1",
+		"DELETE FROM vocabulary_senses WHERE entry_id = $1",
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, entryID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertChildren(ctx context.Context, tx *sql.Tx, entryID int64, entry dao.Entry) error {
+	const kanjiQuery = `
+INSERT INTO vocabulary_kanji_forms (entry_id, position, text, info, priorities)
+VALUES ($1, $2, $3, $4, $5)
+`
+	for position, form := range entry.KanjiForms {
+		if _, err := tx.ExecContext(ctx, kanjiQuery, entryID, position, form.Text, pq.Array(form.Info), pq.Array(form.Priorities)); err != nil {
+			return err
+		}
+	}
+
+	const kanaQuery = `
+INSERT INTO vocabulary_kana_forms (entry_id, position, text, romaji, no_kanji, restrictions, info, priorities)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+	for position, form := range entry.KanaForms {
+		if _, err := tx.ExecContext(
+			ctx,
+			kanaQuery,
+			entryID,
+			position,
+			form.Text,
+			form.Romaji,
+			form.NoKanji,
+			pq.Array(form.Restrictions),
+			pq.Array(form.Info),
+			pq.Array(form.Priorities),
+		); err != nil {
+			return err
+		}
+	}
+
+	const senseQuery = `
+INSERT INTO vocabulary_senses (entry_id, position, parts_of_speech, glosses, misc, fields, info)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+	for position, sense := range entry.Senses {
+		if _, err := tx.ExecContext(
+			ctx,
+			senseQuery,
+			entryID,
+			position,
+			pq.Array(sense.PartsOfSpeech),
+			pq.Array(sense.Glosses),
+			pq.Array(sense.Misc),
+			pq.Array(sense.Fields),
+			pq.Array(sense.Info),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"gobackend/shared/pagination"
+	"gobackend/shared/utils"
+	"gobackend/src/vocabulary/dao"
+	vocabularyinterfaces "gobackend/src/vocabulary/interfaces"
+)
+
+var _ vocabularyinterfaces.Repository = (*PostgresRepository)(nil)
+
+// searchMatchesCTE collects candidate entries from the indexed form and gloss columns first, so only the
+// candidates are ranked instead of every entry in the dictionary.
+const searchMatchesCTE = `
+WITH candidates AS (
+    SELECT entry_id FROM vocabulary_kanji_forms WHERE text LIKE $3
+    UNION
+    SELECT entry_id FROM vocabulary_kana_forms WHERE text LIKE $3 OR replace(romaji, '''', '') LIKE $4
+    UNION
+    SELECT entry_id FROM vocabulary_senses WHERE vocabulary_gloss_text(glosses) ILIKE $5
+),
+matches AS (
+    SELECT e.id,
+           e.ent_seq,
+           e.is_common,
+           e.content_hash,
+           e.created_at,
+           e.updated_at,
+           (
+               EXISTS (SELECT 1 FROM vocabulary_kanji_forms f WHERE f.entry_id = e.id AND f.text = $1)
+               OR EXISTS (SELECT 1 FROM vocabulary_kana_forms f WHERE f.entry_id = e.id AND (f.text = $1 OR replace(f.romaji, '''', '') = $2))
+               OR EXISTS (SELECT 1 FROM vocabulary_senses s, unnest(s.glosses) AS gloss WHERE s.entry_id = e.id AND lower(gloss) = lower($1))
+           ) AS exact
+    FROM vocabulary_entries e
+    JOIN candidates c ON c.entry_id = e.id
+)`
+
+// PostgresRepository implements vocabulary dictionary queries against Postgres.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new vocabulary repository.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// EnsureSchema verifies that required tables and indexes exist.
+func (r *PostgresRepository) EnsureSchema(ctx context.Context) error {
+	const tableQuery = `
+SELECT 1
+FROM information_schema.tables
+WHERE table_schema = 'public' AND table_name = $1
+`
+
+	tables := []string{
+		"vocabulary_entries",
+		"vocabulary_kanji_forms",
+		"vocabulary_kana_forms",
+		"vocabulary_senses",
+	}
+
+	for _, table := range tables {
+		var exists int
+		if err := r.db.QueryRowContext(ctx, tableQuery, table).Scan(&exists); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%s table not found; please run database migrations", table)
+			}
+			return err
+		}
+	}
+
+	const indexQuery = `
+SELECT 1
+FROM pg_indexes
+WHERE schemaname = 'public' AND tablename = 'vocabulary_senses' AND indexname = 'vocabulary_senses_glosses_trgm_idx'
+`
+
+	var exists int
+	if err := r.db.QueryRowContext(ctx, indexQuery).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("index vocabulary_senses_glosses_trgm_idx not found; please run database migrations")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Search finds entries whose kanji, kana, romaji or English glosses match the query.
+// Exact matches rank first, then common words, then JMdict sequence order.
+func (r *PostgresRepository) Search(ctx context.Context, params pagination.Params, query string) ([]dao.Entry, int64, error) {
+	escaped := utils.EscapeLike(query)
+	romaji := strings.ToLower(strings.ReplaceAll(query, "'", ""))
+	args := []interface{}{
+		query,
+		romaji,
+		escaped + "%",
+		utils.EscapeLike(romaji) + "%",
+		"%" + escaped + "%",
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, searchMatchesCTE+" SELECT COUNT(*) FROM matches", args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	pageQuery := fmt.Sprintf(
+		"%s SELECT id, ent_seq, is_common, content_hash, created_at, updated_at FROM matches ORDER BY exact DESC, is_common DESC, ent_seq ASC LIMIT $%d OFFSET $%d",
+		searchMatchesCTE,
+		len(args)+1,
+		len(args)+2,
+	)
+	args = append(args, params.Limit(), params.Offset())
+
+	rows, err := r.db.QueryContext(ctx, pageQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var (
+		entries []dao.Entry
+		ids     []int64
+	)
+	for rows.Next() {
+		var entry dao.Entry
+		if err := rows.Scan(&entry.ID, &entry.Sequence, &entry.IsCommon, &entry.ContentHash, &entry.CreatedAt, &entry.UpdatedAt); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+		ids = append(ids, entry.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if len(entries) == 0 {
+		return entries, total, nil
+	}
+
+	if err := r.loadChildren(ctx, ids, entries); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+func (r *PostgresRepository) loadChildren(ctx context.Context, ids []int64, entries []dao.Entry) error {
+	index := make(map[int64]*dao.Entry, len(entries))
+	for i := range entries {
+		index[entries[i].ID] = &entries[i]
+	}
+
+	const kanjiQuery = `
+SELECT entry_id, text, info, priorities
+FROM vocabulary_kanji_forms
+WHERE entry_id = ANY($1)
+ORDER BY entry_id, position
+`
+	kanjiRows, err := r.db.QueryContext(ctx, kanjiQuery, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer kanjiRows.Close()
+
+	for kanjiRows.Next() {
+		var (
+			entryID int64
+			form    dao.KanjiForm
+		)
+		if err := kanjiRows.Scan(&entryID, &form.Text, pq.Array(&form.Info), pq.Array(&form.Priorities)); err != nil {
+			return err
+		}
+		index[entryID].KanjiForms = append(index[entryID].KanjiForms, form)
+	}
+	if err := kanjiRows.Err(); err != nil {
+		return err
+	}
+
+	const kanaQuery = `
+SELECT entry_id, text, romaji, no_kanji, restrictions, info, priorities
+FROM vocabulary_kana_forms
+WHERE entry_id = ANY($1)
+ORDER BY entry_id, position
+`
+	kanaRows, err := r.db.QueryContext(ctx, kanaQuery, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer kanaRows.Close()
+
+	for kanaRows.Next() {
+		var (
+			entryID int64
+			form    dao.KanaForm
+		)
+		if err := kanaRows.Scan(
+			&entryID,
+			&form.Text,
+			&form.Romaji,
+			&form.NoKanji,
+			pq.Array(&form.Restrictions),
+			pq.Array(&form.Info),
+			pq.Array(&form.Priorities),
+		); err != nil {
+			return err
+		}
+		index[entryID].KanaForms = append(index[entryID].KanaForms, form)
+	}
+	if err := kanaRows.Err(); err != nil {
+		return err
+	}
+
+	const senseQuery = `
+SELECT entry_id, parts_of_speech, glosses, misc, fields, info
+FROM vocabulary_senses
+WHERE entry_id = ANY($1)
+ORDER BY entry_id, position
+`
+	senseRows, err := r.db.QueryContext(ctx, senseQuery, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer senseRows.Close()
+
+	for senseRows.Next() {
+		var (
+			entryID int64
+			sense   dao.Sense
+		)
+		if err := senseRows.Scan(
+			&entryID,
+			pq.Array(&sense.PartsOfSpeech),
+			pq.Array(&sense.Glosses),
+			pq.Array(&sense.Misc),
+			pq.Array(&sense.Fields),
+			pq.Array(&sense.Info),
+		); err != nil {
+			return err
+		}
+		index[entryID].Senses = append(index[entryID].Senses, sense)
+	}
+
+	return senseRows.Err()
+}
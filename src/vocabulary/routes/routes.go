@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	vocabularydelivery "gobackend/src/vocabulary/delivery"
+)
+
+// Register mounts vocabulary endpoints on the router.
+func Register(router gin.IRoutes, handler *vocabularydelivery.Handler) {
+	router.GET("/api/vocabulary/search", handler.Search)
+}
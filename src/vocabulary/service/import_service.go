@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"gobackend/src/vocabulary/dao"
+	"gobackend/src/vocabulary/dto"
+	"gobackend/src/vocabulary/importer"
+	vocabularyinterfaces "gobackend/src/vocabulary/interfaces"
+)
+
+const defaultImportBatchSize = 500
+
+// ImportOptions controls a JMdict import run.
+type ImportOptions struct {
+	BatchSize int
+	DryRun    bool
+}
+
+// ImportService loads JMdict data into the vocabulary tables.
+type ImportService struct {
+	repo vocabularyinterfaces.ImportRepository
+}
+
+// NewImportService constructs a new ImportService.
+func NewImportService(repo vocabularyinterfaces.ImportRepository) *ImportService {
+	return &ImportService{repo: repo}
+}
+
+// ImportJMdict streams the JMdict document and upserts its entries in batches.
+// Entries without a sequence number, reading or English sense, and repeated sequences, are counted as skipped.
+func (s *ImportService) ImportJMdict(ctx context.Context, r io.Reader, opts ImportOptions) (dto.ImportResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultImportBatchSize
+	}
+
+	var (
+		result dto.ImportResult
+		batch  = make([]dao.Entry, 0, opts.BatchSize)
+		seen   = make(map[int64]struct{})
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		batchResult, err := s.repo.UpsertBatch(ctx, batch, opts.DryRun)
+		if err != nil {
+			return err
+		}
+
+		result.Add(batchResult)
+		log.Printf("jmdict import progress: inserted=%d updated=%d skipped=%d", result.Inserted, result.Updated, result.Skipped)
+		batch = batch[:0]
+		return nil
+	}
+
+	err := importer.DecodeJMdict(r, func(entry dao.Entry) error {
+		if entry.Sequence <= 0 || len(entry.KanaForms) == 0 || len(entry.Senses) == 0 {
+			result.Skipped++
+			return nil
+		}
+
+		if _, duplicate := seen[entry.Sequence]; duplicate {
+			result.Skipped++
+			return nil
+		}
+		seen[entry.Sequence] = struct{}{}
+
+		batch = append(batch, entry)
+		if len(batch) >= opts.BatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gobackend/shared/pagination"
+	"gobackend/src/vocabulary/dao"
+	"gobackend/src/vocabulary/dto"
+	vocabularyinterfaces "gobackend/src/vocabulary/interfaces"
+)
+
+var _ vocabularyinterfaces.Service = (*VocabularyService)(nil)
+
+// ErrEmptyQuery indicates a search was requested without a search term.
+var ErrEmptyQuery = errors.New("search query is required")
+
+// VocabularyService provides dictionary lookups.
+type VocabularyService struct {
+	repo vocabularyinterfaces.Repository
+}
+
+// NewVocabularyService constructs a new VocabularyService.
+func NewVocabularyService(repo vocabularyinterfaces.Repository) *VocabularyService {
+	return &VocabularyService{repo: repo}
+}
+
+// Search looks up entries by kanji, kana, romaji or English gloss.
+func (s *VocabularyService) Search(ctx context.Context, params pagination.Params, query string) ([]dto.Entry, int64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, 0, ErrEmptyQuery
+	}
+
+	entries, total, err := s.repo.Search(ctx, params, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]dto.Entry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, toDTO(entry))
+	}
+
+	return result, total, nil
+}
+
+func toDTO(entry dao.Entry) dto.Entry {
+	result := dto.Entry{
+		Sequence:   entry.Sequence,
+		IsCommon:   entry.IsCommon,
+		KanjiForms: make([]dto.KanjiForm, 0, len(entry.KanjiForms)),
+		KanaForms:  make([]dto.KanaForm, 0, len(entry.KanaForms)),
+		Senses:     make([]dto.Sense, 0, len(entry.Senses)),
+	}
+
+	for _, form := range entry.KanjiForms {
+		result.KanjiForms = append(result.KanjiForms, dto.KanjiForm{
+			Text:       form.Text,
+			Info:       form.Info,
+			Priorities: form.Priorities,
+		})
+	}
+
+	for _, form := range entry.KanaForms {
+		result.KanaForms = append(result.KanaForms, dto.KanaForm{
+			Text:         form.Text,
+			Romaji:       form.Romaji,
+			NoKanji:      form.NoKanji,
+			Restrictions: form.Restrictions,
+			Info:         form.Info,
+			Priorities:   form.Priorities,
+		})
+	}
+
+	for _, sense := range entry.Senses {
+		result.Senses = append(result.Senses, dto.Sense{
+			PartsOfSpeech: sense.PartsOfSpeech,
+			Glosses:       sense.Glosses,
+			Misc:          sense.Misc,
+			Fields:        sense.Fields,
+			Info:          sense.Info,
+		})
+	}
+
+	return result
+}